@@ -10,7 +10,9 @@ require (
 	github.com/goccy/go-yaml v1.18.0
 	github.com/mark3labs/mcp-go v0.18.0
 	github.com/skanehira/clipboard-image v1.0.0
+	github.com/xuri/efp v0.0.0-20240408161823-9ad904a10d6d
 	github.com/xuri/excelize/v2 v2.9.0
+	golang.org/x/text v0.19.0
 )
 
 require (
@@ -18,12 +20,10 @@ require (
 	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
 	github.com/richardlehane/mscfb v1.0.4 // indirect
 	github.com/richardlehane/msoleps v1.0.4 // indirect
-	github.com/xuri/efp v0.0.0-20240408161823-9ad904a10d6d // indirect
 	github.com/xuri/nfp v0.0.0-20240318013403-ab9948c2c4a7 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 	golang.org/x/crypto v0.28.0 // indirect
 	golang.org/x/exp v0.0.0-20240613232115-7f521ea00fb8 // indirect
 	golang.org/x/net v0.30.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
-	golang.org/x/text v0.19.0 // indirect
 )
@@ -0,0 +1,378 @@
+package excel
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// AutoFilterType is the type of the filter criteria of a column
+type AutoFilterType string
+
+const (
+	AutoFilterEquals   AutoFilterType = "equals"
+	AutoFilterContains AutoFilterType = "contains"
+	AutoFilterTopN     AutoFilterType = "topN"
+	AutoFilterCustom   AutoFilterType = "custom"
+)
+
+// AutoFilter is the auto filter of a worksheet. The first row of the range is the header row.
+type AutoFilter struct {
+	Range   string
+	Columns []AutoFilterColumn
+}
+
+// AutoFilterColumn is the filter criteria of a column in the auto filter range
+type AutoFilterColumn struct {
+	Column     string // column name in the sheet (e.g., "B")
+	Type       AutoFilterType
+	Values     []string // equals: values to show. An empty value shows blank cells.
+	Text       string   // contains: text contained in the values
+	Count      int      // topN: number of items, or percent if Percent is true
+	Bottom     bool     // topN: show the bottom items instead of the top items
+	Percent    bool     // topN
+	Expression string   // custom: one or two conditions joined by "and" or "or" (e.g., ">= 10 and < 20", "<> *draft*")
+}
+
+// filterCondition is a condition of a custom filter
+type filterCondition struct {
+	Operator string // =, <>, >, >=, <, <=
+	Value    string
+}
+
+var filterConditionPattern = regexp.MustCompile(`^\s*(?:[A-Za-z_]\w*\s*)?(==|=|!=|<>|>=|<=|>|<)\s*(.*?)\s*$`)
+
+// filterConditionJoinPattern matches "and" or "or" followed by the second condition
+var filterConditionJoinPattern = regexp.MustCompile(`(?i)^(.*?)\s+(and|or)\s+((?:[A-Za-z_]\w*\s*)?(?:==|=|!=|<>|>=|<=|>|<).*)$`)
+
+// parseFilterExpression parses a custom filter expression into one or two conditions and whether they are joined by "and".
+// A placeholder before the operator is allowed as in Excelize (e.g., "x >= 10 and x < 20").
+func parseFilterExpression(expression string) ([]filterCondition, bool, error) {
+	parts, and := []string{expression}, false
+	if matches := filterConditionJoinPattern.FindStringSubmatch(expression); matches != nil {
+		parts, and = []string{matches[1], matches[3]}, strings.EqualFold(matches[2], "and")
+	}
+	conditions := make([]filterCondition, len(parts))
+	for i, part := range parts {
+		matches := filterConditionPattern.FindStringSubmatch(part)
+		if matches == nil {
+			return nil, false, fmt.Errorf("invalid filter expression: %s", expression)
+		}
+		operator := matches[1]
+		switch operator {
+		case "==":
+			operator = "="
+		case "!=":
+			operator = "<>"
+		}
+		value := matches[2]
+		if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+			value = value[1 : len(value)-1]
+		}
+		conditions[i] = filterCondition{Operator: operator, Value: value}
+	}
+	return conditions, and, nil
+}
+
+// formatFilterExpression formats the conditions as a custom filter expression
+func formatFilterExpression(conditions []filterCondition, and bool) string {
+	parts := make([]string, len(conditions))
+	for i, condition := range conditions {
+		parts[i] = condition.Operator + " " + condition.Value
+	}
+	if and {
+		return strings.Join(parts, " and ")
+	}
+	return strings.Join(parts, " or ")
+}
+
+// matchFilterCondition reports whether the cell matches the condition as Excel does.
+// text is the formatted value of the cell and raw is the raw value.
+func matchFilterCondition(condition filterCondition, text string, raw string) bool {
+	value, valueErr := strconv.ParseFloat(condition.Value, 64)
+	number, numberErr := strconv.ParseFloat(raw, 64)
+	numeric := valueErr == nil && numberErr == nil
+	switch condition.Operator {
+	case "=":
+		return (numeric && number == value) || matchWildcard(condition.Value, text)
+	case "<>":
+		return !((numeric && number == value) || matchWildcard(condition.Value, text))
+	}
+	var compared int
+	switch {
+	case numeric:
+		compared = int(math.Copysign(1, number-value))
+		if number == value {
+			compared = 0
+		}
+	case valueErr != nil && numberErr != nil && text != "":
+		compared = strings.Compare(strings.ToLower(text), strings.ToLower(condition.Value))
+	default:
+		// Numbers and text are not comparable
+		return false
+	}
+	switch condition.Operator {
+	case ">":
+		return compared > 0
+	case ">=":
+		return compared >= 0
+	case "<":
+		return compared < 0
+	default:
+		return compared <= 0
+	}
+}
+
+// matchWildcard reports whether the text matches the pattern case-insensitively.
+// "*" matches any characters, "?" matches a character and "~" escapes them.
+func matchWildcard(pattern string, text string) bool {
+	var expression strings.Builder
+	expression.WriteString("(?is)^")
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '~' && i+1 < len(runes):
+			i++
+			expression.WriteString(regexp.QuoteMeta(string(runes[i])))
+		case runes[i] == '*':
+			expression.WriteString(".*")
+		case runes[i] == '?':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(runes[i])))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String()).MatchString(text)
+}
+
+// escapeWildcard escapes the wildcard characters in the text
+func escapeWildcard(text string) string {
+	return strings.NewReplacer("~", "~~", "*", "~*", "?", "~?").Replace(text)
+}
+
+// topNThreshold returns the smallest (or the largest for the bottom items) number shown by the top N filter
+func topNThreshold(column AutoFilterColumn, numbers []float64) (float64, bool) {
+	if len(numbers) == 0 {
+		return 0, false
+	}
+	sorted := slices.Clone(numbers)
+	slices.Sort(sorted)
+	if !column.Bottom {
+		slices.Reverse(sorted)
+	}
+	count := column.Count
+	if column.Percent {
+		count = max(1, len(sorted)*column.Count/100)
+	}
+	count = min(max(count, 1), len(sorted))
+	return sorted[count-1], true
+}
+
+// autoFilterMatcher returns the function reporting whether a cell is shown by the filter criteria of the column.
+// text is the formatted value of the cell and raw is the raw value, which is empty for text cells.
+// numbers are the numeric values in the column used by the top N filter.
+func autoFilterMatcher(column AutoFilterColumn, numbers []float64) (func(text string, raw string) bool, error) {
+	switch column.Type {
+	case AutoFilterEquals:
+		return func(text string, raw string) bool {
+			return slices.ContainsFunc(column.Values, func(value string) bool { return strings.EqualFold(value, text) })
+		}, nil
+	case AutoFilterContains:
+		return func(text string, raw string) bool {
+			return strings.Contains(strings.ToLower(text), strings.ToLower(column.Text))
+		}, nil
+	case AutoFilterTopN:
+		threshold, ok := topNThreshold(column, numbers)
+		return func(text string, raw string) bool {
+			number, err := strconv.ParseFloat(raw, 64)
+			switch {
+			case !ok || err != nil:
+				return false
+			case column.Bottom:
+				return number <= threshold
+			default:
+				return number >= threshold
+			}
+		}, nil
+	case AutoFilterCustom:
+		conditions, and, err := parseFilterExpression(column.Expression)
+		if err != nil {
+			return nil, err
+		}
+		return func(text string, raw string) bool {
+			matched := matchFilterCondition(conditions[0], text, raw)
+			switch {
+			case len(conditions) == 1:
+				return matched
+			case and:
+				return matched && matchFilterCondition(conditions[1], text, raw)
+			default:
+				return matched || matchFilterCondition(conditions[1], text, raw)
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid filter type: %s", column.Type)
+	}
+}
+
+// The types below mirror the autoFilter element of a worksheet, which Excelize can neither read nor write with top N filters.
+
+type xlsxAutoFilter struct {
+	XMLName      xml.Name           `xml:"autoFilter"`
+	Ref          string             `xml:"ref,attr"`
+	FilterColumn []xlsxFilterColumn `xml:"filterColumn"`
+}
+
+type xlsxFilterColumn struct {
+	ColID         int                `xml:"colId,attr"`
+	Filters       *xlsxFilters       `xml:"filters"`
+	Top10         *xlsxTop10         `xml:"top10"`
+	CustomFilters *xlsxCustomFilters `xml:"customFilters"`
+}
+
+type xlsxFilters struct {
+	Blank  bool         `xml:"blank,attr,omitempty"`
+	Filter []xlsxFilter `xml:"filter"`
+}
+
+type xlsxFilter struct {
+	Val string `xml:"val,attr"`
+}
+
+type xlsxTop10 struct {
+	Top       string  `xml:"top,attr,omitempty"` // "0" or "false" for the bottom items
+	Percent   bool    `xml:"percent,attr,omitempty"`
+	Val       float64 `xml:"val,attr"`
+	FilterVal float64 `xml:"filterVal,attr,omitempty"`
+}
+
+type xlsxCustomFilters struct {
+	And          bool               `xml:"and,attr,omitempty"`
+	CustomFilter []xlsxCustomFilter `xml:"customFilter"`
+}
+
+type xlsxCustomFilter struct {
+	Operator string `xml:"operator,attr,omitempty"`
+	Val      string `xml:"val,attr"`
+}
+
+// filterOperators maps the operators of the custom filter expression to the operators of customFilter elements
+var filterOperators = map[string]string{
+	"=":  "equal",
+	"<>": "notEqual",
+	">":  "greaterThan",
+	">=": "greaterThanOrEqual",
+	"<":  "lessThan",
+	"<=": "lessThanOrEqual",
+}
+
+// toXlsxFilterColumn converts the filter criteria of a column into a filterColumn element
+func toXlsxFilterColumn(column AutoFilterColumn, colID int, numbers []float64) (xlsxFilterColumn, error) {
+	filterColumn := xlsxFilterColumn{ColID: colID}
+	switch column.Type {
+	case AutoFilterEquals:
+		filterColumn.Filters = &xlsxFilters{}
+		for _, value := range column.Values {
+			if value == "" {
+				filterColumn.Filters.Blank = true
+			} else {
+				filterColumn.Filters.Filter = append(filterColumn.Filters.Filter, xlsxFilter{Val: value})
+			}
+		}
+	case AutoFilterContains:
+		filterColumn.CustomFilters = &xlsxCustomFilters{CustomFilter: []xlsxCustomFilter{
+			{Val: "*" + escapeWildcard(column.Text) + "*"},
+		}}
+	case AutoFilterTopN:
+		// The top attribute is always written since Excelize reads a missing one as false
+		top10 := &xlsxTop10{Top: "1", Percent: column.Percent, Val: float64(column.Count)}
+		if column.Bottom {
+			top10.Top = "0"
+		}
+		top10.FilterVal, _ = topNThreshold(column, numbers)
+		filterColumn.Top10 = top10
+	case AutoFilterCustom:
+		conditions, and, err := parseFilterExpression(column.Expression)
+		if err != nil {
+			return filterColumn, err
+		}
+		filterColumn.CustomFilters = &xlsxCustomFilters{And: and && len(conditions) > 1}
+		for _, condition := range conditions {
+			filterColumn.CustomFilters.CustomFilter = append(filterColumn.CustomFilters.CustomFilter, xlsxCustomFilter{
+				Operator: filterOperators[condition.Operator],
+				Val:      condition.Value,
+			})
+		}
+	default:
+		return filterColumn, fmt.Errorf("invalid filter type: %s", column.Type)
+	}
+	return filterColumn, nil
+}
+
+// fromXlsxFilterColumn converts a filterColumn element into the filter criteria of a column
+func fromXlsxFilterColumn(filterColumn xlsxFilterColumn, startCol int) AutoFilterColumn {
+	columnName, _ := excelize.ColumnNumberToName(startCol + filterColumn.ColID)
+	column := AutoFilterColumn{Column: columnName}
+	switch {
+	case filterColumn.Filters != nil:
+		column.Type = AutoFilterEquals
+		for _, filter := range filterColumn.Filters.Filter {
+			column.Values = append(column.Values, filter.Val)
+		}
+		if filterColumn.Filters.Blank {
+			column.Values = append(column.Values, "")
+		}
+	case filterColumn.Top10 != nil:
+		column.Type = AutoFilterTopN
+		column.Count = int(filterColumn.Top10.Val)
+		column.Bottom = filterColumn.Top10.Top == "0" || filterColumn.Top10.Top == "false"
+		column.Percent = filterColumn.Top10.Percent
+	case filterColumn.CustomFilters != nil:
+		customFilters := filterColumn.CustomFilters.CustomFilter
+		if len(customFilters) == 1 && (customFilters[0].Operator == "" || customFilters[0].Operator == "equal") {
+			if text, ok := containsFilterText(customFilters[0].Val); ok {
+				column.Type = AutoFilterContains
+				column.Text = text
+				return column
+			}
+		}
+		conditions := make([]filterCondition, len(customFilters))
+		for i, customFilter := range customFilters {
+			conditions[i] = filterCondition{Operator: "=", Value: customFilter.Val}
+			for operator, name := range filterOperators {
+				if name == customFilter.Operator {
+					conditions[i].Operator = operator
+				}
+			}
+		}
+		column.Type = AutoFilterCustom
+		column.Expression = formatFilterExpression(conditions, filterColumn.CustomFilters.And)
+	}
+	return column
+}
+
+// containsFilterText returns the text of the contains filter, which is saved as a wildcard pattern (e.g., *text*).
+// ok is false if the pattern is not a contains filter.
+func containsFilterText(pattern string) (string, bool) {
+	text, ok := strings.CutPrefix(pattern, "*")
+	if !ok || !strings.HasSuffix(text, "*") || len(text) < 2 {
+		return "", false
+	}
+	// The text must not contain unescaped wildcard characters
+	if text = text[:len(text)-1]; escapeWildcard(unescapeWildcard(text)) != text {
+		return "", false
+	}
+	return unescapeWildcard(text), true
+}
+
+// unescapeWildcard removes the escape characters of the wildcard characters
+func unescapeWildcard(text string) string {
+	return strings.NewReplacer("~~", "~", "~*", "*", "~?", "?").Replace(text)
+}
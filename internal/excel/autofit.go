@@ -0,0 +1,53 @@
+package excel
+
+import (
+	"math"
+	"strings"
+
+	"golang.org/x/text/width"
+)
+
+const (
+	// defaultFontSize is the font size of the default style of a workbook in points
+	defaultFontSize = 11.0
+	// maxColumnWidth is the maximum column width Excel accepts in characters
+	maxColumnWidth = 255.0
+	// maxRowHeight is the maximum row height Excel accepts in points
+	maxRowHeight = 409.0
+	// autoFitPadding is the margin added to the widest text of a column in characters
+	autoFitPadding = 1.5
+)
+
+// textDisplayWidth returns the width of the longest line of the text in characters of the default font.
+// East Asian wide characters count as two characters.
+func textDisplayWidth(text string) float64 {
+	maxWidth := 0.0
+	for _, line := range strings.Split(text, "\n") {
+		lineWidth := 0.0
+		for _, r := range line {
+			switch width.LookupRune(r).Kind() {
+			case width.EastAsianWide, width.EastAsianFullwidth:
+				lineWidth += 2
+			default:
+				lineWidth++
+			}
+		}
+		maxWidth = max(maxWidth, lineWidth)
+	}
+	return maxWidth
+}
+
+// estimateColumnWidth estimates the column width in characters needed to show the text in the font
+func estimateColumnWidth(text string, fontSize float64, bold bool) float64 {
+	columnWidth := textDisplayWidth(text) * fontSize / defaultFontSize
+	if bold {
+		columnWidth *= 1.1
+	}
+	return min(math.Round((columnWidth+autoFitPadding)*100)/100, maxColumnWidth)
+}
+
+// estimateRowHeight estimates the row height in points needed to show the lines of text in the font.
+// A line of the default font needs 15 points.
+func estimateRowHeight(lines int, fontSize float64) float64 {
+	return min(math.Round(float64(max(lines, 1))*fontSize*15/defaultFontSize*100)/100, maxRowHeight)
+}
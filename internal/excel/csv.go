@@ -0,0 +1,217 @@
+package excel
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// CSVEncoding is the character encoding of a CSV file
+type CSVEncoding string
+
+const (
+	CSVEncodingUTF8     CSVEncoding = "utf-8"     // with or without BOM
+	CSVEncodingShiftJIS CSVEncoding = "shift_jis" // Windows-31J (code page 932)
+	CSVEncodingUTF16    CSVEncoding = "utf-16"    // with BOM, which tells the byte order
+)
+
+// CSVImportOptions specifies how to import a CSV file into a worksheet
+type CSVImportOptions struct {
+	Path       string // absolute path to the CSV file
+	Delimiter  rune
+	Encoding   CSVEncoding
+	StartCell  string // top-left cell to write to
+	HasHeader  bool   // the first row is a header row, which is always imported as text
+	InferTypes bool   // import numbers, booleans and dates as typed values instead of text
+}
+
+// openCSVReader opens the CSV file decoding it into UTF-8
+func openCSVReader(options *CSVImportOptions) (*csv.Reader, io.Closer, error) {
+	file, err := os.Open(options.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var reader io.Reader
+	switch options.Encoding {
+	case CSVEncodingShiftJIS:
+		reader = transform.NewReader(file, japanese.ShiftJIS.NewDecoder())
+	case CSVEncodingUTF16:
+		reader = transform.NewReader(file, unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder())
+	default:
+		buffered := bufio.NewReader(file)
+		// Skip the BOM written by Excel and other Windows applications
+		if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+			buffered.Discard(3)
+		}
+		reader = buffered
+	}
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = options.Delimiter
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	csvReader.ReuseRecord = true
+	return csvReader, file, nil
+}
+
+// measureCSV returns the number of records and the maximum number of fields of the records in the CSV file
+func measureCSV(options *CSVImportOptions) (int, int, error) {
+	reader, closer, err := openCSVReader(options)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer closer.Close()
+	rows, columns := 0, 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, columns, nil
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to read CSV file: %w", err)
+		}
+		rows++
+		columns = max(columns, len(record))
+	}
+}
+
+// csvNumberPattern matches the numbers to infer. Numbers with leading zeros such as codes and zip codes are left as text.
+var csvNumberPattern = regexp.MustCompile(`^-?(?:0|[1-9][0-9]*)(?:\.[0-9]+)?(?:[eE][-+]?[0-9]+)?$`)
+
+// csvDateLayouts are the layouts of the dates to infer, with whether they have no time part
+var csvDateLayouts = []struct {
+	layout   string
+	dateOnly bool
+}{
+	{"2006-01-02", true},
+	{"2006/01/02", true},
+	{"2006/1/2", true},
+	{"2006-01-02 15:04:05", false},
+	{"2006-01-02 15:04", false},
+	{"2006-01-02T15:04:05", false},
+	{"2006-01-02T15:04:05Z07:00", false},
+	{"2006/01/02 15:04:05", false},
+	{"2006/1/2 15:04:05", false},
+	{"2006/01/02 15:04", false},
+	{"2006/1/2 15:04", false},
+}
+
+// inferCSVValue converts the text of a CSV field into a number, a boolean or a date if it looks like one.
+// It returns the text itself otherwise, and whether a date has no time part.
+func inferCSVValue(text string) (any, bool) {
+	if csvNumberPattern.MatchString(text) {
+		if number, err := strconv.ParseFloat(text, 64); err == nil {
+			return number, false
+		}
+	}
+	if strings.EqualFold(text, "true") || strings.EqualFold(text, "false") {
+		return strings.EqualFold(text, "true"), false
+	}
+	for _, layout := range csvDateLayouts {
+		if t, err := time.Parse(layout.layout, text); err == nil {
+			return t, layout.dateOnly
+		}
+	}
+	return text, false
+}
+
+// ParseCSVDelimiter parses the delimiter name or character (e.g., ",", ";", "tab")
+func ParseCSVDelimiter(delimiter string) (rune, error) {
+	switch delimiter {
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	runes := []rune(delimiter)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' {
+		return 0, fmt.Errorf("invalid delimiter: %s", delimiter)
+	}
+	return runes[0], nil
+}
+
+// CSVQuoting specifies which fields of an exported CSV file are quoted
+type CSVQuoting string
+
+const (
+	CSVQuotingMinimal    CSVQuoting = "minimal"    // only fields containing the delimiter, quotes or line breaks
+	CSVQuotingAll        CSVQuoting = "all"        // all fields
+	CSVQuotingNonNumeric CSVQuoting = "nonnumeric" // all fields except numbers
+)
+
+// CSVExportOptions specifies how to export a range of a worksheet into a CSV file
+type CSVExportOptions struct {
+	Path       string // absolute path to the CSV file
+	Range      string // range of cells to export (e.g., A1:C10)
+	Delimiter  rune
+	Raw        bool // export the underlying values instead of the formatted values
+	Quoting    CSVQuoting
+	LineEnding string // "\n" or "\r\n"
+}
+
+// ExportCSV writes the values of the range into the CSV file, replacing the file if it exists.
+// It returns the number of rows and columns written.
+func ExportCSV(worksheet Worksheet, options *CSVExportOptions) (int, int, error) {
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(options.Range)
+	if err != nil {
+		return 0, 0, err
+	}
+	file, err := os.Create(options.Path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cell, _ := excelize.CoordinatesToCellName(col, row)
+			typedValue, err := worksheet.GetTypedValue(cell)
+			if err != nil {
+				return 0, 0, err
+			}
+			value := typedValue.Formatted
+			if options.Raw {
+				value = typedValue.Raw
+			}
+			if col > startCol {
+				writer.WriteRune(options.Delimiter)
+			}
+			writeCSVField(writer, value, options)
+		}
+		writer.WriteString(options.LineEnding)
+	}
+	if err := writer.Flush(); err != nil {
+		return 0, 0, err
+	}
+	return endRow - startRow + 1, endCol - startCol + 1, file.Close()
+}
+
+// writeCSVField writes the field quoting it by the quoting rule. Quotes in quoted fields are doubled.
+func writeCSVField(writer *bufio.Writer, field string, options *CSVExportOptions) {
+	quote := strings.ContainsRune(field, options.Delimiter) || strings.ContainsAny(field, "\"\r\n")
+	switch options.Quoting {
+	case CSVQuotingAll:
+		quote = true
+	case CSVQuotingNonNumeric:
+		if !csvNumberPattern.MatchString(field) {
+			quote = true
+		}
+	}
+	if !quote {
+		writer.WriteString(field)
+		return
+	}
+	writer.WriteByte('"')
+	writer.WriteString(strings.ReplaceAll(field, `"`, `""`))
+	writer.WriteByte('"')
+}
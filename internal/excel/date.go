@@ -0,0 +1,146 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateValueKind is the kind of a date or time value written to cells
+type DateValueKind string
+
+const (
+	DateValueDate     DateValueKind = "date"     // e.g., 2024-03-31
+	DateValueDateTime DateValueKind = "datetime" // e.g., 2024-03-31T09:30:00
+	DateValueTime     DateValueKind = "time"     // e.g., 09:30:00
+	DateValueDuration DateValueKind = "duration" // e.g., PT1H30M
+)
+
+// dateValueNumFmts are the number formats applied to the cells of the date or time values
+var dateValueNumFmts = map[DateValueKind]string{
+	DateValueDate:     "yyyy-mm-dd",
+	DateValueDateTime: "yyyy-mm-dd hh:mm:ss",
+	DateValueTime:     "hh:mm:ss",
+	DateValueDuration: "[h]:mm:ss",
+}
+
+// DateValue is a date or time value parsed from an ISO 8601 string
+type DateValue struct {
+	Kind     DateValueKind
+	Time     time.Time     // for dates and datetimes
+	Duration time.Duration // for times (time of the day) and durations
+}
+
+// dateValueLayouts are the ISO 8601 layouts of the dates and datetimes. Time zone offsets are accepted but ignored,
+// because cells have no time zone.
+var dateValueLayouts = []struct {
+	layout string
+	kind   DateValueKind
+}{
+	{"2006-01-02", DateValueDate},
+	{"2006-01-02T15:04", DateValueDateTime},
+	{"2006-01-02T15:04:05", DateValueDateTime},
+	{"2006-01-02T15:04:05Z07:00", DateValueDateTime},
+	{"2006-01-02 15:04", DateValueDateTime},
+	{"2006-01-02 15:04:05", DateValueDateTime},
+}
+
+// timeOfDayPattern matches the times of the day (e.g., 09:30, 09:30:15.5)
+var timeOfDayPattern = regexp.MustCompile(`^([01][0-9]|2[0-3]):([0-5][0-9])(?::([0-5][0-9](?:\.[0-9]+)?))?$`)
+
+// durationPattern matches the ISO 8601 durations in weeks, days, hours, minutes and seconds (e.g., P1DT2H, PT90M, PT1.5S).
+// Years and months are not supported because their lengths vary.
+var durationPattern = regexp.MustCompile(`^(-)?P(?:([0-9]+(?:\.[0-9]+)?)W)?(?:([0-9]+(?:\.[0-9]+)?)D)?(?:T(?:([0-9]+(?:\.[0-9]+)?)H)?(?:([0-9]+(?:\.[0-9]+)?)M)?(?:([0-9]+(?:\.[0-9]+)?)S)?)?$`)
+
+// ParseDateValue parses the ISO 8601 string as the kind of value. An empty kind accepts any kind.
+// The datetime kind accepts dates as well, which are at midnight.
+func ParseDateValue(text string, kind DateValueKind) (*DateValue, error) {
+	if kind == "" || kind == DateValueDate || kind == DateValueDateTime {
+		for _, layout := range dateValueLayouts {
+			if kind == DateValueDate && layout.kind != DateValueDate {
+				continue
+			}
+			if t, err := time.Parse(layout.layout, text); err == nil {
+				// keep the local time in the string
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+				if kind == "" {
+					return &DateValue{Kind: layout.kind, Time: t}, nil
+				}
+				return &DateValue{Kind: kind, Time: t}, nil
+			}
+		}
+	}
+	if kind == "" || kind == DateValueTime {
+		if match := timeOfDayPattern.FindStringSubmatch(text); match != nil {
+			hours, _ := strconv.Atoi(match[1])
+			minutes, _ := strconv.Atoi(match[2])
+			seconds, _ := strconv.ParseFloat("0"+match[3], 64)
+			duration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second))
+			return &DateValue{Kind: DateValueTime, Duration: duration}, nil
+		}
+	}
+	if kind == "" || kind == DateValueDuration {
+		// A duration has at least one element, and the time designator T is followed by time elements
+		if match := durationPattern.FindStringSubmatch(text); match != nil && !strings.HasSuffix(text, "T") {
+			units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+			var duration time.Duration
+			elements := 0
+			for i, unit := range units {
+				if match[i+2] != "" {
+					value, _ := strconv.ParseFloat(match[i+2], 64)
+					duration += time.Duration(value * float64(unit))
+					elements++
+				}
+			}
+			if match[1] == "-" {
+				duration = -duration
+			}
+			if elements > 0 {
+				return &DateValue{Kind: DateValueDuration, Duration: duration}, nil
+			}
+		}
+	}
+	if kind == "" {
+		return nil, fmt.Errorf("not an ISO 8601 date, datetime, time or duration: %s", text)
+	}
+	return nil, fmt.Errorf("not an ISO 8601 %s: %s", kind, text)
+}
+
+// Serial returns the serial number of the value in the date system of the workbook.
+// Times and durations are fractions of days, which do not depend on the date system.
+func (v *DateValue) Serial(date1904 bool) (float64, error) {
+	if v.Kind == DateValueTime || v.Kind == DateValueDuration {
+		return v.Duration.Hours() / 24, nil
+	}
+	serial := DateToSerial(v.Time, date1904)
+	if serial < 0 {
+		if date1904 {
+			return 0, fmt.Errorf("dates before 1904-01-01 are not supported in the 1904 date system: %s", v.Time.Format(time.DateOnly))
+		}
+		return 0, fmt.Errorf("dates before 1900-01-01 are not supported: %s", v.Time.Format(time.DateOnly))
+	}
+	return serial, nil
+}
+
+// NumFmt returns the number format suitable for the kind of the value
+func (v *DateValue) NumFmt() string {
+	return dateValueNumFmts[v.Kind]
+}
+
+// DateToSerial converts the date and time into the serial number in the 1900 or 1904 date system.
+// In the 1900 date system, the serial numbers after February 28, 1900 count the nonexistent February 29, 1900
+// for the compatibility with Lotus 1-2-3.
+func DateToSerial(t time.Time, date1904 bool) float64 {
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	epoch := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		epoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	} else if t.Before(time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)) {
+		epoch = time.Date(1899, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	// time.Duration cannot hold the periods longer than about 292 years
+	seconds := float64(t.Unix()-epoch.Unix()) + float64(t.Nanosecond())/float64(time.Second)
+	return seconds / (24 * 60 * 60)
+}
@@ -0,0 +1,102 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDateValue(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, min, sec int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+	}
+	tests := []struct {
+		text    string
+		kind    DateValueKind
+		want    *DateValue
+		wantErr bool
+	}{
+		{"2024-03-31", "", &DateValue{Kind: DateValueDate, Time: date(2024, 3, 31, 0, 0, 0)}, false},
+		{"2024-03-31T09:30", "", &DateValue{Kind: DateValueDateTime, Time: date(2024, 3, 31, 9, 30, 0)}, false},
+		{"2024-03-31 09:30:15", "", &DateValue{Kind: DateValueDateTime, Time: date(2024, 3, 31, 9, 30, 15)}, false},
+		{"2024-03-31T09:30:00+09:00", "", &DateValue{Kind: DateValueDateTime, Time: date(2024, 3, 31, 9, 30, 0)}, false},
+		{"2024-03-31T09:30:00Z", DateValueDateTime, &DateValue{Kind: DateValueDateTime, Time: date(2024, 3, 31, 9, 30, 0)}, false},
+		{"2024-03-31", DateValueDateTime, &DateValue{Kind: DateValueDateTime, Time: date(2024, 3, 31, 0, 0, 0)}, false},
+		{"2024-03-31", DateValueDate, &DateValue{Kind: DateValueDate, Time: date(2024, 3, 31, 0, 0, 0)}, false},
+		{"2024-03-31T09:30", DateValueDate, nil, true},
+		{"2024-02-30", "", nil, true},
+		{"09:30", "", &DateValue{Kind: DateValueTime, Duration: 9*time.Hour + 30*time.Minute}, false},
+		{"23:59:59.5", DateValueTime, &DateValue{Kind: DateValueTime, Duration: 24*time.Hour - 500*time.Millisecond}, false},
+		{"24:00", DateValueTime, nil, true},
+		{"09:30", DateValueDate, nil, true},
+		{"PT1H30M", "", &DateValue{Kind: DateValueDuration, Duration: 90 * time.Minute}, false},
+		{"P1W", DateValueDuration, &DateValue{Kind: DateValueDuration, Duration: 7 * 24 * time.Hour}, false},
+		{"-P1DT2H", DateValueDuration, &DateValue{Kind: DateValueDuration, Duration: -26 * time.Hour}, false},
+		{"PT1.5S", DateValueDuration, &DateValue{Kind: DateValueDuration, Duration: 1500 * time.Millisecond}, false},
+		{"P", DateValueDuration, nil, true},
+		{"PT", DateValueDuration, nil, true},
+		{"P1DT", DateValueDuration, nil, true},
+		{"P1Y", DateValueDuration, nil, true},
+		{"PT1H", DateValueTime, nil, true},
+		{"tomorrow", "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.kind)+" "+tt.text, func(t *testing.T) {
+			got, err := ParseDateValue(tt.text, tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDateValue(%q, %q) error = %v, want error %v", tt.text, tt.kind, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseDateValue(%q, %q) = %+v, want %+v", tt.text, tt.kind, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateValueSerial(t *testing.T) {
+	tests := []struct {
+		text     string
+		date1904 bool
+		want     float64
+		wantErr  bool
+	}{
+		{"1900-01-01", false, 1, false},
+		{"1900-02-28", false, 59, false},
+		{"1900-03-01", false, 61, false},
+		{"2024-03-31", false, 45382, false},
+		{"2024-03-31T12:00", false, 45382.5, false},
+		{"2024-03-31", true, 43920, false},
+		{"1904-01-01", true, 0, false},
+		{"1899-12-30", false, 0, true},
+		{"1903-12-31", true, 0, true},
+		{"06:00", true, 0.25, false},
+		{"PT36H", false, 1.5, false},
+	}
+	for _, tt := range tests {
+		value, err := ParseDateValue(tt.text, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := value.Serial(tt.date1904)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Serial of %s (1904: %v) error = %v, want error %v", tt.text, tt.date1904, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Serial of %s (1904: %v) = %v, want %v", tt.text, tt.date1904, got, tt.want)
+		}
+	}
+}
+
+func TestDateValueNumFmt(t *testing.T) {
+	for kind, want := range map[DateValueKind]string{
+		DateValueDate:     "yyyy-mm-dd",
+		DateValueDateTime: "yyyy-mm-dd hh:mm:ss",
+		DateValueTime:     "hh:mm:ss",
+		DateValueDuration: "[h]:mm:ss",
+	} {
+		if got := (&DateValue{Kind: kind}).NumFmt(); got != want {
+			t.Errorf("NumFmt of %s = %q, want %q", kind, got, want)
+		}
+	}
+}
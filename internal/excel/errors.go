@@ -0,0 +1,27 @@
+package excel
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InvalidArgumentError is an error caused by the arguments of the caller rather than by the file or the backend,
+// so that tools can report it as an invalid argument.
+type InvalidArgumentError struct {
+	message string
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return e.message
+}
+
+// invalidArgumentErrorf formats an InvalidArgumentError
+func invalidArgumentErrorf(format string, a ...any) error {
+	return &InvalidArgumentError{message: fmt.Sprintf(format, a...)}
+}
+
+// IsInvalidArgument reports whether any error in the chain is an InvalidArgumentError
+func IsInvalidArgument(err error) bool {
+	var invalidArgumentError *InvalidArgumentError
+	return errors.As(err, &invalidArgumentError)
+}
@@ -1,6 +1,13 @@
 package excel
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
 	"github.com/xuri/excelize/v2"
 )
 
@@ -15,8 +22,37 @@ type Excel interface {
 	CreateNewSheet(sheetName string) error
 	// CopySheet copies a sheet from one to another.
 	CopySheet(srcSheetName, destSheetName string) error
+	// RenameSheet renames a sheet and rewrites the formulas referring to it.
+	RenameSheet(oldName, newName string) error
+	// DeleteSheet deletes a sheet. References to it in the formulas become #REF!.
+	DeleteSheet(sheetName string) error
+	// MoveSheet moves a sheet to the specified 1-based position.
+	MoveSheet(sheetName string, position int) error
+	// GetSheetVisibility returns the visibility of a sheet.
+	GetSheetVisibility(sheetName string) (SheetVisibility, error)
+	// SetSheetVisibility shows or hides a sheet.
+	SetSheetVisibility(sheetName string, visibility SheetVisibility) error
+	// GetTabColor returns the tab color of a sheet (e.g., #FF0000), or empty if it is not set.
+	GetTabColor(sheetName string) (string, error)
+	// SetTabColor sets the tab color of a sheet (e.g., #FF0000). An empty color clears it.
+	SetTabColor(sheetName string, color string) error
+	// FindSheetDependents returns the cells in other sheets whose formulas refer to the sheet (e.g., Sheet2!A1).
+	FindSheetDependents(sheetName string) ([]string, error)
+	// GetDefinedNames returns the defined names in the workbook.
+	GetDefinedNames() ([]DefinedName, error)
+	// SetDefinedName creates a defined name, or updates it if the name already exists in the scope.
+	SetDefinedName(definedName *DefinedName) error
+	// DeleteDefinedName deletes a defined name. An empty scope means the workbook scope.
+	DeleteDefinedName(name string, scope string) error
+	// Date1904 reports whether the workbook uses the 1904 date system instead of the 1900 date system.
+	Date1904() (bool, error)
+	// Recalculate calculates all formulas in the workbook and updates their cached values.
+	Recalculate() (*RecalculationResult, error)
 	// Save saves the Excel file.
 	Save() error
+	// SaveAs saves a copy of the workbook to another file in the format, leaving the original file untouched.
+	// It returns warnings about the content which the format cannot keep.
+	SaveAs(absolutePath string, format FileFormat) ([]string, error)
 }
 
 type Worksheet interface {
@@ -28,14 +64,20 @@ type Worksheet interface {
 	GetTables() ([]Table, error)
 	// GetPivotTable returns a pivot tables in this worksheet.
 	GetPivotTables() ([]PivotTable, error)
+	// GetCharts returns the charts in this worksheet.
+	GetCharts() ([]Chart, error)
 	// SetValue sets a value in the specified cell.
 	SetValue(cell string, value any) error
 	// SetFormula sets a formula in the specified cell.
 	SetFormula(cell string, formula string) error
 	// GetValue gets the value from the specified cell.
 	GetValue(cell string) (string, error)
+	// GetTypedValue gets the value from the specified cell with its type, underlying value and formatted text.
+	GetTypedValue(cell string) (*TypedValue, error)
 	// GetFormula gets the formula from the specified cell.
 	GetFormula(cell string) (string, error)
+	// TraceFormula returns the trees of the precedents and dependents of the cell up to the depth.
+	TraceFormula(cell string, depth int) (*FormulaTrace, error)
 	// GetDimention gets the dimension of the worksheet.
 	GetDimention() (string, error)
 	// GetPagingStrategy returns the paging strategy for the worksheet.
@@ -43,10 +85,78 @@ type Worksheet interface {
 	GetPagingStrategy(pageSize int) (PagingStrategy, error)
 	// CapturePicture returns base64 encoded image data of the specified range.
 	CapturePicture(captureRange string) (string, error)
+	// GetPictures returns the pictures placed in this worksheet.
+	GetPictures() ([]Picture, error)
+	// GetPictureData returns the image data and its format (e.g., png, jpeg) of the specified picture.
+	GetPictureData(name string) ([]byte, string, error)
+	// AddPicture adds a picture to this worksheet.
+	AddPicture(options *PictureOptions) error
 	// AddTable adds a table to this worksheet.
 	AddTable(tableRange, tableName string) error
+	// AddChart adds a chart to this worksheet.
+	AddChart(options *ChartOptions) error
+	// AddPivotTable adds a pivot table to this worksheet.
+	AddPivotTable(options *PivotTableOptions) error
 	// GetCellStyle gets style information for the specified cell.
 	GetCellStyle(cell string) (*CellStyle, error)
+	// SetCellStyle applies style to the specified range, merging it with the existing cell styles.
+	SetCellStyle(cellRange string, style *CellStyle) error
+	// InsertRows inserts the specified number of rows before the specified row.
+	InsertRows(row int, count int) (*ShiftResult, error)
+	// DeleteRows deletes the specified number of rows starting from the specified row.
+	DeleteRows(row int, count int) (*ShiftResult, error)
+	// InsertColumns inserts the specified number of columns before the specified column.
+	InsertColumns(col int, count int) (*ShiftResult, error)
+	// DeleteColumns deletes the specified number of columns starting from the specified column.
+	DeleteColumns(col int, count int) (*ShiftResult, error)
+	// GetMergedCells returns the ranges of merged cells in this worksheet.
+	GetMergedCells() ([]string, error)
+	// MergeCells merges the cells in the specified range.
+	MergeCells(cellRange string) error
+	// UnmergeCells unmerges all merged cells overlapping the specified range.
+	UnmergeCells(cellRange string) error
+	// GetComments returns the comments (notes) in this worksheet.
+	GetComments() ([]Comment, error)
+	// AddComment adds a comment to the cell, replacing the existing comment of the cell.
+	AddComment(comment *Comment) error
+	// DeleteComment deletes the comment of the specified cell.
+	DeleteComment(cell string) error
+	// GetHyperlink returns the hyperlink of the specified cell, or nil if the cell has no hyperlink.
+	GetHyperlink(cell string) (*Hyperlink, error)
+	// SetHyperlink sets the hyperlink of the specified cell. A nil hyperlink removes the hyperlink.
+	SetHyperlink(cell string, hyperlink *Hyperlink) error
+	// GetPanes returns the frozen or split panes of this worksheet.
+	GetPanes() (*Panes, error)
+	// SetPanes freezes or splits this worksheet, or removes the panes if the mode is PanesNone.
+	SetPanes(panes *Panes) error
+	// GetViewOptions returns the view options of this worksheet.
+	GetViewOptions() (*ViewOptions, error)
+	// SetViewOptions sets the view options of this worksheet. Nil or empty fields are left unchanged.
+	SetViewOptions(options *ViewOptions) error
+	// GetAutoFilter returns the auto filter of this worksheet, or nil if the worksheet has no auto filter.
+	GetAutoFilter() (*AutoFilter, error)
+	// SetAutoFilter sets the auto filter of this worksheet, replacing the existing one, and hides the rows not matching the criteria.
+	SetAutoFilter(filter *AutoFilter) error
+	// RemoveAutoFilter removes the auto filter of this worksheet and shows the rows hidden by it, which are those not matching its criteria.
+	RemoveAutoFilter() error
+	// SortRange sorts the rows of the range by the keys, moving the values, styles and formulas of the cells together.
+	SortRange(options *SortOptions) error
+	// Search returns the cells matching the search options in row-major order.
+	Search(options *SearchOptions) ([]SearchMatch, error)
+	// GetColumnWidth returns the width of the column (e.g., "B") in characters.
+	GetColumnWidth(column string) (float64, error)
+	// SetColumnWidth sets the width of the column (e.g., "B") in characters.
+	SetColumnWidth(column string, width float64) error
+	// GetRowHeight returns the height of the row in points.
+	GetRowHeight(row int) (float64, error)
+	// SetRowHeight sets the height of the row in points.
+	SetRowHeight(row int, height float64) error
+	// AutoFitColumns fits the widths of the columns of the range to the contents of the cells in the range.
+	AutoFitColumns(cellRange string) error
+	// AutoFitRows fits the heights of the rows of the range to the contents of the cells in the range.
+	AutoFitRows(cellRange string) error
+	// ImportCSV writes the rows of the CSV file into this worksheet from the start cell and returns the written range.
+	ImportCSV(options *CSVImportOptions) (string, error)
 	// AddDataValidation adds data validation to the specified range with dropdown options.
 	AddDataValidation(cellRange string, validationType DataValidationType, options *DataValidationOptions) error
 	// AddConditionalFormatting adds conditional formatting to the specified range.
@@ -57,14 +167,194 @@ type Worksheet interface {
 	AddVBAModule(moduleName, vbaCode string) error
 }
 
+// SheetVisibility is the visibility of a sheet.
+// Very hidden sheets cannot be shown from the Excel UI, only from VBA.
+type SheetVisibility string
+
+const (
+	SheetVisible    SheetVisibility = "visible"
+	SheetHidden     SheetVisibility = "hidden"
+	SheetVeryHidden SheetVisibility = "veryHidden"
+)
+
+// PanesMode is the mode of the panes of a worksheet
+type PanesMode string
+
+const (
+	PanesNone   PanesMode = "none"
+	PanesFreeze PanesMode = "freeze"
+	PanesSplit  PanesMode = "split"
+)
+
+// Panes is the frozen or split panes of a worksheet.
+// Rows and Columns are the numbers of rows above and columns left of the split (e.g., 1 and 0 freeze the header row).
+type Panes struct {
+	Mode    PanesMode
+	Rows    int
+	Columns int
+}
+
+// ViewOptions is the view settings of a worksheet
+type ViewOptions struct {
+	ShowGridLines *bool
+	Zoom          *int // in percent (10-400)
+	RightToLeft   *bool
+	SelectedCell  string
+}
+
+// DefinedName is a name referring to a range, a constant or a formula.
+// Scope is the sheet name for sheet-scoped names, or empty for workbook-scoped names.
+type DefinedName struct {
+	Name     string
+	RefersTo string // without the leading "="
+	Scope    string
+	Comment  string
+}
+
+// RecalculationResult is the result of recalculating the formulas in the workbook
+type RecalculationResult struct {
+	Calculated int // number of the formulas whose cached values are updated
+	// Errors are the formula cells resulting in error values (e.g., #DIV/0!), with the error values
+	Errors []RecalculationIssue
+	// Failures are the formula cells which cannot be calculated, with the reasons. Their cached values are unchanged.
+	Failures []RecalculationIssue
+	// UnsupportedFunctions are the formula cells using the functions the calculation engine does not support,
+	// with the names of the functions
+	UnsupportedFunctions []RecalculationIssue
+}
+
+// RecalculationIssue is a formula cell having an issue in the recalculation
+type RecalculationIssue struct {
+	Cell   string // qualified with the sheet name (e.g., Sheet1!A1)
+	Detail string
+}
+
+// FormulaTrace is the precedents and dependents of a cell
+type FormulaTrace struct {
+	Cell    string // qualified with the sheet name (e.g., Sheet1!A1)
+	Formula string // with the leading "=", or empty if the cell has no formula
+	Value   string
+	// Precedents are the references in the formula of the cell, which have the references in their formulas
+	Precedents []*FormulaTraceNode
+	// Dependents are the formulas referring to the cell, which have the formulas referring to them
+	Dependents []*FormulaTraceNode
+}
+
+// FormulaTraceNode is a node of the trees of the precedents and dependents
+type FormulaTraceNode struct {
+	Kind FormulaReferenceKind
+	// Reference is the cell or range qualified with the sheet name (e.g., Sheet1!A1, 'My Sheet'!$A$1:$B$2),
+	// the defined name, the structured reference or the external reference
+	Reference string
+	Formula   string // formula of the cell, or what the defined name refers to, with the leading "="
+	Value     string // value of the cell
+	Children  []*FormulaTraceNode
+	// Circular is true if the cell appears in its ancestors, whose children are omitted
+	Circular bool
+	// Truncated is true if the node has children beyond the depth, which are omitted
+	Truncated bool
+}
+
+// Comment is a comment (note) attached to a cell
+type Comment struct {
+	Cell   string
+	Author string
+	Text   string
+}
+
+// Hyperlink is a link from a cell to an external URL or to a location in the workbook (e.g., Sheet2!A1)
+type Hyperlink struct {
+	Target  string
+	Tooltip string
+}
+
+// IsExternal reports whether the hyperlink refers to an external URL or file
+func (h *Hyperlink) IsExternal() bool {
+	return IsExternalLink(h.Target)
+}
+
 type Table struct {
 	Name  string
 	Range string
 }
 
 type PivotTable struct {
-	Name  string
-	Range string
+	Name         string
+	Range        string
+	SourceRange  string
+	RowFields    []string
+	ColumnFields []string
+	FilterFields []string
+	DataFields   []PivotDataField
+}
+
+// PivotDataField describes a field aggregated in the values area of a pivot table
+type PivotDataField struct {
+	Field    string `yaml:"field"`
+	Function string `yaml:"function"` // sum, count, average, max, min
+	Name     string `yaml:"name,omitempty"`
+}
+
+// PivotTableOptions contains options for adding a pivot table
+type PivotTableOptions struct {
+	Name string
+	// SourceRange is a range qualified with the sheet name (e.g., Sheet1!$A$1:$E$31) or a table name
+	SourceRange  string
+	TargetCell   string
+	RowFields    []string
+	ColumnFields []string
+	FilterFields []string
+	DataFields   []PivotDataField
+}
+
+// Picture is a picture placed in a worksheet
+type Picture struct {
+	Name       string
+	AnchorCell string
+	AltText    string
+}
+
+// PictureOptions contains options for adding a picture
+type PictureOptions struct {
+	Data []byte
+	// Format is the image format, png or jpeg
+	Format     string
+	AnchorCell string
+	ScaleX     float64
+	ScaleY     float64
+	// OffsetX and OffsetY are the offsets from the top-left corner of the anchor cell in pixels
+	OffsetX int
+	OffsetY int
+	AltText string
+}
+
+type Chart struct {
+	Name       string
+	Type       string
+	Title      string
+	AnchorCell string
+	Series     []ChartSeries
+}
+
+// ChartSeries contains references of the data plotted by a chart series
+type ChartSeries struct {
+	// Name is a reference to the cell holding the series name (e.g., "Sheet1!$B$1")
+	Name       string `yaml:"name,omitempty"`
+	Categories string `yaml:"categories,omitempty"`
+	Values     string `yaml:"values"`
+}
+
+// ChartOptions contains options for adding a chart
+type ChartOptions struct {
+	Type           string        `yaml:"type"` // line, bar, column, pie, scatter, area
+	Series         []ChartSeries `yaml:"series"`
+	Title          string        `yaml:"title,omitempty"`
+	XAxisTitle     string        `yaml:"xAxisTitle,omitempty"`
+	YAxisTitle     string        `yaml:"yAxisTitle,omitempty"`
+	LegendPosition string        `yaml:"legendPosition,omitempty"` // top, bottom, left, right, topRight, none
+	AnchorCell     string        `yaml:"anchorCell"`
+	Width          int           `yaml:"width,omitempty"`  // in pixels
+	Height         int           `yaml:"height,omitempty"` // in pixels
 }
 
 type CellStyle struct {
@@ -81,14 +371,15 @@ type BorderStyle struct {
 	Color string          `yaml:"color,omitempty"`
 }
 
+// FontStyle is a font style. Bold, Italic and Strike are nil when they are not specified, so that an explicit false can turn them off.
 type FontStyle struct {
-	Bold      bool   `yaml:"bold,omitempty"`
-	Italic    bool   `yaml:"italic,omitempty"`
-	Underline string `yaml:"underline,omitempty"`
-	Size      int    `yaml:"size,omitempty"`
-	Strike    bool   `yaml:"strike,omitempty"`
-	Color     string `yaml:"color,omitempty"`
-	VertAlign string `yaml:"vertAlign,omitempty"`
+	Bold      *bool   `yaml:"bold,omitempty"`
+	Italic    *bool   `yaml:"italic,omitempty"`
+	Underline string  `yaml:"underline,omitempty"`
+	Size      float64 `yaml:"size,omitempty"`
+	Strike    *bool   `yaml:"strike,omitempty"`
+	Color     string  `yaml:"color,omitempty"`
+	VertAlign string  `yaml:"vertAlign,omitempty"`
 }
 
 type FillStyle struct {
@@ -117,6 +408,90 @@ func OpenFile(absoluteFilePath string) (Excel, func(), error) {
 	}, nil
 }
 
+// FileFormat is the format of an Excel file named after its extension.
+type FileFormat string
+
+const (
+	FileFormatXlsx FileFormat = "xlsx"
+	FileFormatXlsm FileFormat = "xlsm"
+	FileFormatXltx FileFormat = "xltx"
+	FileFormatXltm FileFormat = "xltm"
+)
+
+// FileFormatOf returns the format of the file path by its extension.
+func FileFormatOf(absolutePath string) (FileFormat, error) {
+	format := FileFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(absolutePath)), "."))
+	switch format {
+	case FileFormatXlsx, FileFormatXlsm, FileFormatXltx, FileFormatXltm:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported file format: %s", absolutePath)
+}
+
+// MacroEnabled reports whether the format can contain a VBA project.
+func (f FileFormat) MacroEnabled() bool {
+	return f == FileFormatXlsm || f == FileFormatXltm
+}
+
+// CreateFileOptions specifies the content of a new Excel file.
+type CreateFileOptions struct {
+	// SheetNames are the sheets of the new file. With a template, they are added after the sheets of the template.
+	SheetNames []string
+	// TemplatePath is the absolute path to a .xltx or .xltm template to create the file from, or empty.
+	TemplatePath string
+	// Overwrite allows replacing an existing file.
+	Overwrite bool
+}
+
+// CreateFile creates a new Excel file (.xlsx or .xlsm) and returns an Excel interface.
+// It first tries to create the file using OLE automation, and if that fails,
+// it tries to using the excelize library.
+func CreateFile(absoluteFilePath string, options *CreateFileOptions) (Excel, func(), error) {
+	ext := strings.ToLower(filepath.Ext(absoluteFilePath))
+	if ext != ".xlsx" && ext != ".xlsm" {
+		return nil, func() {}, invalidArgumentErrorf("file extension must be .xlsx or .xlsm: %s", absoluteFilePath)
+	}
+	switch strings.ToLower(filepath.Ext(options.TemplatePath)) {
+	case "", ".xltx":
+	case ".xltm":
+		if ext != ".xlsm" {
+			return nil, func() {}, invalidArgumentErrorf("file created from a macro-enabled template must be .xlsm: %s", absoluteFilePath)
+		}
+	default:
+		return nil, func() {}, invalidArgumentErrorf("template extension must be .xltx or .xltm: %s", options.TemplatePath)
+	}
+	if options.TemplatePath == "" && len(options.SheetNames) == 0 {
+		return nil, func() {}, invalidArgumentErrorf("at least one sheet is required")
+	}
+	for i, sheetName := range options.SheetNames {
+		if slices.ContainsFunc(options.SheetNames[:i], func(name string) bool { return strings.EqualFold(name, sheetName) }) {
+			return nil, func() {}, invalidArgumentErrorf("duplicate sheet name: %s", sheetName)
+		}
+	}
+	if options.TemplatePath != "" {
+		if _, err := os.Stat(options.TemplatePath); errors.Is(err, os.ErrNotExist) {
+			return nil, func() {}, invalidArgumentErrorf("template not found: %s", options.TemplatePath)
+		}
+	}
+	if _, err := os.Stat(absoluteFilePath); err == nil && !options.Overwrite {
+		return nil, func() {}, invalidArgumentErrorf("file already exists: %s", absoluteFilePath)
+	}
+
+	ole, releaseFn, err := NewExcelOleWithNewWorkbook(absoluteFilePath, options)
+	if err == nil {
+		return ole, releaseFn, nil
+	}
+	// If OLE fails, try Excelize
+	workbook, err := createExcelizeFile(absoluteFilePath, options)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	excelize := NewExcelizeExcel(workbook)
+	return excelize, func() {
+		workbook.Close()
+	}, nil
+}
+
 // BorderStyleName represents border style constants
 type BorderStyleName int
 
@@ -131,6 +506,10 @@ const (
 	BorderStyleSlantDashDot
 	BorderStyleMediumDashDot
 	BorderStyleMediumDashDotDot
+	BorderStyleHair
+	BorderStyleMedium
+	BorderStyleMediumDash
+	BorderStyleThick
 )
 
 var borderStyleNames = map[BorderStyleName]string{
@@ -144,6 +523,10 @@ var borderStyleNames = map[BorderStyleName]string{
 	BorderStyleSlantDashDot:     "slantDashDot",
 	BorderStyleMediumDashDot:    "mediumDashDot",
 	BorderStyleMediumDashDotDot: "mediumDashDotDot",
+	BorderStyleHair:             "hair",
+	BorderStyleMedium:           "medium",
+	BorderStyleMediumDash:       "mediumDash",
+	BorderStyleThick:            "thick",
 }
 
 func (b BorderStyleName) String() string {
@@ -157,6 +540,16 @@ func (b BorderStyleName) MarshalText() ([]byte, error) {
 	return []byte(b.String()), nil
 }
 
+func (b *BorderStyleName) UnmarshalText(text []byte) error {
+	for style, name := range borderStyleNames {
+		if name == string(text) {
+			*b = style
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown border style: %s", text)
+}
+
 // FillPatternName represents fill pattern constants
 type FillPatternName int
 
@@ -215,6 +608,16 @@ func (f FillPatternName) MarshalText() ([]byte, error) {
 	return []byte(f.String()), nil
 }
 
+func (f *FillPatternName) UnmarshalText(text []byte) error {
+	for pattern, name := range fillPatternNames {
+		if name == string(text) {
+			*f = pattern
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown fill pattern: %s", text)
+}
+
 // FillShadingName represents fill shading constants
 type FillShadingName int
 
@@ -238,6 +641,16 @@ func (f FillShadingName) MarshalText() ([]byte, error) {
 	return []byte(f.String()), nil
 }
 
+func (f *FillShadingName) UnmarshalText(text []byte) error {
+	for shading, name := range fillShadingNames {
+		if name == string(text) {
+			*f = shading
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown fill shading: %s", text)
+}
+
 var fillShadingNames = map[FillShadingName]string{
 	FillShadingHorizontal:   "horizontal",
 	FillShadingVertical:     "vertical",
@@ -1,20 +1,72 @@
 package excel
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	_ "image/jpeg" // register decoders used by excelize to get the size of pictures
+	_ "image/png"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/xuri/excelize/v2"
 )
 
 type ExcelizeExcel struct {
 	file *excelize.File
+	// cachedValues are the calculated values of the formula cells, which are written when the workbook is saved
+	cachedValues map[formulaCell]excelizeCachedValue
+	// autoFilters are the auto filters set (or removed as nil) on the sheets, which are written when the workbook is saved
+	autoFilters map[string]*xlsxAutoFilter
 }
 
 func NewExcelizeExcel(file *excelize.File) Excel {
-	return &ExcelizeExcel{file: file}
+	return &ExcelizeExcel{file: file, autoFilters: map[string]*xlsxAutoFilter{}}
+}
+
+// createExcelizeFile creates a new Excel file with the sheets, from the template if any
+func createExcelizeFile(absolutePath string, options *CreateFileOptions) (*excelize.File, error) {
+	var file *excelize.File
+	sheetNames := options.SheetNames
+	if options.TemplatePath == "" {
+		file = excelize.NewFile()
+		if err := file.SetSheetName(file.GetSheetName(0), sheetNames[0]); err != nil {
+			file.Close()
+			return nil, err
+		}
+		sheetNames = sheetNames[1:]
+	} else {
+		var err error
+		if file, err = excelize.OpenFile(options.TemplatePath); err != nil {
+			return nil, fmt.Errorf("failed to open template: %w", err)
+		}
+	}
+	for _, sheetName := range sheetNames {
+		if index, _ := file.GetSheetIndex(sheetName); index >= 0 {
+			file.Close()
+			return nil, invalidArgumentErrorf("sheet already exists in template: %s", sheetName)
+		}
+		if _, err := file.NewSheet(sheetName); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to create new sheet: %w", err)
+		}
+	}
+	// Excelize sets the content type of the workbook by the extension of its path when writing, which turns a template into a workbook
+	file.Path = absolutePath
+	if err := NewExcelizeExcel(file).Save(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
 }
 
 func (e *ExcelizeExcel) GetBackendName() string {
@@ -29,7 +81,7 @@ func (e *ExcelizeExcel) FindSheet(sheetName string) (Worksheet, error) {
 	if index < 0 {
 		return nil, fmt.Errorf("sheet not found: %s", sheetName)
 	}
-	return &ExcelizeWorksheet{file: e.file, sheetName: sheetName}, nil
+	return &ExcelizeWorksheet{file: e.file, sheetName: sheetName, autoFilters: e.autoFilters}, nil
 }
 
 func (e *ExcelizeExcel) CreateNewSheet(sheetName string) error {
@@ -66,321 +118,2557 @@ func (e *ExcelizeExcel) GetSheets() ([]Worksheet, error) {
 	sheetList := e.file.GetSheetList()
 	worksheets := make([]Worksheet, len(sheetList))
 	for i, sheetName := range sheetList {
-		worksheets[i] = &ExcelizeWorksheet{file: e.file, sheetName: sheetName}
+		worksheets[i] = &ExcelizeWorksheet{file: e.file, sheetName: sheetName, autoFilters: e.autoFilters}
 	}
 	return worksheets, nil
 }
 
-// SaveExcelize saves the Excel file to the specified path.
-// Excelize's Save method restricts the file path length to 207 characters,
-// but since this limitation has been relaxed in some environments,
-// we ignore this restriction.
-// https://github.com/qax-os/excelize/blob/v2.9.0/file.go#L71-L73
-func (w *ExcelizeExcel) Save() error {
-	file, err := os.OpenFile(filepath.Clean(w.file.Path), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, os.ModePerm)
-	if err != nil {
-		return err
+// sheetName returns the sheet name as it is stored in the workbook, since sheet names are case-insensitive
+func (e *ExcelizeExcel) sheetName(sheetName string) (string, error) {
+	for _, name := range e.file.GetSheetList() {
+		if strings.EqualFold(name, sheetName) {
+			return name, nil
+		}
 	}
-	defer file.Close()
-	return w.file.Write(file)
+	return "", fmt.Errorf("sheet not found: %s", sheetName)
 }
 
-type ExcelizeWorksheet struct {
-	file      *excelize.File
-	sheetName string
+// RenameSheet renames the sheet. Excelize does not update the formulas, chart series and pivot table sources
+// referring to the sheet, so they are rewritten here.
+func (e *ExcelizeExcel) RenameSheet(oldName string, newName string) error {
+	oldName, err := e.sheetName(oldName)
+	if err != nil {
+		return err
+	}
+	if existing, err := e.sheetName(newName); err == nil && existing != oldName {
+		return fmt.Errorf("sheet already exists: %s", newName)
+	}
+	// Excelize rewrites defined names without quoting the new name, so they are rewritten here as well
+	definedNames, err := e.GetDefinedNames()
+	if err != nil {
+		return err
+	}
+	if err := e.file.SetSheetName(oldName, newName); err != nil {
+		return fmt.Errorf("failed to rename sheet: %w", err)
+	}
+	renamedDefinedNames, err := e.GetDefinedNames()
+	if err != nil {
+		return err
+	}
+	for i, definedName := range renamedDefinedNames {
+		if refersTo := renameSheetInFormula(definedNames[i].RefersTo, oldName, newName); refersTo != definedName.RefersTo {
+			definedName.RefersTo = refersTo
+			if err := e.SetDefinedName(&definedName); err != nil {
+				return err
+			}
+		}
+	}
+	for _, sheetName := range e.file.GetSheetList() {
+		formulas, err := getExcelizeFormulas(e.file, sheetName)
+		if err != nil {
+			return err
+		}
+		for cell, formula := range formulas {
+			if renamed := renameSheetInFormula(formula, oldName, newName); renamed != formula {
+				if err := e.file.SetCellFormula(sheetName, cell, renamed); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	renameSheetInExcelizeParts(e.file, oldName, newName)
+	return nil
 }
 
-func (w *ExcelizeWorksheet) Release() {
-	// No resources to release in excelize
+// DeleteSheet deletes the sheet. References to the sheet in the formulas and defined names become #REF!.
+func (e *ExcelizeExcel) DeleteSheet(sheetName string) error {
+	sheetName, err := e.sheetName(sheetName)
+	if err != nil {
+		return err
+	}
+	visibleSheets := 0
+	for _, name := range e.file.GetSheetList() {
+		if visibility, _ := e.GetSheetVisibility(name); visibility == SheetVisible && name != sheetName {
+			visibleSheets++
+		}
+	}
+	if visibleSheets == 0 {
+		return fmt.Errorf("cannot delete sheet %s: a workbook must contain at least one visible sheet", sheetName)
+	}
+	if err := e.activateOtherSheet(sheetName); err != nil {
+		return err
+	}
+	if err := e.file.DeleteSheet(sheetName); err != nil {
+		return fmt.Errorf("failed to delete sheet: %w", err)
+	}
+	for _, name := range e.file.GetSheetList() {
+		formulas, err := getExcelizeFormulas(e.file, name)
+		if err != nil {
+			return err
+		}
+		for cell, formula := range formulas {
+			if invalidated := invalidateSheetInFormula(formula, sheetName); invalidated != formula {
+				if err := e.file.SetCellFormula(name, cell, invalidated); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	definedNames, err := e.GetDefinedNames()
+	if err != nil {
+		return err
+	}
+	for _, definedName := range definedNames {
+		if invalidated := invalidateSheetInFormula(definedName.RefersTo, sheetName); invalidated != definedName.RefersTo {
+			definedName.RefersTo = invalidated
+			if err := e.SetDefinedName(&definedName); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
-func (w *ExcelizeWorksheet) Name() (string, error) {
-	return w.sheetName, nil
+// activateOtherSheet activates another visible sheet if the sheet is active,
+// since Excelize refuses to hide the active sheet and may leave a hidden sheet active on delete
+func (e *ExcelizeExcel) activateOtherSheet(sheetName string) error {
+	sheetList := e.file.GetSheetList()
+	if sheetList[e.file.GetActiveSheetIndex()] != sheetName {
+		return nil
+	}
+	for index, name := range sheetList {
+		if visibility, _ := e.GetSheetVisibility(name); visibility == SheetVisible && name != sheetName {
+			e.file.SetActiveSheet(index)
+			return nil
+		}
+	}
+	return fmt.Errorf("no other visible sheet")
 }
 
-func (w *ExcelizeWorksheet) GetTables() ([]Table, error) {
-	tables, err := w.file.GetTables(w.sheetName)
+// MoveSheet moves the sheet to the 1-based position
+func (e *ExcelizeExcel) MoveSheet(sheetName string, position int) error {
+	sheetName, err := e.sheetName(sheetName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tables: %w", err)
+		return err
 	}
-	tableList := make([]Table, len(tables))
-	for i, table := range tables {
-		tableList[i] = Table{
-			Name:  table.Name,
-			Range: NormalizeRange(table.Range),
+	sheetList := e.file.GetSheetList()
+	if position < 1 || position > len(sheetList) {
+		return fmt.Errorf("position must be between 1 and %d", len(sheetList))
+	}
+	current := slices.Index(sheetList, sheetName) + 1
+	switch {
+	case position == current:
+		return nil
+	case position < current:
+		err = e.file.MoveSheet(sheetName, sheetList[position-1])
+	case position < len(sheetList):
+		// Excelize moves the sheet before the target, which is shifted to the left after moving
+		err = e.file.MoveSheet(sheetName, sheetList[position])
+	default:
+		// Excelize cannot move a sheet after the last one, so the sheet is moved just before it
+		// and then the last sheet is moved before the sheet
+		last := sheetList[len(sheetList)-1]
+		if err = e.file.MoveSheet(sheetName, last); err == nil {
+			err = e.file.MoveSheet(last, sheetName)
 		}
 	}
-	return tableList, nil
+	if err != nil {
+		return fmt.Errorf("failed to move sheet: %w", err)
+	}
+	return nil
 }
 
-func (w *ExcelizeWorksheet) GetPivotTables() ([]PivotTable, error) {
-	pivotTables, err := w.file.GetPivotTables(w.sheetName)
+func (e *ExcelizeExcel) GetSheetVisibility(sheetName string) (SheetVisibility, error) {
+	sheetName, err := e.sheetName(sheetName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pivot tables: %w", err)
+		return "", err
 	}
-	pivotTableList := make([]PivotTable, len(pivotTables))
-	for i, pivotTable := range pivotTables {
-		pivotTableList[i] = PivotTable{
-			Name:  pivotTable.Name,
-			Range: NormalizeRange(pivotTable.PivotTableRange),
+	for _, sheet := range e.file.WorkBook.Sheets.Sheet {
+		if sheet.Name == sheetName && sheet.State != "" {
+			return SheetVisibility(sheet.State), nil
 		}
 	}
-	return pivotTableList, nil
+	return SheetVisible, nil
 }
 
-func (w *ExcelizeWorksheet) SetValue(cell string, value any) error {
-	if err := w.file.SetCellValue(w.sheetName, cell, value); err != nil {
+func (e *ExcelizeExcel) SetSheetVisibility(sheetName string, visibility SheetVisibility) error {
+	sheetName, err := e.sheetName(sheetName)
+	if err != nil {
 		return err
 	}
-	if err := w.updateDimension(cell); err != nil {
-		return fmt.Errorf("failed to update dimension: %w", err)
+	switch visibility {
+	case SheetVisible:
+		if err := e.file.SetSheetVisible(sheetName, true); err != nil {
+			return fmt.Errorf("failed to set sheet visibility: %w", err)
+		}
+		return nil
+	case SheetHidden, SheetVeryHidden:
+	default:
+		return fmt.Errorf("invalid sheet visibility: %s", visibility)
 	}
-	return nil
-}
-
-func (w *ExcelizeWorksheet) SetFormula(cell string, formula string) error {
-	if err := w.file.SetCellFormula(w.sheetName, cell, formula); err != nil {
-		return err
+	if err := e.activateOtherSheet(sheetName); err != nil {
+		return fmt.Errorf("cannot hide sheet %s: a workbook must contain at least one visible sheet", sheetName)
 	}
-	if err := w.updateDimension(cell); err != nil {
-		return fmt.Errorf("failed to update dimension: %w", err)
+	if err := e.file.SetSheetVisible(sheetName, false, visibility == SheetVeryHidden); err != nil {
+		return fmt.Errorf("failed to set sheet visibility: %w", err)
+	}
+	// Excelize silently ignores hiding the last visible sheet
+	if current, _ := e.GetSheetVisibility(sheetName); current != visibility {
+		return fmt.Errorf("cannot hide sheet %s: a workbook must contain at least one visible sheet", sheetName)
 	}
 	return nil
 }
 
-func (w *ExcelizeWorksheet) GetValue(cell string) (string, error) {
-	value, err := w.file.GetCellValue(w.sheetName, cell)
+func (e *ExcelizeExcel) GetTabColor(sheetName string) (string, error) {
+	sheetName, err := e.sheetName(sheetName)
 	if err != nil {
 		return "", err
 	}
-	if value == "" {
-		// try to get calculated value
-		formula, err := w.file.GetCellFormula(w.sheetName, cell)
-		if err != nil {
-			return "", fmt.Errorf("failed to get formula: %w", err)
-		}
-		if formula != "" {
-			return w.file.CalcCellValue(w.sheetName, cell)
-		}
+	props, err := e.file.GetSheetProps(sheetName)
+	if err != nil {
+		return "", err
 	}
-	return value, nil
+	if props.TabColorRGB == nil || *props.TabColorRGB == "" {
+		return "", nil
+	}
+	color := *props.TabColorRGB
+	// Drop the alpha channel of ARGB
+	if len(color) == 8 {
+		color = color[2:]
+	}
+	return "#" + strings.ToUpper(color), nil
 }
 
-func (w *ExcelizeWorksheet) GetFormula(cell string) (string, error) {
-	formula, err := w.file.GetCellFormula(w.sheetName, cell)
+func (e *ExcelizeExcel) SetTabColor(sheetName string, color string) error {
+	sheetName, err := e.sheetName(sheetName)
 	if err != nil {
-		return "", fmt.Errorf("failed to get formula: %w", err)
+		return err
 	}
-	if formula == "" {
-		// fallback
-		return w.GetValue(cell)
+	// Excelize cannot remove the tabColor element, so an empty color clears its attributes instead
+	rgb, indexed, tint := "", 0, 0.0
+	if color != "" {
+		rgb = "FF" + strings.ToUpper(strings.TrimPrefix(color, "#"))
 	}
-	if !strings.HasPrefix(formula, "=") {
-		formula = "=" + formula
+	err = e.file.SetSheetProps(sheetName, &excelize.SheetPropsOptions{
+		TabColorRGB:     &rgb,
+		TabColorIndexed: &indexed,
+		TabColorTint:    &tint,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set tab color: %w", err)
 	}
-	return formula, nil
+	return nil
 }
 
-func (w *ExcelizeWorksheet) GetDimention() (string, error) {
-	return w.file.GetSheetDimension(w.sheetName)
+func (e *ExcelizeExcel) FindSheetDependents(sheetName string) ([]string, error) {
+	sheetName, err := e.sheetName(sheetName)
+	if err != nil {
+		return nil, err
+	}
+	dependents := []string{}
+	for _, name := range e.file.GetSheetList() {
+		if name == sheetName {
+			continue
+		}
+		formulas, err := getExcelizeFormulas(e.file, name)
+		if err != nil {
+			return nil, err
+		}
+		cells := []string{}
+		for cell, formula := range formulas {
+			if formulaRefersToSheet(formula, sheetName) {
+				cells = append(cells, cell)
+			}
+		}
+		sortCellNames(cells)
+		for _, cell := range cells {
+			dependents = append(dependents, QuoteSheetName(name)+"!"+cell)
+		}
+	}
+	return dependents, nil
 }
 
-func (w *ExcelizeWorksheet) GetPagingStrategy(pageSize int) (PagingStrategy, error) {
-	return NewExcelizeFixedSizePagingStrategy(pageSize, w)
-}
+// excelizeFilterDatabaseName is the hidden name Excelize defines for the auto filter range of a sheet
+const excelizeFilterDatabaseName = "_xlnm._FilterDatabase"
 
-func (w *ExcelizeWorksheet) CapturePicture(captureRange string) (string, error) {
-	return "", fmt.Errorf("CapturePicture is not supported in Excelize")
+func (e *ExcelizeExcel) GetDefinedNames() ([]DefinedName, error) {
+	definedNames := []DefinedName{}
+	for _, definedName := range e.file.GetDefinedName() {
+		if definedName.Name == excelizeFilterDatabaseName {
+			continue
+		}
+		scope := definedName.Scope
+		if scope == "Workbook" {
+			scope = ""
+		}
+		definedNames = append(definedNames, DefinedName{
+			Name:     definedName.Name,
+			RefersTo: strings.TrimPrefix(definedName.RefersTo, "="),
+			Scope:    scope,
+			Comment:  definedName.Comment,
+		})
+	}
+	return definedNames, nil
 }
 
-func (w *ExcelizeWorksheet) AddTable(tableRange, tableName string) error {
-	enable := true
-	if err := w.file.AddTable(w.sheetName, &excelize.Table{
-		Range:             tableRange,
-		Name:              tableName,
-		StyleName:         "TableStyleMedium2",
-		ShowColumnStripes: true,
-		ShowFirstColumn:   false,
-		ShowHeaderRow:     &enable,
-		ShowLastColumn:    false,
-		ShowRowStripes:    &enable,
-	}); err != nil {
+func (e *ExcelizeExcel) SetDefinedName(definedName *DefinedName) error {
+	if definedName.Scope != "" {
+		if index, _ := e.file.GetSheetIndex(definedName.Scope); index < 0 {
+			return fmt.Errorf("sheet not found: %s", definedName.Scope)
+		}
+	}
+	// Excelize does not update existing names, so the name is recreated
+	definedNames, err := e.GetDefinedNames()
+	if err != nil {
 		return err
 	}
+	for _, existing := range definedNames {
+		if strings.EqualFold(existing.Name, definedName.Name) && strings.EqualFold(existing.Scope, definedName.Scope) {
+			if err := e.DeleteDefinedName(existing.Name, existing.Scope); err != nil {
+				return err
+			}
+		}
+	}
+	err = e.file.SetDefinedName(&excelize.DefinedName{
+		Name:     definedName.Name,
+		RefersTo: strings.TrimPrefix(definedName.RefersTo, "="),
+		Scope:    definedName.Scope,
+		Comment:  definedName.Comment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set defined name: %w", err)
+	}
 	return nil
 }
 
-func (w *ExcelizeWorksheet) GetCellStyle(cell string) (*CellStyle, error) {
-	styleID, err := w.file.GetCellStyle(w.sheetName, cell)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get cell style: %w", err)
+func (e *ExcelizeExcel) DeleteDefinedName(name string, scope string) error {
+	if err := e.file.DeleteDefinedName(&excelize.DefinedName{Name: name, Scope: scope}); err != nil {
+		return fmt.Errorf("failed to delete defined name: %w", err)
 	}
+	return nil
+}
 
-	style, err := w.file.GetStyle(styleID)
+func (e *ExcelizeExcel) Date1904() (bool, error) {
+	props, err := e.file.GetWorkbookProps()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get style details: %w", err)
+		return false, err
 	}
-
-	return convertExcelizeStyleToCellStyle(style), nil
+	return props.Date1904 != nil && *props.Date1904, nil
 }
 
-func convertExcelizeStyleToCellStyle(style *excelize.Style) *CellStyle {
-	result := &CellStyle{}
-
-	// Border
-	if len(style.Border) > 0 {
-		var borders []BorderStyle
-		for _, border := range style.Border {
-			borderStyle := BorderStyle{
-				Type: border.Type,
-			}
-			if border.Color != "" {
-				borderStyle.Color = "#" + strings.ToUpper(border.Color)
-			}
-			if border.Style != 0 {
-				borderStyle.Style = intToBorderStyleName(border.Style)
-			}
-			borders = append(borders, borderStyle)
-		}
-		if len(borders) > 0 {
-			result.Border = borders
-		}
+func (e *ExcelizeExcel) Recalculate() (*RecalculationResult, error) {
+	graph, err := newExcelizeFormulaGraph(e)
+	if err != nil {
+		return nil, err
 	}
+	support := newExcelizeFunctionSupport()
+	defer support.close()
 
-	// Font
-	if style.Font != nil {
-		font := &FontStyle{}
-		if style.Font.Bold {
-			font.Bold = true
-		}
-		if style.Font.Italic {
-			font.Italic = true
-		}
-		if style.Font.Underline != "" {
-			font.Underline = style.Font.Underline
+	result := &RecalculationResult{
+		Errors:               []RecalculationIssue{},
+		Failures:             []RecalculationIssue{},
+		UnsupportedFunctions: []RecalculationIssue{},
+	}
+	// The cached values are written when the workbook is saved, so that the calculations do not use them
+	if e.cachedValues == nil {
+		e.cachedValues = map[formulaCell]excelizeCachedValue{}
+	}
+	failed := map[formulaCell]bool{}
+	sorted, circular := graph.sortFormulas()
+	for _, cell := range circular {
+		failed[cell] = true
+		result.Failures = append(result.Failures, RecalculationIssue{Cell: cell.String(), Detail: "circular reference"})
+	}
+	for _, cell := range sorted {
+		unsupported := []string{}
+		for _, function := range formulaFunctions(graph.formulas[cell]) {
+			if !support.isSupported(function) {
+				unsupported = append(unsupported, function)
+			}
 		}
-		if style.Font.Size > 0 {
-			font.Size = int(style.Font.Size)
+		if len(unsupported) > 0 {
+			result.UnsupportedFunctions = append(result.UnsupportedFunctions, RecalculationIssue{Cell: cell.String(), Detail: strings.Join(unsupported, ", ")})
 		}
-		if style.Font.Strike {
-			font.Strike = true
+		if index := slices.IndexFunc(graph.precedents[cell], func(precedent formulaCell) bool { return failed[precedent] }); index >= 0 {
+			failed[cell] = true
+			result.Failures = append(result.Failures, RecalculationIssue{Cell: cell.String(), Detail: fmt.Sprintf("refers to failed cell %s", graph.precedents[cell][index])})
+			continue
 		}
-		if style.Font.Color != "" {
-			font.Color = "#" + strings.ToUpper(style.Font.Color)
+
+		var cellType, value string
+		calculated, err := e.file.CalcCellValue(cell.sheet, cell.cell, excelize.Options{RawCellValue: true})
+		switch {
+		case err == nil:
+			cellType, value = cachedValue(calculated)
+		case isFormulaErrorValue(err.Error()):
+			cellType, value = "e", err.Error()
+			result.Errors = append(result.Errors, RecalculationIssue{Cell: cell.String(), Detail: value})
+		default:
+			failed[cell] = true
+			detail := err.Error()
+			if match := unsupportedFunctionPattern.FindStringSubmatch(detail); match != nil {
+				detail = fmt.Sprintf("unsupported function %s", match[1])
+			}
+			result.Failures = append(result.Failures, RecalculationIssue{Cell: cell.String(), Detail: detail})
+			continue
 		}
-		if style.Font.VertAlign != "" {
-			font.VertAlign = style.Font.VertAlign
+		e.cachedValues[cell] = excelizeCachedValue{cellType, value}
+		result.Calculated++
+	}
+	return result, nil
+}
+
+// SaveExcelize saves the Excel file to the specified path.
+// Excelize's Save method restricts the file path length to 207 characters,
+// but since this limitation has been relaxed in some environments,
+// we ignore this restriction.
+// https://github.com/qax-os/excelize/blob/v2.9.0/file.go#L71-L73
+func (w *ExcelizeExcel) Save() error {
+	file, err := os.OpenFile(filepath.Clean(w.file.Path), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return w.writeTo(w.file.Path, file)
+}
+
+func (w *ExcelizeExcel) SaveAs(absolutePath string, format FileFormat) ([]string, error) {
+	var buf bytes.Buffer
+	if err := w.writeTo(absolutePath, &buf); err != nil {
+		return nil, err
+	}
+	warnings := []string{}
+	content := buf.Bytes()
+	if !format.MacroEnabled() {
+		var dropped bool
+		var err error
+		if content, dropped, err = dropVBAProject(content); err != nil {
+			return nil, err
 		}
-		if font.Bold || font.Italic || font.Underline != "" || font.Size > 0 || font.Strike || font.Color != "" || font.VertAlign != "" {
-			result.Font = font
+		if dropped {
+			warnings = append(warnings, fmt.Sprintf("The VBA project was dropped because the %s format cannot contain macros.", format))
 		}
 	}
+	if err := os.WriteFile(filepath.Clean(absolutePath), content, 0666); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
 
-	// Fill
-	if style.Fill.Type != "" || style.Fill.Pattern != 0 || len(style.Fill.Color) > 0 {
-		fill := &FillStyle{}
+// writeTo writes the workbook in the format of the path with the cached values of the recalculated formulas and
+// the auto filters. Excelize sets the content type of the workbook by the extension of its path, which it accepts
+// only in lowercase.
+func (w *ExcelizeExcel) writeTo(absolutePath string, writer io.Writer) error {
+	originalPath := w.file.Path
+	extension := filepath.Ext(absolutePath)
+	w.file.Path = strings.TrimSuffix(absolutePath, extension) + strings.ToLower(extension)
+	defer func() { w.file.Path = originalPath }()
+	if len(w.cachedValues) == 0 && len(w.autoFilters) == 0 {
+		_, err := w.file.WriteTo(writer)
+		return err
+	}
+	var buf bytes.Buffer
+	if _, err := w.file.WriteTo(&buf); err != nil {
+		return err
+	}
+	content := buf.Bytes()
+	var err error
+	if len(w.cachedValues) > 0 {
+		if content, err = writeExcelizeCachedValues(content, w.cachedValues); err != nil {
+			return err
+		}
+	}
+	if len(w.autoFilters) > 0 {
+		if content, err = writeExcelizeAutoFilters(content, w.autoFilters); err != nil {
+			return err
+		}
+	}
+	_, err = writer.Write(content)
+	return err
+}
+
+const vbaProjectContentType = "application/vnd.ms-office.vbaProject"
+
+var vbaProjectPartPattern = regexp.MustCompile(`^xl/(?:_rels/)?vbaProject[^/]*\.bin(?:\.rels)?$`)
+
+// xmlContentTypes is the [Content_Types].xml part of a package
+type xmlContentTypes struct {
+	XMLName   xml.Name                 `xml:"http://schemas.openxmlformats.org/package/2006/content-types Types"`
+	Defaults  []xmlContentTypeDefault  `xml:"Default"`
+	Overrides []xmlContentTypeOverride `xml:"Override"`
+}
+
+type xmlContentTypeDefault struct {
+	Extension   string `xml:",attr"`
+	ContentType string `xml:",attr"`
+}
+
+type xmlContentTypeOverride struct {
+	PartName    string `xml:",attr"`
+	ContentType string `xml:",attr"`
+}
+
+// xmlRelationships is a relationships part (e.g., xl/_rels/workbook.xml.rels) of a package
+type xmlRelationships struct {
+	XMLName       xml.Name          `xml:"http://schemas.openxmlformats.org/package/2006/relationships Relationships"`
+	Relationships []xmlRelationship `xml:"Relationship"`
+}
+
+type xmlRelationship struct {
+	ID         string `xml:"Id,attr"`
+	Type       string `xml:",attr"`
+	Target     string `xml:",attr"`
+	TargetMode string `xml:",attr,omitempty"`
+}
+
+// dropVBAProject removes the VBA project parts from the package of the workbook, reporting whether it had a VBA project
+func dropVBAProject(content []byte) ([]byte, bool, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, false, err
+	}
+	if !slices.ContainsFunc(reader.File, func(file *zip.File) bool { return vbaProjectPartPattern.MatchString(file.Name) }) {
+		return content, false, nil
+	}
+	content, err = rewritePackage(reader, func(name string, data []byte) ([]byte, error) {
+		switch {
+		case vbaProjectPartPattern.MatchString(name):
+			return nil, nil
+		case name == "xl/_rels/workbook.xml.rels":
+			return editXMLPart(data, func(relationships *xmlRelationships) {
+				relationships.Relationships = slices.DeleteFunc(relationships.Relationships, func(relationship xmlRelationship) bool {
+					return strings.HasSuffix(relationship.Type, "/vbaProject")
+				})
+			})
+		case name == "[Content_Types].xml":
+			return editXMLPart(data, func(contentTypes *xmlContentTypes) {
+				contentTypes.Defaults = slices.DeleteFunc(contentTypes.Defaults, func(contentType xmlContentTypeDefault) bool {
+					return contentType.ContentType == vbaProjectContentType
+				})
+				contentTypes.Overrides = slices.DeleteFunc(contentTypes.Overrides, func(contentType xmlContentTypeOverride) bool {
+					return vbaProjectPartPattern.MatchString(strings.TrimPrefix(contentType.PartName, "/"))
+				})
+			})
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return content, true, nil
+}
+
+// rewritePackage copies the parts of the package through the rewrite, which drops the part by returning nil
+func rewritePackage(reader *zip.Reader, rewrite func(name string, data []byte) ([]byte, error)) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range reader.File {
+		data, err := readPackagePart(file)
+		if err != nil {
+			return nil, err
+		}
+		if data, err = rewrite(file.Name, data); err != nil {
+			return nil, fmt.Errorf("failed to edit %s: %w", file.Name, err)
+		}
+		if data == nil {
+			continue
+		}
+		w, err := writer.CreateHeader(&zip.FileHeader{Name: file.Name, Method: zip.Deflate, Modified: file.Modified})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func readPackagePart(file *zip.File) ([]byte, error) {
+	part, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer part.Close()
+	return io.ReadAll(part)
+}
+
+// editXMLPart decodes the XML part, applies the edit and encodes it again
+func editXMLPart[T any](data []byte, edit func(*T)) ([]byte, error) {
+	var part T
+	if err := xml.Unmarshal(data, &part); err != nil {
+		return nil, err
+	}
+	edit(&part)
+	encoded, err := xml.Marshal(&part)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), encoded...), nil
+}
+
+type ExcelizeWorksheet struct {
+	file      *excelize.File
+	sheetName string
+	// autoFilters are the auto filters of the workbook to write when it is saved
+	autoFilters map[string]*xlsxAutoFilter
+}
+
+func (w *ExcelizeWorksheet) Release() {
+	// No resources to release in excelize
+}
+
+func (w *ExcelizeWorksheet) Name() (string, error) {
+	return w.sheetName, nil
+}
+
+func (w *ExcelizeWorksheet) GetTables() ([]Table, error) {
+	tables, err := w.file.GetTables(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+	tableList := make([]Table, len(tables))
+	for i, table := range tables {
+		tableList[i] = Table{
+			Name:  table.Name,
+			Range: NormalizeRange(table.Range),
+		}
+	}
+	return tableList, nil
+}
+
+func (w *ExcelizeWorksheet) GetPivotTables() ([]PivotTable, error) {
+	pivotTables, err := w.file.GetPivotTables(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pivot tables: %w", err)
+	}
+	pivotTableList := make([]PivotTable, len(pivotTables))
+	for i, pivotTable := range pivotTables {
+		sourceRange := pivotTable.DataRange
+		if sourceSheetName, sourceRangePart := SplitSheetReference(sourceRange); sourceSheetName != "" {
+			sourceRange = QuoteSheetName(sourceSheetName) + "!" + NormalizeRange(sourceRangePart)
+		}
+		dataFields := make([]PivotDataField, len(pivotTable.Data))
+		for j, field := range pivotTable.Data {
+			function := strings.ToLower(field.Subtotal)
+			if function == "" {
+				function = "sum"
+			}
+			dataFields[j] = PivotDataField{
+				Field:    field.Data,
+				Function: function,
+				Name:     field.Name,
+			}
+		}
+		pivotTableList[i] = PivotTable{
+			Name:         pivotTable.Name,
+			Range:        NormalizeRange(pivotTable.PivotTableRange),
+			SourceRange:  sourceRange,
+			RowFields:    excelizePivotTableFieldNames(pivotTable.Rows),
+			ColumnFields: excelizePivotTableFieldNames(pivotTable.Columns),
+			FilterFields: excelizePivotTableFieldNames(pivotTable.Filter),
+			DataFields:   dataFields,
+		}
+	}
+	return pivotTableList, nil
+}
+
+func excelizePivotTableFieldNames(fields []excelize.PivotTableField) []string {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Data
+	}
+	return names
+}
+
+var excelizePivotTableSubtotals = map[string]string{
+	"sum":     "Sum",
+	"count":   "Count",
+	"average": "Average",
+	"max":     "Max",
+	"min":     "Min",
+}
+
+func (w *ExcelizeWorksheet) AddPivotTable(options *PivotTableOptions) error {
+	sourceSheetName, dataRange, sourceRange, err := w.resolvePivotTableSource(options.SourceRange)
+	if err != nil {
+		return err
+	}
+	startCol, startRow, endCol, endRow, err := ParseRange(sourceRange)
+	if err != nil {
+		return invalidArgumentErrorf("invalid source range: %s", options.SourceRange)
+	}
+	fieldColumns := make(map[string]int)
+	for col := startCol; col <= endCol; col++ {
+		cell, _ := excelize.CoordinatesToCellName(col, startRow)
+		header, err := w.file.GetCellValue(sourceSheetName, cell)
+		if err != nil {
+			return err
+		}
+		fieldColumns[header] = col
+	}
+
+	fieldNames := slices.Concat(options.RowFields, options.ColumnFields, options.FilterFields)
+	for _, dataField := range options.DataFields {
+		fieldNames = append(fieldNames, dataField.Field)
+	}
+	for _, name := range fieldNames {
+		if _, ok := fieldColumns[name]; !ok {
+			return invalidArgumentErrorf("field not found in source range: %s", name)
+		}
+	}
+	toPivotTableFields := func(names []string) []excelize.PivotTableField {
+		fields := make([]excelize.PivotTableField, len(names))
+		for i, name := range names {
+			fields[i] = excelize.PivotTableField{Data: name, DefaultSubtotal: true}
+		}
+		return fields
+	}
+	rows := toPivotTableFields(options.RowFields)
+	columns := toPivotTableFields(options.ColumnFields)
+	filters := toPivotTableFields(options.FilterFields)
+	data := make([]excelize.PivotTableField, len(options.DataFields))
+	for i, dataField := range options.DataFields {
+		subtotal, ok := excelizePivotTableSubtotals[dataField.Function]
+		if !ok {
+			return invalidArgumentErrorf("unsupported function: %s", dataField.Function)
+		}
+		data[i] = excelize.PivotTableField{Data: dataField.Field, Name: dataField.Name, Subtotal: subtotal}
+	}
+
+	// Excelize does not create the records of the pivot cache and Excel lays out the pivot table when the file is opened,
+	// so the size of the pivot table is estimated from the distinct items of the row and column fields.
+	countItems := func(fields []string) (int, error) {
+		items := make(map[string]bool)
+		for row := startRow + 1; row <= endRow; row++ {
+			values := make([]string, len(fields))
+			for i, field := range fields {
+				cell, _ := excelize.CoordinatesToCellName(fieldColumns[field], row)
+				value, err := w.file.GetCellValue(sourceSheetName, cell)
+				if err != nil {
+					return 0, err
+				}
+				values[i] = value
+			}
+			items[strings.Join(values, "\x00")] = true
+		}
+		return len(items), nil
+	}
+	rowItems, colItems := 1, 0
+	if len(rows) > 0 {
+		if rowItems, err = countItems(options.RowFields); err != nil {
+			return err
+		}
+	}
+	if len(columns) > 0 {
+		if colItems, err = countItems(options.ColumnFields); err != nil {
+			return err
+		}
+	}
+	width, height := 1+max(1, len(data)), 2+rowItems
+	if colItems > 0 {
+		width, height = 1+(colItems+1)*max(1, len(data)), height+1
+	}
+
+	targetCol, targetRow, err := excelize.CellNameToCoordinates(options.TargetCell)
+	if err != nil {
+		return invalidArgumentErrorf("invalid target cell: %s", options.TargetCell)
+	}
+	if len(filters) > 0 {
+		// Filter fields are placed above the pivot table with a blank row
+		targetRow += len(filters) + 1
+	}
+	topLeftCell, _ := excelize.CoordinatesToCellName(targetCol, targetRow)
+	bottomRightCell, err := excelize.CoordinatesToCellName(targetCol+width-1, targetRow+height-1)
+	if err != nil {
+		return invalidArgumentErrorf("pivot table does not fit in the sheet from %s", options.TargetCell)
+	}
+
+	err = w.file.AddPivotTable(&excelize.PivotTableOptions{
+		DataRange:       dataRange,
+		PivotTableRange: fmt.Sprintf("%s!%s:%s", w.sheetName, topLeftCell, bottomRightCell),
+		Name:            options.Name,
+		Rows:            rows,
+		Columns:         columns,
+		Filter:          filters,
+		Data:            data,
+		RowGrandTotals:  true,
+		ColGrandTotals:  true,
+		ShowDrill:       true,
+		ShowRowHeaders:  true,
+		ShowColHeaders:  true,
+		ShowLastColumn:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add pivot table: %w", err)
+	}
+	return nil
+}
+
+// resolvePivotTableSource returns the sheet name and the range of the pivot table source,
+// and the data range in the form accepted by excelize.
+func (w *ExcelizeWorksheet) resolvePivotTableSource(source string) (string, string, string, error) {
+	if sheetName, rangePart := SplitSheetReference(source); sheetName != "" {
+		if index, _ := w.file.GetSheetIndex(sheetName); index < 0 {
+			return "", "", "", invalidArgumentErrorf("sheet not found: %s", sheetName)
+		}
+		rangePart = strings.ReplaceAll(rangePart, "$", "")
+		return sheetName, sheetName + "!" + rangePart, rangePart, nil
+	}
+	for _, sheetName := range w.file.GetSheetList() {
+		tables, err := w.file.GetTables(sheetName)
+		if err != nil {
+			return "", "", "", err
+		}
+		for _, table := range tables {
+			if strings.EqualFold(table.Name, source) {
+				return sheetName, table.Name, table.Range, nil
+			}
+		}
+	}
+	return "", "", "", invalidArgumentErrorf("table not found: %s", source)
+}
+
+func (w *ExcelizeWorksheet) GetCharts() ([]Chart, error) {
+	charts, err := getExcelizeCharts(w.file, w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get charts: %w", err)
+	}
+	return charts, nil
+}
+
+var excelizeChartTypes = map[string]excelize.ChartType{
+	"line":    excelize.Line,
+	"bar":     excelize.Bar,
+	"column":  excelize.Col,
+	"pie":     excelize.Pie,
+	"scatter": excelize.Scatter,
+	"area":    excelize.Area,
+}
+
+func (w *ExcelizeWorksheet) AddChart(options *ChartOptions) error {
+	chartType, ok := excelizeChartTypes[options.Type]
+	if !ok {
+		return fmt.Errorf("unsupported chart type: %s", options.Type)
+	}
+	chart := &excelize.Chart{
+		Type: chartType,
+		Dimension: excelize.ChartDimension{
+			Width:  uint(options.Width),
+			Height: uint(options.Height),
+		},
+	}
+	for _, series := range options.Series {
+		chart.Series = append(chart.Series, excelize.ChartSeries{
+			Name:       series.Name,
+			Categories: series.Categories,
+			Values:     series.Values,
+		})
+	}
+	if options.Title != "" {
+		chart.Title = []excelize.RichTextRun{{Text: options.Title}}
+	}
+	if options.XAxisTitle != "" {
+		chart.XAxis.Title = []excelize.RichTextRun{{Text: options.XAxisTitle}}
+	}
+	if options.YAxisTitle != "" {
+		chart.YAxis.Title = []excelize.RichTextRun{{Text: options.YAxisTitle}}
+	}
+	if options.LegendPosition == "topRight" {
+		chart.Legend.Position = "top_right"
+	} else {
+		chart.Legend.Position = options.LegendPosition
+	}
+	if err := w.file.AddChart(w.sheetName, options.AnchorCell, chart); err != nil {
+		return fmt.Errorf("failed to add chart: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetPictures() ([]Picture, error) {
+	return getExcelizePictures(w.file, w.sheetName)
+}
+
+func (w *ExcelizeWorksheet) GetPictureData(name string) ([]byte, string, error) {
+	return getExcelizePictureData(w.file, w.sheetName, name)
+}
+
+func (w *ExcelizeWorksheet) AddPicture(options *PictureOptions) error {
+	extension := ".png"
+	if options.Format == "jpeg" {
+		extension = ".jpg"
+	}
+	err := w.file.AddPictureFromBytes(w.sheetName, options.AnchorCell, &excelize.Picture{
+		Extension: extension,
+		File:      options.Data,
+		Format: &excelize.GraphicOptions{
+			AltText: options.AltText,
+			ScaleX:  options.ScaleX,
+			ScaleY:  options.ScaleY,
+			OffsetX: options.OffsetX,
+			OffsetY: options.OffsetY,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add picture: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) SetValue(cell string, value any) error {
+	if err := w.file.SetCellValue(w.sheetName, cell, value); err != nil {
+		return err
+	}
+	if err := w.updateDimension(cell); err != nil {
+		return fmt.Errorf("failed to update dimension: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) SetFormula(cell string, formula string) error {
+	// The formula is stored without the leading "=" in the file
+	if err := w.file.SetCellFormula(w.sheetName, cell, strings.TrimPrefix(formula, "=")); err != nil {
+		return err
+	}
+	if err := w.updateDimension(cell); err != nil {
+		return fmt.Errorf("failed to update dimension: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetValue(cell string) (string, error) {
+	value, err := w.file.GetCellValue(w.sheetName, cell)
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		// try to get calculated value
+		formula, err := w.file.GetCellFormula(w.sheetName, cell)
+		if err != nil {
+			return "", fmt.Errorf("failed to get formula: %w", err)
+		}
+		if formula != "" {
+			return w.file.CalcCellValue(w.sheetName, cell)
+		}
+	}
+	return value, nil
+}
+
+func (w *ExcelizeWorksheet) GetTypedValue(cell string) (*TypedValue, error) {
+	formatted, err := w.GetValue(cell)
+	if err != nil {
+		// The formulas calculated on the fly fail with their error values (e.g., #DIV/0!)
+		if isFormulaErrorValue(err.Error()) {
+			return &TypedValue{Type: CellValueError, Raw: err.Error(), Formatted: err.Error()}, nil
+		}
+		return nil, err
+	}
+	cellType, err := w.file.GetCellType(w.sheetName, cell)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := w.file.GetCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	if raw == "" && formatted != "" {
+		// the formula has no cached value, so the value is calculated on the fly
+		if raw, err = w.file.CalcCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true}); err != nil {
+			return nil, err
+		}
+		cellType = excelize.CellTypeUnset
+	}
+	switch cellType {
+	case excelize.CellTypeBool:
+		if raw == "1" || strings.EqualFold(raw, "true") {
+			return &TypedValue{Type: CellValueBoolean, Raw: "TRUE", Formatted: formatted}, nil
+		}
+		return &TypedValue{Type: CellValueBoolean, Raw: "FALSE", Formatted: formatted}, nil
+	case excelize.CellTypeError:
+		return &TypedValue{Type: CellValueError, Raw: raw, Formatted: formatted}, nil
+	case excelize.CellTypeSharedString, excelize.CellTypeInlineString, excelize.CellTypeFormula:
+		// The type of the cached string results of formulas is CellTypeFormula
+		return &TypedValue{Type: CellValueString, Raw: raw, Formatted: formatted}, nil
+	case excelize.CellTypeDate:
+		return &TypedValue{Type: CellValueDate, Raw: raw, Formatted: formatted}, nil
+	}
+	dateFormatted, err := w.isDateFormatted(cell)
+	if err != nil {
+		return nil, err
+	}
+	return inferTypedValue(raw, formatted, dateFormatted), nil
+}
+
+// isDateFormatted reports whether the number format of the cell formats numbers as dates or times
+func (w *ExcelizeWorksheet) isDateFormatted(cell string) (bool, error) {
+	styleID, err := w.file.GetCellStyle(w.sheetName, cell)
+	if err != nil {
+		return false, err
+	}
+	style, err := w.file.GetStyle(styleID)
+	if err != nil {
+		return false, err
+	}
+	if style.CustomNumFmt != nil {
+		return isDateNumFmt(*style.CustomNumFmt), nil
+	}
+	return slices.Contains(builtInDateNumFmtIDs, style.NumFmt), nil
+}
+
+func (w *ExcelizeWorksheet) GetFormula(cell string) (string, error) {
+	formula, err := w.file.GetCellFormula(w.sheetName, cell)
+	if err != nil {
+		return "", fmt.Errorf("failed to get formula: %w", err)
+	}
+	if formula == "" {
+		// fallback
+		return w.GetValue(cell)
+	}
+	if !strings.HasPrefix(formula, "=") {
+		formula = "=" + formula
+	}
+	return formula, nil
+}
+
+func (w *ExcelizeWorksheet) TraceFormula(cell string, depth int) (*FormulaTrace, error) {
+	graph, err := newExcelizeFormulaGraph(&ExcelizeExcel{file: w.file})
+	if err != nil {
+		return nil, err
+	}
+	return graph.trace(graph.findCell(w.sheetName, cell), depth, func(cell formulaCell) string {
+		worksheet := &ExcelizeWorksheet{file: w.file, sheetName: cell.sheet}
+		typedValue, err := worksheet.GetTypedValue(cell.cell)
+		if err != nil {
+			return ""
+		}
+		return typedValue.Formatted
+	}), nil
+}
+
+func (w *ExcelizeWorksheet) GetDimention() (string, error) {
+	return w.file.GetSheetDimension(w.sheetName)
+}
+
+func (w *ExcelizeWorksheet) GetPagingStrategy(pageSize int) (PagingStrategy, error) {
+	return NewExcelizeFixedSizePagingStrategy(pageSize, w)
+}
+
+func (w *ExcelizeWorksheet) CapturePicture(captureRange string) (string, error) {
+	return "", fmt.Errorf("CapturePicture is not supported in Excelize")
+}
+
+func (w *ExcelizeWorksheet) AddTable(tableRange, tableName string) error {
+	enable := true
+	if err := w.file.AddTable(w.sheetName, &excelize.Table{
+		Range:             tableRange,
+		Name:              tableName,
+		StyleName:         "TableStyleMedium2",
+		ShowColumnStripes: true,
+		ShowFirstColumn:   false,
+		ShowHeaderRow:     &enable,
+		ShowLastColumn:    false,
+		ShowRowStripes:    &enable,
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetCellStyle(cell string) (*CellStyle, error) {
+	styleID, err := w.file.GetCellStyle(w.sheetName, cell)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cell style: %w", err)
+	}
+
+	style, err := w.file.GetStyle(styleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get style details: %w", err)
+	}
+
+	return convertExcelizeStyleToCellStyle(style), nil
+}
+
+func convertExcelizeStyleToCellStyle(style *excelize.Style) *CellStyle {
+	result := &CellStyle{}
+
+	// Border
+	if len(style.Border) > 0 {
+		var borders []BorderStyle
+		for _, border := range style.Border {
+			borderStyle := BorderStyle{
+				Type: border.Type,
+			}
+			if border.Color != "" {
+				borderStyle.Color = "#" + strings.ToUpper(border.Color)
+			}
+			if border.Style != 0 {
+				borderStyle.Style = intToBorderStyleName(border.Style)
+			}
+			borders = append(borders, borderStyle)
+		}
+		if len(borders) > 0 {
+			result.Border = borders
+		}
+	}
+
+	// Font
+	if style.Font != nil {
+		font := &FontStyle{}
+		if style.Font.Bold {
+			font.Bold = &style.Font.Bold
+		}
+		if style.Font.Italic {
+			font.Italic = &style.Font.Italic
+		}
+		if style.Font.Underline != "" {
+			font.Underline = style.Font.Underline
+		}
+		if style.Font.Size > 0 {
+			font.Size = style.Font.Size
+		}
+		if style.Font.Strike {
+			font.Strike = &style.Font.Strike
+		}
+		if style.Font.Color != "" {
+			font.Color = "#" + strings.ToUpper(style.Font.Color)
+		}
+		if style.Font.VertAlign != "" {
+			font.VertAlign = style.Font.VertAlign
+		}
+		if font.Bold != nil || font.Italic != nil || font.Underline != "" || font.Size > 0 || font.Strike != nil || font.Color != "" || font.VertAlign != "" {
+			result.Font = font
+		}
+	}
+
+	// Fill
+	if style.Fill.Type != "" || style.Fill.Pattern != 0 || len(style.Fill.Color) > 0 {
+		fill := &FillStyle{}
 		if style.Fill.Type != "" {
 			fill.Type = style.Fill.Type
 		}
-		if style.Fill.Pattern != 0 {
-			fill.Pattern = intToFillPatternName(style.Fill.Pattern)
+		if style.Fill.Pattern != 0 {
+			fill.Pattern = intToFillPatternName(style.Fill.Pattern)
+		}
+		if len(style.Fill.Color) > 0 {
+			var colors []string
+			for _, color := range style.Fill.Color {
+				if color != "" {
+					colors = append(colors, "#"+strings.ToUpper(color))
+				}
+			}
+			if len(colors) > 0 {
+				fill.Color = colors
+			}
+		}
+		if style.Fill.Shading != 0 {
+			fill.Shading = intToFillShadingName(style.Fill.Shading)
+		}
+		if fill.Type != "" || fill.Pattern != FillPatternNone || len(fill.Color) > 0 || fill.Shading != FillShadingHorizontal {
+			result.Fill = fill
+		}
+	}
+
+	// NumFmt
+	if style.CustomNumFmt != nil && *style.CustomNumFmt != "" {
+		result.NumFmt = *style.CustomNumFmt
+	}
+
+	// DecimalPlaces
+	if style.DecimalPlaces != nil && *style.DecimalPlaces != 0 {
+		result.DecimalPlaces = *style.DecimalPlaces
+	}
+
+	return result
+}
+
+func intToBorderStyleName(style int) BorderStyleName {
+	styles := map[int]BorderStyleName{
+		0:  BorderStyleNone,
+		1:  BorderStyleContinuous,
+		2:  BorderStyleMedium,
+		3:  BorderStyleDash,
+		4:  BorderStyleDot,
+		5:  BorderStyleThick,
+		6:  BorderStyleDouble,
+		7:  BorderStyleHair,
+		8:  BorderStyleMediumDash,
+		9:  BorderStyleDashDot,
+		10: BorderStyleMediumDashDot,
+		11: BorderStyleDashDotDot,
+		12: BorderStyleMediumDashDotDot,
+		13: BorderStyleSlantDashDot,
+	}
+	if name, exists := styles[style]; exists {
+		return name
+	}
+	return BorderStyleContinuous
+}
+
+func intToFillPatternName(pattern int) FillPatternName {
+	patterns := map[int]FillPatternName{
+		0:  FillPatternNone,
+		1:  FillPatternSolid,
+		2:  FillPatternMediumGray,
+		3:  FillPatternDarkGray,
+		4:  FillPatternLightGray,
+		5:  FillPatternDarkHorizontal,
+		6:  FillPatternDarkVertical,
+		7:  FillPatternDarkDown,
+		8:  FillPatternDarkUp,
+		9:  FillPatternDarkGrid,
+		10: FillPatternDarkTrellis,
+		11: FillPatternLightHorizontal,
+		12: FillPatternLightVertical,
+		13: FillPatternLightDown,
+		14: FillPatternLightUp,
+		15: FillPatternLightGrid,
+		16: FillPatternLightTrellis,
+		17: FillPatternGray125,
+		18: FillPatternGray0625,
+	}
+	if name, exists := patterns[pattern]; exists {
+		return name
+	}
+	return FillPatternNone
+}
+
+func intToFillShadingName(shading int) FillShadingName {
+	shadings := map[int]FillShadingName{
+		0: FillShadingHorizontal,
+		1: FillShadingVertical,
+		2: FillShadingDiagonalDown,
+		3: FillShadingDiagonalUp,
+		4: FillShadingFromCenter,
+		5: FillShadingFromCorner,
+	}
+	if name, exists := shadings[shading]; exists {
+		return name
+	}
+	return FillShadingHorizontal
+}
+
+// SetCellStyle merges the given style into the existing style of each cell in the range
+func (w *ExcelizeWorksheet) SetCellStyle(cellRange string, style *CellStyle) error {
+	if style == nil {
+		return fmt.Errorf("cell style cannot be nil")
+	}
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(cellRange)
+	if err != nil {
+		return err
+	}
+
+	// cells sharing the same style before the update share the same style after it
+	mergedStyleIDs := make(map[int]int)
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return err
+			}
+			styleID, err := w.file.GetCellStyle(w.sheetName, cell)
+			if err != nil {
+				return fmt.Errorf("failed to get cell style: %w", err)
+			}
+			mergedStyleID, exists := mergedStyleIDs[styleID]
+			if !exists {
+				existingStyle, err := w.file.GetStyle(styleID)
+				if err != nil {
+					return fmt.Errorf("failed to get style details: %w", err)
+				}
+				mergeCellStyleIntoExcelizeStyle(existingStyle, style)
+				mergedStyleID, err = w.file.NewStyle(existingStyle)
+				if err != nil {
+					return fmt.Errorf("failed to create style: %w", err)
+				}
+				mergedStyleIDs[styleID] = mergedStyleID
+			}
+			if err := w.file.SetCellStyle(w.sheetName, cell, cell, mergedStyleID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeCellStyleIntoExcelizeStyle overwrites the elements of base which are specified in style
+func mergeCellStyleIntoExcelizeStyle(base *excelize.Style, style *CellStyle) {
+	// Border
+	for _, border := range style.Border {
+		borders := make([]excelize.Border, 0, len(base.Border)+1)
+		for _, existing := range base.Border {
+			if existing.Type != border.Type {
+				borders = append(borders, existing)
+			}
+		}
+		if border.Style != BorderStyleNone {
+			borders = append(borders, excelize.Border{
+				Type:  border.Type,
+				Style: borderStyleNameToInt(border.Style),
+				Color: border.Color,
+			})
+		}
+		base.Border = borders
+	}
+
+	// Font
+	if style.Font != nil {
+		if base.Font == nil {
+			base.Font = &excelize.Font{}
+		}
+		if style.Font.Bold != nil {
+			base.Font.Bold = *style.Font.Bold
+		}
+		if style.Font.Italic != nil {
+			base.Font.Italic = *style.Font.Italic
+		}
+		if style.Font.Underline != "" {
+			base.Font.Underline = style.Font.Underline
+		}
+		if style.Font.Size > 0 {
+			base.Font.Size = style.Font.Size
+		}
+		if style.Font.Strike != nil {
+			base.Font.Strike = *style.Font.Strike
+		}
+		if style.Font.Color != "" {
+			base.Font.Color = style.Font.Color
+			base.Font.ColorIndexed = 0
+			base.Font.ColorTheme = nil
+			base.Font.ColorTint = 0
+		}
+		if style.Font.VertAlign != "" {
+			base.Font.VertAlign = style.Font.VertAlign
+		}
+	}
+
+	// Fill
+	if style.Fill != nil {
+		fill := excelize.Fill{
+			Type:    style.Fill.Type,
+			Pattern: int(style.Fill.Pattern),
+			Color:   style.Fill.Color,
+			Shading: int(style.Fill.Shading),
+		}
+		if fill.Type == "" {
+			fill.Type = "pattern"
+		}
+		if fill.Type == "pattern" && fill.Pattern == int(FillPatternNone) && len(fill.Color) > 0 {
+			fill.Pattern = int(FillPatternSolid)
+		}
+		base.Fill = fill
+	}
+
+	// NumFmt
+	if style.NumFmt != "" {
+		numFmt := style.NumFmt
+		base.CustomNumFmt = &numFmt
+	} else if style.DecimalPlaces > 0 {
+		numFmt := decimalPlacesToNumFmt(style.DecimalPlaces)
+		base.CustomNumFmt = &numFmt
+	}
+}
+
+func borderStyleNameToInt(style BorderStyleName) int {
+	styles := map[BorderStyleName]int{
+		BorderStyleNone:             0,
+		BorderStyleContinuous:       1,
+		BorderStyleMedium:           2,
+		BorderStyleDash:             3,
+		BorderStyleDot:              4,
+		BorderStyleThick:            5,
+		BorderStyleDouble:           6,
+		BorderStyleHair:             7,
+		BorderStyleMediumDash:       8,
+		BorderStyleDashDot:          9,
+		BorderStyleMediumDashDot:    10,
+		BorderStyleDashDotDot:       11,
+		BorderStyleMediumDashDotDot: 12,
+		BorderStyleSlantDashDot:     13,
+	}
+	if value, exists := styles[style]; exists {
+		return value
+	}
+	return 1
+}
+
+func (w *ExcelizeWorksheet) InsertRows(row int, count int) (*ShiftResult, error) {
+	return w.shiftCells(cellShift{rows: true, start: row, offset: count}, func() error {
+		return w.file.InsertRows(w.sheetName, row, count)
+	})
+}
+
+func (w *ExcelizeWorksheet) DeleteRows(row int, count int) (*ShiftResult, error) {
+	return w.shiftCells(cellShift{rows: true, start: row, offset: -count}, func() error {
+		for i := 0; i < count; i++ {
+			if err := w.file.RemoveRow(w.sheetName, row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (w *ExcelizeWorksheet) InsertColumns(col int, count int) (*ShiftResult, error) {
+	colName, err := excelize.ColumnNumberToName(col)
+	if err != nil {
+		return nil, err
+	}
+	return w.shiftCells(cellShift{rows: false, start: col, offset: count}, func() error {
+		return w.file.InsertCols(w.sheetName, colName, count)
+	})
+}
+
+func (w *ExcelizeWorksheet) DeleteColumns(col int, count int) (*ShiftResult, error) {
+	colName, err := excelize.ColumnNumberToName(col)
+	if err != nil {
+		return nil, err
+	}
+	return w.shiftCells(cellShift{rows: false, start: col, offset: -count}, func() error {
+		for i := 0; i < count; i++ {
+			if err := w.file.RemoveCol(w.sheetName, colName); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// shiftCells runs shiftFn, which inserts or deletes rows or columns, and reports the adjusted references.
+// Excelize adjusts formulas, tables and defined names by itself, but not the sheet dimension.
+func (w *ExcelizeWorksheet) shiftCells(s cellShift, shiftFn func() error) (*ShiftResult, error) {
+	if s.start < 1 || s.offset == 0 {
+		return nil, fmt.Errorf("invalid position or count: %d, %d", s.start, s.offset)
+	}
+	before, err := w.takeShiftSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	tables, err := w.file.GetTables(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+	if err := shiftFn(); err != nil {
+		return nil, err
+	}
+	if err := w.repairShiftedTables(s, tables); err != nil {
+		return nil, fmt.Errorf("failed to adjust tables: %w", err)
+	}
+	if err := w.shiftDimension(s); err != nil {
+		return nil, fmt.Errorf("failed to update dimension: %w", err)
+	}
+	after, err := w.takeShiftSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return s.diff(w.sheetName, before, after), nil
+}
+
+// repairShiftedTables recreates the tables whose range has been lost by the shift.
+// Excelize clears the range of a table when the first column of the table is deleted.
+func (w *ExcelizeWorksheet) repairShiftedTables(s cellShift, tablesBeforeShift []excelize.Table) error {
+	tables, err := w.file.GetTables(w.sheetName)
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if table.Range != "" {
+			continue
+		}
+		if err := w.file.DeleteTable(table.Name); err != nil {
+			return err
+		}
+		for _, tableBeforeShift := range tablesBeforeShift {
+			if tableBeforeShift.Name != table.Name {
+				continue
+			}
+			startCol, startRow, endCol, endRow, err := ParseRange(tableBeforeShift.Range)
+			if err != nil {
+				return err
+			}
+			startCol, startRow, endCol, endRow, ok := s.moveRange(startCol, startRow, endCol, endRow)
+			if !ok {
+				break
+			}
+			startCell, _ := excelize.CoordinatesToCellName(startCol, startRow)
+			endCell, _ := excelize.CoordinatesToCellName(endCol, endRow)
+			tableBeforeShift.Range = fmt.Sprintf("%s:%s", startCell, endCell)
+			if err := w.file.AddTable(w.sheetName, &tableBeforeShift); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) shiftDimension(s cellShift) error {
+	dimension, err := w.file.GetSheetDimension(w.sheetName)
+	if err != nil || dimension == "" {
+		return err
+	}
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(dimension)
+	if err != nil {
+		return err
+	}
+	startCol, startRow, endCol, endRow, ok := s.moveRange(startCol, startRow, endCol, endRow)
+	if !ok {
+		return w.file.SetSheetDimension(w.sheetName, "A1")
+	}
+	startCell, err := excelize.CoordinatesToCellName(startCol, startRow)
+	if err != nil {
+		return err
+	}
+	endCell, err := excelize.CoordinatesToCellName(endCol, endRow)
+	if err != nil {
+		return err
+	}
+	return w.file.SetSheetDimension(w.sheetName, fmt.Sprintf("%s:%s", startCell, endCell))
+}
+
+func (w *ExcelizeWorksheet) takeShiftSnapshot() (*shiftSnapshot, error) {
+	snapshot := newShiftSnapshot()
+	tables, err := w.GetTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		snapshot.tables[table.Name] = table.Range
+	}
+	for _, definedName := range w.file.GetDefinedName() {
+		name := definedName.Name
+		if definedName.Scope != "" && definedName.Scope != "Workbook" {
+			name = fmt.Sprintf("%s!%s", definedName.Scope, definedName.Name)
+		}
+		snapshot.definedNames[name] = definedName.RefersTo
+	}
+	for _, sheetName := range w.file.GetSheetList() {
+		formulas, err := getExcelizeFormulas(w.file, sheetName)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.formulas[sheetName] = formulas
+	}
+	return snapshot, nil
+}
+
+// getExcelizeFormulas returns all formulas in the sheet keyed by cell name
+func getExcelizeFormulas(file *excelize.File, sheetName string) (map[string]string, error) {
+	formulas := make(map[string]string)
+	rows, err := file.Rows(sheetName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for row := 1; rows.Next(); row++ {
+		columns, err := rows.Columns(excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, err
+		}
+		for col := 1; col <= len(columns); col++ {
+			cell, err := excelize.CoordinatesToCellName(col, row)
+			if err != nil {
+				return nil, err
+			}
+			formula, err := file.GetCellFormula(sheetName, cell)
+			if err != nil {
+				return nil, err
+			}
+			if formula != "" {
+				formulas[cell] = formula
+			}
+		}
+	}
+	return formulas, nil
+}
+
+func (w *ExcelizeWorksheet) GetMergedCells() ([]string, error) {
+	mergeCells, err := w.file.GetMergeCells(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged cells: %w", err)
+	}
+	mergedCells := make([]string, len(mergeCells))
+	for i, mergeCell := range mergeCells {
+		mergedCells[i] = fmt.Sprintf("%s:%s", mergeCell.GetStartAxis(), mergeCell.GetEndAxis())
+	}
+	return mergedCells, nil
+}
+
+func (w *ExcelizeWorksheet) MergeCells(cellRange string) error {
+	topLeftCell, bottomRightCell, err := splitRange(cellRange)
+	if err != nil {
+		return err
+	}
+	return w.file.MergeCell(w.sheetName, topLeftCell, bottomRightCell)
+}
+
+func (w *ExcelizeWorksheet) UnmergeCells(cellRange string) error {
+	topLeftCell, bottomRightCell, err := splitRange(cellRange)
+	if err != nil {
+		return err
+	}
+	return w.file.UnmergeCell(w.sheetName, topLeftCell, bottomRightCell)
+}
+
+func (w *ExcelizeWorksheet) GetComments() ([]Comment, error) {
+	comments, err := w.file.GetComments(w.sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	result := make([]Comment, len(comments))
+	for i, comment := range comments {
+		text := comment.Text
+		if text == "" {
+			for _, run := range comment.Paragraph {
+				text += run.Text
+			}
+		}
+		result[i] = Comment{
+			Cell:   comment.Cell,
+			Author: comment.Author,
+			Text:   text,
+		}
+	}
+	return result, nil
+}
+
+func (w *ExcelizeWorksheet) AddComment(comment *Comment) error {
+	cell := strings.ReplaceAll(comment.Cell, "$", "")
+	// Excelize adds another comment to the cell instead of replacing the existing one
+	if err := w.file.DeleteComment(w.sheetName, cell); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	commentCounts := make(map[string]int)
+	for partPath, comments := range w.file.Comments {
+		if comments != nil {
+			commentCounts[partPath] = len(comments.CommentList.Comment)
+		}
+	}
+	err := w.file.AddComment(w.sheetName, excelize.Comment{
+		Cell:   cell,
+		Author: comment.Author,
+		Text:   comment.Text,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	// Excelize sets the author ID to 0 if the author is already listed in the comments part,
+	// so the author ID of the added comment is corrected
+	author := comment.Author
+	if author == "" {
+		author = "Author"
+	}
+	for partPath, comments := range w.file.Comments {
+		if comments == nil || len(comments.CommentList.Comment) <= commentCounts[partPath] {
+			continue
+		}
+		added := &comments.CommentList.Comment[len(comments.CommentList.Comment)-1]
+		if authorID := slices.IndexFunc(comments.Authors.Author, func(a string) bool { return strings.EqualFold(a, author) }); authorID >= 0 {
+			added.AuthorID = authorID
+		}
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) DeleteComment(cell string) error {
+	cell = strings.ReplaceAll(cell, "$", "")
+	comments, err := w.GetComments()
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(comments, func(comment Comment) bool { return strings.EqualFold(comment.Cell, cell) }) {
+		return fmt.Errorf("no comment in cell %s", cell)
+	}
+	if err := w.file.DeleteComment(w.sheetName, cell); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetHyperlink(cell string) (*Hyperlink, error) {
+	ok, target, err := w.file.GetCellHyperLink(w.sheetName, strings.ReplaceAll(cell, "$", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hyperlink: %w", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &Hyperlink{Target: target}, nil
+}
+
+func (w *ExcelizeWorksheet) SetHyperlink(cell string, hyperlink *Hyperlink) error {
+	cell = strings.ReplaceAll(cell, "$", "")
+	// The existing hyperlink is removed first so that the relationship of an external link does not remain
+	if err := w.file.SetCellHyperLink(w.sheetName, cell, "", "None"); err != nil {
+		return fmt.Errorf("failed to remove hyperlink: %w", err)
+	}
+	if hyperlink == nil {
+		return nil
+	}
+
+	linkType := "Location"
+	if hyperlink.IsExternal() {
+		linkType = "External"
+	}
+	opts := excelize.HyperlinkOpts{}
+	if hyperlink.Tooltip != "" {
+		opts.Tooltip = &hyperlink.Tooltip
+	}
+	if err := w.file.SetCellHyperLink(w.sheetName, cell, hyperlink.Target, linkType, opts); err != nil {
+		return fmt.Errorf("failed to set hyperlink: %w", err)
+	}
+	// Excelize does not apply the hyperlink style as Excel does
+	return w.SetCellStyle(cell, &CellStyle{
+		Font: &FontStyle{
+			Underline: "single",
+			Color:     "#0563C1",
+		},
+	})
+}
+
+func (w *ExcelizeWorksheet) GetPanes() (*Panes, error) {
+	panes, err := w.file.GetPanes(w.sheetName)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case panes.Freeze:
+		return &Panes{Mode: PanesFreeze, Rows: panes.YSplit, Columns: panes.XSplit}, nil
+	case panes.XSplit > 0 || panes.YSplit > 0:
+		// The split position is in twips, so it is converted to the number of columns and rows
+		columns, rows, err := w.splitCount(panes.XSplit, panes.YSplit)
+		if err != nil {
+			return nil, err
+		}
+		return &Panes{Mode: PanesSplit, Rows: rows, Columns: columns}, nil
+	default:
+		return &Panes{Mode: PanesNone}, nil
+	}
+}
+
+func (w *ExcelizeWorksheet) SetPanes(panes *Panes) error {
+	viewOptions, err := w.GetViewOptions()
+	if err != nil {
+		return err
+	}
+	options := &excelize.Panes{
+		Freeze: panes.Mode == PanesFreeze,
+		Split:  panes.Mode == PanesSplit,
+	}
+	if panes.Mode != PanesNone {
+		if panes.Rows == 0 && panes.Columns == 0 {
+			return fmt.Errorf("rows or columns must be greater than 0 to %s panes", panes.Mode)
+		}
+		options.XSplit, options.YSplit = panes.Columns, panes.Rows
+		if panes.Mode == PanesSplit {
+			if options.XSplit, options.YSplit, err = w.splitPosition(panes.Columns, panes.Rows); err != nil {
+				return err
+			}
+		}
+		options.TopLeftCell, _ = excelize.CoordinatesToCellName(panes.Columns+1, panes.Rows+1)
+		options.ActivePane = excelizeActivePane(panes.Rows, panes.Columns)
+	}
+	options.Selection = []excelize.Selection{{
+		SQRef:      viewOptions.SelectedCell,
+		ActiveCell: viewOptions.SelectedCell,
+		Pane:       options.ActivePane,
+	}}
+	if err := w.file.SetPanes(w.sheetName, options); err != nil {
+		return fmt.Errorf("failed to set panes: %w", err)
+	}
+	return nil
+}
+
+// excelizeActivePane returns the pane containing the cells below and right of the split
+func excelizeActivePane(rows int, columns int) string {
+	switch {
+	case rows > 0 && columns > 0:
+		return "bottomRight"
+	case rows > 0:
+		return "bottomLeft"
+	case columns > 0:
+		return "topRight"
+	default:
+		return ""
+	}
+}
+
+// splitPosition estimates the split position in twips (1/20 points) from the widths of the columns and the heights of the rows
+func (w *ExcelizeWorksheet) splitPosition(columns int, rows int) (int, int, error) {
+	x, y := 0.0, 0.0
+	for col := 1; col <= columns; col++ {
+		name, err := excelize.ColumnNumberToName(col)
+		if err != nil {
+			return 0, 0, err
+		}
+		width, err := w.file.GetColWidth(w.sheetName, name)
+		if err != nil {
+			return 0, 0, err
+		}
+		x += excelizeColumnWidthToPoints(width)
+	}
+	for row := 1; row <= rows; row++ {
+		height, err := w.file.GetRowHeight(w.sheetName, row)
+		if err != nil {
+			return 0, 0, err
+		}
+		y += height
+	}
+	return int(x * 20), int(y * 20), nil
+}
+
+// splitCount converts the split position in twips to the number of columns and rows left of and above the split
+func (w *ExcelizeWorksheet) splitCount(x int, y int) (int, int, error) {
+	columns, rows := 0, 0
+	for position := 0.0; position < float64(x)/20 && columns < excelize.MaxColumns; columns++ {
+		name, err := excelize.ColumnNumberToName(columns + 1)
+		if err != nil {
+			return 0, 0, err
+		}
+		width, err := w.file.GetColWidth(w.sheetName, name)
+		if err != nil {
+			return 0, 0, err
+		}
+		position += excelizeColumnWidthToPoints(width)
+	}
+	for position := 0.0; position < float64(y)/20 && rows < excelize.TotalRows; rows++ {
+		height, err := w.file.GetRowHeight(w.sheetName, rows+1)
+		if err != nil {
+			return 0, 0, err
+		}
+		position += height
+	}
+	return columns, rows, nil
+}
+
+// excelizeColumnWidthToPoints converts the column width in characters to points in the same way as Excelize
+func excelizeColumnWidthToPoints(width float64) float64 {
+	// The maximum digit width of the default font is 7 pixels with 5 pixels of padding, and 1 pixel is 0.75 points
+	switch {
+	case width == 0:
+		return 0
+	case width < 1:
+		return math.Ceil(width*12+0.5) * 0.75
+	default:
+		return math.Ceil(width*7+0.5+5) * 0.75
+	}
+}
+
+func (w *ExcelizeWorksheet) GetViewOptions() (*ViewOptions, error) {
+	view, err := w.file.GetSheetView(w.sheetName, 0)
+	if err != nil {
+		return nil, err
+	}
+	panes, err := w.file.GetPanes(w.sheetName)
+	if err != nil {
+		return nil, err
+	}
+	showGridLines, rightToLeft, zoom := true, false, 100
+	if view.ShowGridLines != nil {
+		showGridLines = *view.ShowGridLines
+	}
+	if view.RightToLeft != nil {
+		rightToLeft = *view.RightToLeft
+	}
+	if view.ZoomScale != nil && *view.ZoomScale > 0 {
+		zoom = int(*view.ZoomScale)
+	}
+	selectedCell := "A1"
+	for _, selection := range panes.Selection {
+		if selection.ActiveCell != "" && (selection.Pane == panes.ActivePane || selectedCell == "A1") {
+			selectedCell = selection.ActiveCell
+		}
+	}
+	return &ViewOptions{
+		ShowGridLines: &showGridLines,
+		Zoom:          &zoom,
+		RightToLeft:   &rightToLeft,
+		SelectedCell:  selectedCell,
+	}, nil
+}
+
+func (w *ExcelizeWorksheet) SetViewOptions(options *ViewOptions) error {
+	view := &excelize.ViewOptions{
+		ShowGridLines: options.ShowGridLines,
+		RightToLeft:   options.RightToLeft,
+	}
+	if options.Zoom != nil {
+		zoom := float64(*options.Zoom)
+		view.ZoomScale = &zoom
+	}
+	if err := w.file.SetSheetView(w.sheetName, 0, view); err != nil {
+		return fmt.Errorf("failed to set sheet view: %w", err)
+	}
+	if options.SelectedCell == "" {
+		return nil
+	}
+	// Excelize sets the selection with the panes, so the current panes are set again
+	panes, err := w.file.GetPanes(w.sheetName)
+	if err != nil {
+		return err
+	}
+	panes.Split = !panes.Freeze && (panes.XSplit > 0 || panes.YSplit > 0)
+	panes.Selection = []excelize.Selection{{
+		SQRef:      options.SelectedCell,
+		ActiveCell: options.SelectedCell,
+		Pane:       panes.ActivePane,
+	}}
+	if err := w.file.SetPanes(w.sheetName, &panes); err != nil {
+		return fmt.Errorf("failed to set selected cell: %w", err)
+	}
+	return nil
+}
+
+func (w *ExcelizeWorksheet) GetAutoFilter() (*AutoFilter, error) {
+	autoFilter, err := w.getXlsxAutoFilter()
+	if err != nil || autoFilter == nil {
+		return nil, err
+	}
+	startCol, _, _, _, err := ParseCellOrRange(autoFilter.Ref)
+	if err != nil {
+		return nil, err
+	}
+	filter := &AutoFilter{Range: autoFilter.Ref, Columns: []AutoFilterColumn{}}
+	for _, filterColumn := range autoFilter.FilterColumn {
+		filter.Columns = append(filter.Columns, fromXlsxFilterColumn(filterColumn, startCol))
+	}
+	return filter, nil
+}
+
+// getXlsxAutoFilter returns the autoFilter element of the worksheet, which is the one set or removed since the
+// workbook was opened if any, or the one Excelize writes.
+func (w *ExcelizeWorksheet) getXlsxAutoFilter() (*xlsxAutoFilter, error) {
+	sheetName, err := w.storedSheetName()
+	if err != nil {
+		return nil, err
+	}
+	if autoFilter, ok := w.autoFilters[sheetName]; ok {
+		return autoFilter, nil
+	}
+	return readExcelizeAutoFilter(w.file, sheetName)
+}
+
+// storedSheetName returns the sheet name as it is stored in the workbook, since sheet names are case-insensitive
+func (w *ExcelizeWorksheet) storedSheetName() (string, error) {
+	index, err := w.file.GetSheetIndex(w.sheetName)
+	if err != nil {
+		return "", err
+	}
+	if index < 0 {
+		return "", fmt.Errorf("sheet not found: %s", w.sheetName)
+	}
+	return w.file.GetSheetName(index), nil
+}
+
+// matchAutoFilterColumn evaluates the criteria of the column against the formatted and raw values of the cells
+// below the header row. It returns whether each row is shown and the numeric values in the column.
+func (w *ExcelizeWorksheet) matchAutoFilterColumn(column AutoFilterColumn, col int, startRow int, endRow int) ([]bool, []float64, error) {
+	texts, raws := make([]string, endRow-startRow), make([]string, endRow-startRow)
+	numbers := []float64{}
+	for i := range texts {
+		cell, err := excelize.CoordinatesToCellName(col, startRow+1+i)
+		if err != nil {
+			return nil, nil, err
+		}
+		if texts[i], err = w.file.GetCellValue(w.sheetName, cell); err != nil {
+			return nil, nil, err
+		}
+		cellType, err := w.file.GetCellType(w.sheetName, cell)
+		if err != nil {
+			return nil, nil, err
+		}
+		if cellType == excelize.CellTypeUnset || cellType == excelize.CellTypeNumber {
+			if raws[i], err = w.file.GetCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true}); err != nil {
+				return nil, nil, err
+			}
+		}
+		if number, err := strconv.ParseFloat(raws[i], 64); err == nil {
+			numbers = append(numbers, number)
+		}
+	}
+	match, err := autoFilterMatcher(column, numbers)
+	if err != nil {
+		return nil, nil, err
+	}
+	shown := make([]bool, len(texts))
+	for i := range shown {
+		shown[i] = match(texts[i], raws[i])
+	}
+	return shown, numbers, nil
+}
+
+// SetAutoFilter sets the auto filter, whose element is written when the workbook is saved.
+// Excelize can write neither top N filters nor more than two values of a column.
+func (w *ExcelizeWorksheet) SetAutoFilter(filter *AutoFilter) error {
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(filter.Range)
+	if err != nil {
+		return err
+	}
+	if startRow == endRow {
+		return fmt.Errorf("auto filter range must have rows below the header row: %s", filter.Range)
+	}
+	startCell, endCell, err := splitRange(filter.Range)
+	if err != nil {
+		return err
+	}
+	ref := startCell + ":" + endCell
+	tables, err := w.GetTables()
+	if err != nil {
+		return err
+	}
+	for _, table := range tables {
+		if rangesOverlap(ref, table.Range) {
+			return fmt.Errorf("auto filter range %s overlaps table %s, which has its own filter", ref, table.Name)
+		}
+	}
+	sheetName, err := w.storedSheetName()
+	if err != nil {
+		return err
+	}
+
+	autoFilter := &xlsxAutoFilter{Ref: ref}
+	visible := make([]bool, endRow-startRow)
+	for i := range visible {
+		visible[i] = true
+	}
+	filteredColumns := map[int]bool{}
+	for _, column := range filter.Columns {
+		col, err := excelize.ColumnNameToNumber(column.Column)
+		if err != nil {
+			return err
 		}
-		if len(style.Fill.Color) > 0 {
-			var colors []string
-			for _, color := range style.Fill.Color {
-				if color != "" {
-					colors = append(colors, "#"+strings.ToUpper(color))
-				}
+		if col < startCol || col > endCol {
+			return fmt.Errorf("column %s is out of the auto filter range %s", column.Column, ref)
+		}
+		if filteredColumns[col] {
+			return fmt.Errorf("column %s is filtered more than once", column.Column)
+		}
+		filteredColumns[col] = true
+		shown, numbers, err := w.matchAutoFilterColumn(column, col, startRow, endRow)
+		if err != nil {
+			return err
+		}
+		for i := range visible {
+			visible[i] = visible[i] && shown[i]
+		}
+		filterColumn, err := toXlsxFilterColumn(column, col-startCol, numbers)
+		if err != nil {
+			return err
+		}
+		autoFilter.FilterColumn = append(autoFilter.FilterColumn, filterColumn)
+	}
+	slices.SortFunc(autoFilter.FilterColumn, func(a, b xlsxFilterColumn) int { return a.ColID - b.ColID })
+
+	if err := w.RemoveAutoFilter(); err != nil {
+		return err
+	}
+	absoluteStart, _ := excelize.CoordinatesToCellName(startCol, startRow, true)
+	absoluteEnd, _ := excelize.CoordinatesToCellName(endCol, endRow, true)
+	err = w.file.SetDefinedName(&excelize.DefinedName{
+		Name:     excelizeFilterDatabaseName,
+		RefersTo: qualifyReference([]string{sheetName}, absoluteStart+":"+absoluteEnd),
+		Scope:    sheetName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set auto filter: %w", err)
+	}
+	w.autoFilters[sheetName] = autoFilter
+	for i, isVisible := range visible {
+		if !isVisible {
+			if err := w.file.SetRowVisible(w.sheetName, startRow+1+i, false); err != nil {
+				return err
 			}
-			if len(colors) > 0 {
-				fill.Color = colors
+		}
+	}
+	return nil
+}
+
+// RemoveAutoFilter removes the auto filter, whose element is removed when the workbook is saved, and shows the rows
+// not matching its criteria. The other hidden rows are left hidden since the filter did not hide them.
+func (w *ExcelizeWorksheet) RemoveAutoFilter() error {
+	filter, err := w.GetAutoFilter()
+	if err != nil || filter == nil {
+		return err
+	}
+	sheetName, err := w.storedSheetName()
+	if err != nil {
+		return err
+	}
+	_, startRow, _, endRow, err := ParseCellOrRange(filter.Range)
+	if err != nil {
+		return err
+	}
+	for _, column := range filter.Columns {
+		// Criteria of the types not supported (e.g., color filters) are not evaluated
+		if column.Type == "" {
+			continue
+		}
+		col, err := excelize.ColumnNameToNumber(column.Column)
+		if err != nil {
+			return err
+		}
+		shown, _, err := w.matchAutoFilterColumn(column, col, startRow, endRow)
+		if err != nil {
+			return err
+		}
+		for i, isShown := range shown {
+			if !isShown {
+				if err := w.file.SetRowVisible(w.sheetName, startRow+1+i, true); err != nil {
+					return err
+				}
 			}
 		}
-		if style.Fill.Shading != 0 {
-			fill.Shading = intToFillShadingName(style.Fill.Shading)
+	}
+	w.autoFilters[sheetName] = nil
+	err = w.file.DeleteDefinedName(&excelize.DefinedName{Name: excelizeFilterDatabaseName, Scope: sheetName})
+	if err != nil && !errors.Is(err, excelize.ErrDefinedNameScope) {
+		return fmt.Errorf("failed to remove auto filter: %w", err)
+	}
+	return nil
+}
+
+// sortedCell is a snapshot of a cell moved by sorting
+type sortedCell struct {
+	value    string
+	cellType excelize.CellType
+	richText []excelize.RichTextRun
+	formula  string
+	style    int
+}
+
+func (w *ExcelizeWorksheet) SortRange(options *SortOptions) error {
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(options.Range)
+	if err != nil {
+		return err
+	}
+	if options.HasHeader {
+		startRow++
+	}
+	if startRow >= endRow {
+		return nil
+	}
+	mergedCells, err := w.GetMergedCells()
+	if err != nil {
+		return err
+	}
+	dataStart, _ := excelize.CoordinatesToCellName(startCol, startRow)
+	dataEnd, _ := excelize.CoordinatesToCellName(endCol, endRow)
+	dataRange := dataStart + ":" + dataEnd
+	for _, mergedCell := range mergedCells {
+		if rangesOverlap(mergedCell, dataRange) {
+			return fmt.Errorf("cannot sort %s containing merged cells %s", dataRange, mergedCell)
 		}
-		if fill.Type != "" || fill.Pattern != FillPatternNone || len(fill.Color) > 0 || fill.Shading != FillShadingHorizontal {
-			result.Fill = fill
+	}
+	keyCols := make([]int, len(options.Keys))
+	for i, key := range options.Keys {
+		if keyCols[i], err = excelize.ColumnNameToNumber(key.Column); err != nil {
+			return err
+		}
+		if keyCols[i] < startCol || keyCols[i] > endCol {
+			return fmt.Errorf("sort key column %s is out of the range %s", key.Column, options.Range)
 		}
 	}
 
-	// NumFmt
-	if style.CustomNumFmt != nil && *style.CustomNumFmt != "" {
-		result.NumFmt = *style.CustomNumFmt
+	// Take a snapshot of the cells before moving them
+	rows := make([][]sortedCell, endRow-startRow+1)
+	for i := range rows {
+		rows[i] = make([]sortedCell, endCol-startCol+1)
+		for j := range rows[i] {
+			cell, err := excelize.CoordinatesToCellName(startCol+j, startRow+i)
+			if err != nil {
+				return err
+			}
+			snapshot := &rows[i][j]
+			if snapshot.value, err = w.file.GetCellValue(w.sheetName, cell, excelize.Options{RawCellValue: true}); err != nil {
+				return err
+			}
+			if snapshot.cellType, err = w.file.GetCellType(w.sheetName, cell); err != nil {
+				return err
+			}
+			if snapshot.formula, err = w.file.GetCellFormula(w.sheetName, cell); err != nil {
+				return err
+			}
+			if snapshot.style, err = w.file.GetCellStyle(w.sheetName, cell); err != nil {
+				return err
+			}
+			if snapshot.cellType == excelize.CellTypeSharedString || snapshot.cellType == excelize.CellTypeInlineString {
+				if snapshot.richText, err = w.file.GetCellRichText(w.sheetName, cell); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
-	// DecimalPlaces
-	if style.DecimalPlaces != nil && *style.DecimalPlaces != 0 {
-		result.DecimalPlaces = *style.DecimalPlaces
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
 	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		for i, key := range options.Keys {
+			aCell, bCell := rows[a][keyCols[i]-startCol], rows[b][keyCols[i]-startCol]
+			result := compareSortValues(parseSortValue(aCell.value, aCell.cellType), parseSortValue(bCell.value, bCell.cellType), key, options)
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	})
 
-	return result
+	for i, source := range order {
+		if i == source {
+			continue
+		}
+		for j, snapshot := range rows[source] {
+			cell, err := excelize.CoordinatesToCellName(startCol+j, startRow+i)
+			if err != nil {
+				return err
+			}
+			if err := w.setSortedCell(cell, snapshot, i-source); err != nil {
+				return fmt.Errorf("failed to move cell to %s: %w", cell, err)
+			}
+		}
+	}
+	return nil
 }
 
-func intToBorderStyleName(style int) BorderStyleName {
-	styles := map[int]BorderStyleName{
-		0:  BorderStyleNone,
-		1:  BorderStyleContinuous,
-		2:  BorderStyleContinuous,
-		3:  BorderStyleDash,
-		4:  BorderStyleDot,
-		5:  BorderStyleContinuous,
-		6:  BorderStyleDouble,
-		7:  BorderStyleContinuous,
-		8:  BorderStyleDashDot,
-		9:  BorderStyleDashDotDot,
-		10: BorderStyleSlantDashDot,
-		11: BorderStyleContinuous,
-		12: BorderStyleMediumDashDot,
-		13: BorderStyleMediumDashDotDot,
+// setSortedCell sets the snapshot of the cell moved by the specified number of rows
+func (w *ExcelizeWorksheet) setSortedCell(cell string, snapshot sortedCell, rowOffset int) error {
+	var err error
+	isNumber := snapshot.cellType == excelize.CellTypeUnset || snapshot.cellType == excelize.CellTypeNumber
+	switch {
+	case snapshot.formula != "" && isNumber && snapshot.value != "":
+		// Keep the calculated value of the formula
+		err = w.file.SetCellDefault(w.sheetName, cell, snapshot.value)
+	case snapshot.formula != "":
+		// Excelize marks formula cells as strings, which cannot keep calculated text or boolean values
+		err = w.file.SetCellValue(w.sheetName, cell, nil)
+	case len(snapshot.richText) > 1:
+		err = w.file.SetCellRichText(w.sheetName, cell, snapshot.richText)
+	case snapshot.cellType == excelize.CellTypeBool:
+		err = w.file.SetCellBool(w.sheetName, cell, snapshot.value == "1" || strings.EqualFold(snapshot.value, "TRUE"))
+	case isNumber:
+		if snapshot.value == "" {
+			err = w.file.SetCellValue(w.sheetName, cell, nil)
+		} else {
+			err = w.file.SetCellDefault(w.sheetName, cell, snapshot.value)
+		}
+	default:
+		err = w.file.SetCellStr(w.sheetName, cell, snapshot.value)
 	}
-	if name, exists := styles[style]; exists {
-		return name
+	if err != nil {
+		return err
 	}
-	return BorderStyleContinuous
+	if snapshot.formula != "" {
+		if err := w.file.SetCellFormula(w.sheetName, cell, offsetFormulaReferences(snapshot.formula, rowOffset, 0)); err != nil {
+			return err
+		}
+	}
+	return w.file.SetCellStyle(w.sheetName, cell, cell, snapshot.style)
 }
 
-func intToFillPatternName(pattern int) FillPatternName {
-	patterns := map[int]FillPatternName{
-		0:  FillPatternNone,
-		1:  FillPatternSolid,
-		2:  FillPatternMediumGray,
-		3:  FillPatternDarkGray,
-		4:  FillPatternLightGray,
-		5:  FillPatternDarkHorizontal,
-		6:  FillPatternDarkVertical,
-		7:  FillPatternDarkDown,
-		8:  FillPatternDarkUp,
-		9:  FillPatternDarkGrid,
-		10: FillPatternDarkTrellis,
-		11: FillPatternLightHorizontal,
-		12: FillPatternLightVertical,
-		13: FillPatternLightDown,
-		14: FillPatternLightUp,
-		15: FillPatternLightGrid,
-		16: FillPatternLightTrellis,
-		17: FillPatternGray125,
-		18: FillPatternGray0625,
+func (w *ExcelizeWorksheet) Search(options *SearchOptions) ([]SearchMatch, error) {
+	formulas, err := getExcelizeFormulas(w.file, w.sheetName)
+	if err != nil {
+		return nil, err
 	}
-	if name, exists := patterns[pattern]; exists {
-		return name
+	found := map[string]bool{}
+	if options.Mode == SearchNumeric {
+		match, err := numericSearchMatcher(options.Query)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := w.file.GetRows(w.sheetName, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, err
+		}
+		for i, row := range rows {
+			for j, value := range row {
+				cell, _ := excelize.CoordinatesToCellName(j+1, i+1)
+				if value == "" || !cellInRange(cell, options.Range) || !match(value) {
+					continue
+				}
+				// Text which looks like a number is not a number
+				if cellType, err := w.file.GetCellType(w.sheetName, cell); err != nil {
+					return nil, err
+				} else if cellType != excelize.CellTypeSharedString && cellType != excelize.CellTypeInlineString {
+					found[cell] = true
+				}
+			}
+		}
+	} else {
+		pattern, err := searchPattern(options)
+		if err != nil {
+			return nil, err
+		}
+		if options.Target != SearchFormulas {
+			cells, err := w.file.SearchSheet(w.sheetName, pattern.String(), true)
+			if err != nil {
+				return nil, err
+			}
+			for _, cell := range cells {
+				found[cell] = true
+			}
+		}
+		if options.Target != SearchValues {
+			for cell, formula := range formulas {
+				if pattern.MatchString("=" + formula) {
+					found[cell] = true
+				}
+			}
+		}
 	}
-	return FillPatternNone
+
+	cells := []string{}
+	for cell := range found {
+		if cellInRange(cell, options.Range) {
+			cells = append(cells, cell)
+		}
+	}
+	sortCellNames(cells)
+	matches := []SearchMatch{}
+	for _, cell := range cells {
+		value, err := w.GetValue(cell)
+		if err != nil {
+			return nil, err
+		}
+		match := SearchMatch{Cell: cell, Value: value}
+		if formula, ok := formulas[cell]; ok {
+			match.Formula = "=" + formula
+		}
+		// Cells without values (e.g., styled empty cells) match patterns such as "^$"
+		if match.Value == "" && match.Formula == "" {
+			continue
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
 }
 
-func intToFillShadingName(shading int) FillShadingName {
-	shadings := map[int]FillShadingName{
-		0: FillShadingHorizontal,
-		1: FillShadingVertical,
-		2: FillShadingDiagonalDown,
-		3: FillShadingDiagonalUp,
-		4: FillShadingFromCenter,
-		5: FillShadingFromCorner,
+func (w *ExcelizeWorksheet) GetColumnWidth(column string) (float64, error) {
+	return w.file.GetColWidth(w.sheetName, column)
+}
+
+func (w *ExcelizeWorksheet) SetColumnWidth(column string, width float64) error {
+	return w.file.SetColWidth(w.sheetName, column, column, width)
+}
+
+func (w *ExcelizeWorksheet) GetRowHeight(row int) (float64, error) {
+	return w.file.GetRowHeight(w.sheetName, row)
+}
+
+func (w *ExcelizeWorksheet) SetRowHeight(row int, height float64) error {
+	return w.file.SetRowHeight(w.sheetName, row, height)
+}
+
+// Excelize has no layout engine, so the sizes are estimated from the lengths of the formatted values and the font sizes.
+func (w *ExcelizeWorksheet) AutoFitColumns(cellRange string) error {
+	return w.autoFit(cellRange, func(col int, row int, value string, style *excelize.Style, widths map[int]float64) {
+		fontSize, bold := defaultFontSize, false
+		if style.Font != nil {
+			if style.Font.Size > 0 {
+				fontSize = style.Font.Size
+			}
+			bold = style.Font.Bold
+		}
+		widths[col] = max(widths[col], estimateColumnWidth(value, fontSize, bold))
+	}, func(widths map[int]float64) error {
+		for col, width := range widths {
+			column, _ := excelize.ColumnNumberToName(col)
+			if err := w.SetColumnWidth(column, width); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (w *ExcelizeWorksheet) AutoFitRows(cellRange string) error {
+	return w.autoFit(cellRange, func(col int, row int, value string, style *excelize.Style, heights map[int]float64) {
+		fontSize, lines := defaultFontSize, 1
+		if style.Font != nil && style.Font.Size > 0 {
+			fontSize = style.Font.Size
+		}
+		// Line breaks are shown only in cells wrapping text
+		if style.Alignment != nil && style.Alignment.WrapText {
+			lines = strings.Count(value, "\n") + 1
+		}
+		heights[row] = max(heights[row], estimateRowHeight(lines, fontSize))
+	}, func(heights map[int]float64) error {
+		for row, height := range heights {
+			if err := w.SetRowHeight(row, height); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// autoFit calls measure with each non-empty cell of the range outside merged cells, then apply with the measured sizes
+func (w *ExcelizeWorksheet) autoFit(cellRange string, measure func(col int, row int, value string, style *excelize.Style, sizes map[int]float64), apply func(sizes map[int]float64) error) error {
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(cellRange)
+	if err != nil {
+		return err
 	}
-	if name, exists := shadings[shading]; exists {
-		return name
+	mergedCells, err := w.GetMergedCells()
+	if err != nil {
+		return err
 	}
-	return FillShadingHorizontal
+	styles := map[int]*excelize.Style{}
+	sizes := map[int]float64{}
+	for row := startRow; row <= endRow; row++ {
+		for col := startCol; col <= endCol; col++ {
+			cell, _ := excelize.CoordinatesToCellName(col, row)
+			value, err := w.GetValue(cell)
+			if err != nil {
+				return err
+			}
+			if value == "" || slices.ContainsFunc(mergedCells, func(merged string) bool { return rangesOverlap(cell, merged) }) {
+				continue
+			}
+			styleID, err := w.file.GetCellStyle(w.sheetName, cell)
+			if err != nil {
+				return err
+			}
+			style, ok := styles[styleID]
+			if !ok {
+				if style, err = w.file.GetStyle(styleID); err != nil {
+					return err
+				}
+				styles[styleID] = style
+			}
+			measure(col, row, value, style, sizes)
+		}
+	}
+	return apply(sizes)
+}
+
+// ImportCSV streams the rows into a new or empty sheet. Rows are written cell by cell into a sheet which has contents,
+// because the stream writer replaces the whole sheet.
+func (w *ExcelizeWorksheet) ImportCSV(options *CSVImportOptions) (string, error) {
+	startCol, startRow, err := excelize.CellNameToCoordinates(options.StartCell)
+	if err != nil {
+		return "", err
+	}
+	reader, closer, err := openCSVReader(options)
+	if err != nil {
+		return "", err
+	}
+	defer closer.Close()
+	dateStyle, err := w.file.NewStyle(&excelize.Style{NumFmt: 14})
+	if err != nil {
+		return "", err
+	}
+	dateTimeStyle, err := w.file.NewStyle(&excelize.Style{NumFmt: 22})
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := w.file.Rows(w.sheetName)
+	if err != nil {
+		return "", err
+	}
+	empty := !rows.Next()
+	rows.Close()
+	var writeRow func(row int, values []any) error
+	var flush func() error
+	if empty {
+		// The stream writer writes the dimension of the sheet when it is created
+		records, columns, err := measureCSV(options)
+		if err != nil {
+			return "", err
+		}
+		if records > 0 {
+			endCell, _ := excelize.CoordinatesToCellName(startCol+max(columns, 1)-1, startRow+records-1)
+			if err := w.file.SetSheetDimension(w.sheetName, options.StartCell+":"+endCell); err != nil {
+				return "", fmt.Errorf("failed to update dimension: %w", err)
+			}
+		}
+		stream, err := w.file.NewStreamWriter(w.sheetName)
+		if err != nil {
+			return "", err
+		}
+		writeRow = func(row int, values []any) error {
+			cell, _ := excelize.CoordinatesToCellName(startCol, row)
+			return stream.SetRow(cell, values)
+		}
+		flush = stream.Flush
+	} else {
+		writeRow = func(row int, values []any) error {
+			for i, value := range values {
+				if value == nil {
+					continue
+				}
+				cell, _ := excelize.CoordinatesToCellName(startCol+i, row)
+				styled, ok := value.(excelize.Cell)
+				if ok {
+					value = styled.Value
+				}
+				if err := w.file.SetCellValue(w.sheetName, cell, value); err != nil {
+					return err
+				}
+				if ok {
+					if err := w.file.SetCellStyle(w.sheetName, cell, cell, styled.StyleID); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+		flush = func() error { return nil }
+	}
+
+	row, columns := startRow, 0
+	for ; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read CSV file: %w", err)
+		}
+		values := make([]any, len(record))
+		for i, text := range record {
+			switch {
+			case text == "":
+				// leave the cell empty
+			case !options.InferTypes || (options.HasHeader && row == startRow):
+				values[i] = text
+			default:
+				value, dateOnly := inferCSVValue(text)
+				if t, ok := value.(time.Time); ok {
+					style := dateTimeStyle
+					if dateOnly {
+						style = dateStyle
+					}
+					values[i] = excelize.Cell{StyleID: style, Value: t}
+				} else {
+					values[i] = value
+				}
+			}
+		}
+		if err := writeRow(row, values); err != nil {
+			return "", err
+		}
+		columns = max(columns, len(record))
+	}
+	if row == startRow {
+		return "", fmt.Errorf("CSV file is empty: %s", options.Path)
+	}
+	if err := flush(); err != nil {
+		return "", err
+	}
+	endCell, _ := excelize.CoordinatesToCellName(startCol+max(columns, 1)-1, row-1)
+	if !empty {
+		if err := w.updateDimension(options.StartCell); err != nil {
+			return "", fmt.Errorf("failed to update dimension: %w", err)
+		}
+		if err := w.updateDimension(endCell); err != nil {
+			return "", fmt.Errorf("failed to update dimension: %w", err)
+		}
+	}
+	return options.StartCell + ":" + endCell, nil
 }
 
 // updateDimention updates the dimension of the worksheet after a cell is updated.
@@ -389,7 +2677,7 @@ func (w *ExcelizeWorksheet) updateDimension(updatedCell string) error {
 	if err != nil {
 		return err
 	}
-	startCol, startRow, endCol, endRow, err := ParseRange(dimension)
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(dimension)
 	if err != nil {
 		return err
 	}
@@ -603,9 +2891,9 @@ func (w *ExcelizeWorksheet) createStyleFromFormat(format *ConditionalFormattingS
 
 	if format.Font != nil {
 		style.Font = &excelize.Font{
-			Bold:   format.Font.Bold,
-			Italic: format.Font.Italic,
-			Size:   float64(format.Font.Size),
+			Bold:   format.Font.Bold != nil && *format.Font.Bold,
+			Italic: format.Font.Italic != nil && *format.Font.Italic,
+			Size:   format.Font.Size,
 			Color:  format.Font.Color,
 		}
 	}
@@ -6,12 +6,16 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/go-ole/go-ole"
 	"github.com/go-ole/go-ole/oleutil"
 	"github.com/skanehira/clipboard-image"
+	"github.com/xuri/excelize/v2"
 )
 
 type OleExcel struct {
@@ -106,6 +110,95 @@ func NewExcelOleWithNewObject(absolutePath string) (*OleExcel, func(), error) {
 	}, nil
 }
 
+// NewExcelOleWithNewWorkbook adds a new workbook to the running Excel, and saves it to absolutePath.
+// The workbook stays open in Excel.
+func NewExcelOleWithNewWorkbook(absolutePath string, options *CreateFileOptions) (*OleExcel, func(), error) {
+	ole.CoInitializeEx(0, ole.COINIT_MULTITHREADED)
+
+	unknown, err := oleutil.GetActiveObject("Excel.Application")
+	if err != nil {
+		ole.CoUninitialize()
+		return nil, func() {}, err
+	}
+	excel, err := unknown.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		unknown.Release()
+		ole.CoUninitialize()
+		return nil, func() {}, err
+	}
+	workbooks := oleutil.MustGetProperty(excel, "Workbooks").ToIDispatch()
+	var template any = -4167 // xlWBATWorksheet creates a workbook with a single sheet
+	if options.TemplatePath != "" {
+		template = options.TemplatePath
+	}
+	workbook, err := oleutil.CallMethod(workbooks, "Add", template)
+	if err != nil {
+		workbooks.Release()
+		excel.Release()
+		ole.CoUninitialize()
+		return nil, func() {}, err
+	}
+	w := workbook.ToIDispatch()
+	release := func() {
+		w.Release()
+		workbooks.Release()
+		excel.Release()
+		ole.CoUninitialize()
+	}
+	o := &OleExcel{workbook: w}
+	if err := o.initializeNewWorkbook(absolutePath, options); err != nil {
+		oleutil.CallMethod(w, "Close", false)
+		release()
+		return nil, func() {}, err
+	}
+	return o, release, nil
+}
+
+// initializeNewWorkbook adds the sheets to the new workbook and saves it
+func (o *OleExcel) initializeNewWorkbook(absolutePath string, options *CreateFileOptions) error {
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	sheetNames := options.SheetNames
+	if options.TemplatePath == "" {
+		first := oleutil.MustGetProperty(worksheets, "Item", 1).ToIDispatch()
+		_, err := oleutil.PutProperty(first, "Name", sheetNames[0])
+		first.Release()
+		if err != nil {
+			return fmt.Errorf("failed to name new sheet %s: %w", sheetNames[0], err)
+		}
+		sheetNames = sheetNames[1:]
+	}
+	for _, sheetName := range sheetNames {
+		count := oleutil.MustGetProperty(worksheets, "Count").Val
+		last := oleutil.MustGetProperty(worksheets, "Item", count).ToIDispatch()
+		sheet, err := oleutil.CallMethod(worksheets, "Add", nil, last)
+		last.Release()
+		if err != nil {
+			return fmt.Errorf("failed to create new sheet: %w", err)
+		}
+		worksheet := sheet.ToIDispatch()
+		_, err = oleutil.PutProperty(worksheet, "Name", sheetName)
+		worksheet.Release()
+		if err != nil {
+			return fmt.Errorf("failed to name new sheet %s: %w", sheetName, err)
+		}
+	}
+
+	app := oleutil.MustGetProperty(o.workbook, "Application").ToIDispatch()
+	defer app.Release()
+	displayAlerts := oleutil.MustGetProperty(app, "DisplayAlerts").Value()
+	oleutil.MustPutProperty(app, "DisplayAlerts", false)
+	defer oleutil.PutProperty(app, "DisplayAlerts", displayAlerts)
+	format, err := FileFormatOf(absolutePath)
+	if err != nil {
+		return err
+	}
+	if _, err := oleutil.CallMethod(o.workbook, "SaveAs", absolutePath, oleFileFormats[format]); err != nil {
+		return fmt.Errorf("failed to save workbook: %w", err)
+	}
+	return nil
+}
+
 func (o *OleExcel) GetBackendName() string {
 	return "ole"
 }
@@ -203,6 +296,342 @@ func (o *OleExcel) CopySheet(srcSheetName string, dstSheetName string) error {
 	return nil
 }
 
+// getSheet returns the worksheet with the name. Excel looks up sheet names case-insensitively.
+func (o *OleExcel) getSheet(sheetName string) (*ole.IDispatch, error) {
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	worksheet, err := oleutil.GetProperty(worksheets, "Item", sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("sheet not found: %s", sheetName)
+	}
+	return worksheet.ToIDispatch(), nil
+}
+
+// RenameSheet renames the sheet. Excel rewrites the references to the sheet by itself.
+func (o *OleExcel) RenameSheet(oldName string, newName string) error {
+	worksheet, err := o.getSheet(oldName)
+	if err != nil {
+		return err
+	}
+	defer worksheet.Release()
+	if _, err := oleutil.PutProperty(worksheet, "Name", newName); err != nil {
+		return fmt.Errorf("failed to rename sheet: %w", err)
+	}
+	return nil
+}
+
+func (o *OleExcel) DeleteSheet(sheetName string) error {
+	worksheet, err := o.getSheet(sheetName)
+	if err != nil {
+		return err
+	}
+	defer worksheet.Release()
+
+	app := oleutil.MustGetProperty(o.workbook, "Application").ToIDispatch()
+	defer app.Release()
+	displayAlerts := oleutil.MustGetProperty(app, "DisplayAlerts").Value()
+	oleutil.MustPutProperty(app, "DisplayAlerts", false)
+	defer oleutil.PutProperty(app, "DisplayAlerts", displayAlerts)
+
+	if _, err := oleutil.CallMethod(worksheet, "Delete"); err != nil {
+		return fmt.Errorf("failed to delete sheet: %w", err)
+	}
+	return nil
+}
+
+func (o *OleExcel) MoveSheet(sheetName string, position int) error {
+	worksheet, err := o.getSheet(sheetName)
+	if err != nil {
+		return err
+	}
+	defer worksheet.Release()
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	count := int(oleutil.MustGetProperty(worksheets, "Count").Val)
+	if position < 1 || position > count {
+		return fmt.Errorf("position must be between 1 and %d", count)
+	}
+	current := int(oleutil.MustGetProperty(worksheet, "Index").Val)
+	if position == current {
+		return nil
+	}
+	target := oleutil.MustGetProperty(worksheets, "Item", position).ToIDispatch()
+	defer target.Release()
+	if position < current {
+		_, err = oleutil.CallMethod(worksheet, "Move", target)
+	} else {
+		_, err = oleutil.CallMethod(worksheet, "Move", nil, target)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move sheet: %w", err)
+	}
+	return nil
+}
+
+func (o *OleExcel) GetSheetVisibility(sheetName string) (SheetVisibility, error) {
+	worksheet, err := o.getSheet(sheetName)
+	if err != nil {
+		return "", err
+	}
+	defer worksheet.Release()
+	switch oleutil.MustGetProperty(worksheet, "Visible").Val {
+	case 0: // xlSheetHidden
+		return SheetHidden, nil
+	case 2: // xlSheetVeryHidden
+		return SheetVeryHidden, nil
+	default:
+		return SheetVisible, nil
+	}
+}
+
+func (o *OleExcel) SetSheetVisibility(sheetName string, visibility SheetVisibility) error {
+	worksheet, err := o.getSheet(sheetName)
+	if err != nil {
+		return err
+	}
+	defer worksheet.Release()
+	var visible int
+	switch visibility {
+	case SheetVisible:
+		visible = -1 // xlSheetVisible
+	case SheetHidden:
+		visible = 0 // xlSheetHidden
+	case SheetVeryHidden:
+		visible = 2 // xlSheetVeryHidden
+	default:
+		return fmt.Errorf("invalid sheet visibility: %s", visibility)
+	}
+	if _, err := oleutil.PutProperty(worksheet, "Visible", visible); err != nil {
+		return fmt.Errorf("failed to set sheet visibility: %w", err)
+	}
+	return nil
+}
+
+func (o *OleExcel) GetTabColor(sheetName string) (string, error) {
+	worksheet, err := o.getSheet(sheetName)
+	if err != nil {
+		return "", err
+	}
+	defer worksheet.Release()
+	tab := oleutil.MustGetProperty(worksheet, "Tab").ToIDispatch()
+	defer tab.Release()
+	// Color is False if the tab has no color
+	color, ok := oleutil.MustGetProperty(tab, "Color").Value().(float64)
+	if !ok {
+		return "", nil
+	}
+	return bgrToRgb(color), nil
+}
+
+func (o *OleExcel) SetTabColor(sheetName string, color string) error {
+	worksheet, err := o.getSheet(sheetName)
+	if err != nil {
+		return err
+	}
+	defer worksheet.Release()
+	tab := oleutil.MustGetProperty(worksheet, "Tab").ToIDispatch()
+	defer tab.Release()
+	if color == "" {
+		_, err = oleutil.PutProperty(tab, "ColorIndex", -4142) // xlColorIndexNone
+	} else {
+		_, err = oleutil.PutProperty(tab, "Color", rgbToBgr(color))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set tab color: %w", err)
+	}
+	return nil
+}
+
+func (o *OleExcel) FindSheetDependents(sheetName string) ([]string, error) {
+	target, err := o.getSheet(sheetName)
+	if err != nil {
+		return nil, err
+	}
+	sheetName = oleutil.MustGetProperty(target, "Name").ToString()
+	target.Release()
+
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	count := int(oleutil.MustGetProperty(worksheets, "Count").Val)
+	dependents := []string{}
+	for i := 1; i <= count; i++ {
+		worksheet := oleutil.MustGetProperty(worksheets, "Item", i).ToIDispatch()
+		name := oleutil.MustGetProperty(worksheet, "Name").ToString()
+		if name == sheetName {
+			worksheet.Release()
+			continue
+		}
+		formulas, err := getOleFormulas(worksheet)
+		worksheet.Release()
+		if err != nil {
+			return nil, err
+		}
+		cells := []string{}
+		for cell, formula := range formulas {
+			if formulaRefersToSheet(formula, sheetName) {
+				cells = append(cells, cell)
+			}
+		}
+		sortCellNames(cells)
+		for _, cell := range cells {
+			dependents = append(dependents, QuoteSheetName(name)+"!"+cell)
+		}
+	}
+	return dependents, nil
+}
+
+func (o *OleExcel) GetDefinedNames() ([]DefinedName, error) {
+	definedNames := []DefinedName{}
+	err := o.forEachName(func(name *ole.IDispatch, definedName DefinedName) (bool, error) {
+		// The hidden name of the auto filter range is managed by Excel
+		if definedName.Name == "_FilterDatabase" {
+			return true, nil
+		}
+		definedNames = append(definedNames, definedName)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return definedNames, nil
+}
+
+func (o *OleExcel) SetDefinedName(definedName *DefinedName) error {
+	// Names.Add replaces the existing name in the same scope
+	var names *ole.IDispatch
+	if definedName.Scope == "" {
+		names = oleutil.MustGetProperty(o.workbook, "Names").ToIDispatch()
+	} else {
+		worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+		defer worksheets.Release()
+		worksheetVar, err := oleutil.GetProperty(worksheets, "Item", definedName.Scope)
+		if err != nil {
+			return fmt.Errorf("sheet not found: %s", definedName.Scope)
+		}
+		worksheet := worksheetVar.ToIDispatch()
+		defer worksheet.Release()
+		names = oleutil.MustGetProperty(worksheet, "Names").ToIDispatch()
+	}
+	defer names.Release()
+
+	nameVar, err := oleutil.CallMethod(names, "Add", definedName.Name, "="+strings.TrimPrefix(definedName.RefersTo, "="))
+	if err != nil {
+		return fmt.Errorf("failed to set defined name: %w", err)
+	}
+	name := nameVar.ToIDispatch()
+	defer name.Release()
+	if definedName.Comment != "" {
+		oleutil.MustPutProperty(name, "Comment", definedName.Comment)
+	}
+	return nil
+}
+
+func (o *OleExcel) DeleteDefinedName(name string, scope string) error {
+	found := false
+	err := o.forEachName(func(nameObject *ole.IDispatch, definedName DefinedName) (bool, error) {
+		if !strings.EqualFold(definedName.Name, name) || !strings.EqualFold(definedName.Scope, scope) {
+			return true, nil
+		}
+		found = true
+		_, err := oleutil.CallMethod(nameObject, "Delete")
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete defined name: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("defined name not found: %s", name)
+	}
+	return nil
+}
+
+// forEachName calls fn for each Name object in the workbook until fn returns false
+func (o *OleExcel) forEachName(fn func(name *ole.IDispatch, definedName DefinedName) (bool, error)) error {
+	names := oleutil.MustGetProperty(o.workbook, "Names").ToIDispatch()
+	defer names.Release()
+	count := int(oleutil.MustGetProperty(names, "Count").Val)
+	for i := 1; i <= count; i++ {
+		name := oleutil.MustCallMethod(names, "Item", i).ToIDispatch()
+		defer name.Release()
+		// Sheet-scoped names are qualified with the sheet name (e.g., Sheet1!Name)
+		scope, nameText := SplitSheetReference(oleutil.MustGetProperty(name, "Name").ToString())
+		definedName := DefinedName{
+			Name:     nameText,
+			RefersTo: strings.TrimPrefix(oleutil.MustGetProperty(name, "RefersTo").ToString(), "="),
+			Scope:    scope,
+			Comment:  oleutil.MustGetProperty(name, "Comment").ToString(),
+		}
+		next, err := fn(name, definedName)
+		if err != nil {
+			return err
+		}
+		if !next {
+			break
+		}
+	}
+	return nil
+}
+
+func (o *OleExcel) Date1904() (bool, error) {
+	date1904, ok := oleutil.MustGetProperty(o.workbook, "Date1904").Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("failed to get the date system of the workbook")
+	}
+	return date1904, nil
+}
+
+// Recalculate forces Excel to calculate all formulas in the open workbooks. Excel supports all functions and
+// reports circular references by itself, so only the cells resulting in error values are reported.
+func (o *OleExcel) Recalculate() (*RecalculationResult, error) {
+	application := oleutil.MustGetProperty(o.workbook, "Application").ToIDispatch()
+	defer application.Release()
+	if _, err := oleutil.CallMethod(application, "CalculateFull"); err != nil {
+		return nil, fmt.Errorf("failed to calculate formulas: %w", err)
+	}
+
+	result := &RecalculationResult{
+		Errors:               []RecalculationIssue{},
+		Failures:             []RecalculationIssue{},
+		UnsupportedFunctions: []RecalculationIssue{},
+	}
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	sheetCount := int(oleutil.MustGetProperty(worksheets, "Count").Val)
+	for i := 1; i <= sheetCount; i++ {
+		worksheet := oleutil.MustGetProperty(worksheets, "Item", i).ToIDispatch()
+		sheetName := oleutil.MustGetProperty(worksheet, "Name").ToString()
+		usedRange := oleutil.MustGetProperty(worksheet, "UsedRange").ToIDispatch()
+		// SpecialCells raises an error when the sheet has no such cell
+		if formulaCellsVariant, err := oleutil.CallMethod(usedRange, "SpecialCells", -4123); err == nil { // xlCellTypeFormulas
+			formulaCells := formulaCellsVariant.ToIDispatch()
+			result.Calculated += int(oleutil.MustGetProperty(formulaCells, "Count").Val)
+			formulaCells.Release()
+		}
+		if errorCellsVariant, err := oleutil.CallMethod(usedRange, "SpecialCells", -4123, 16); err == nil { // xlCellTypeFormulas, xlErrors
+			errorCells := errorCellsVariant.ToIDispatch()
+			err = oleutil.ForEach(errorCells, func(v *ole.VARIANT) error {
+				cell := v.ToIDispatch()
+				defer cell.Release()
+				address := oleutil.MustGetProperty(cell, "Address", false, false).ToString()
+				result.Errors = append(result.Errors, RecalculationIssue{
+					Cell:   QuoteSheetName(sheetName) + "!" + address,
+					Detail: oleutil.MustGetProperty(cell, "Text").ToString(),
+				})
+				return nil
+			})
+			errorCells.Release()
+			if err != nil {
+				usedRange.Release()
+				worksheet.Release()
+				return nil, err
+			}
+		}
+		usedRange.Release()
+		worksheet.Release()
+	}
+	return result, nil
+}
+
 func (o *OleExcel) Save() error {
 	_, err := oleutil.CallMethod(o.workbook, "Save")
 	if err != nil {
@@ -211,6 +640,54 @@ func (o *OleExcel) Save() error {
 	return nil
 }
 
+// oleFileFormats maps the file formats to the XlFileFormat constants
+var oleFileFormats = map[FileFormat]int{
+	FileFormatXlsx: 51, // xlOpenXMLWorkbook
+	FileFormatXlsm: 52, // xlOpenXMLWorkbookMacroEnabled
+	FileFormatXltm: 53, // xlOpenXMLTemplateMacroEnabled
+	FileFormatXltx: 54, // xlOpenXMLTemplate
+}
+
+// SaveAs converts a copy of the workbook, so that the open workbook stays associated with the original file
+func (o *OleExcel) SaveAs(absolutePath string, format FileFormat) ([]string, error) {
+	warnings := []string{}
+	if !format.MacroEnabled() && oleutil.MustGetProperty(o.workbook, "HasVBProject").Value().(bool) {
+		warnings = append(warnings, fmt.Sprintf("The VBA project was dropped because the %s format cannot contain macros.", format))
+	}
+
+	tempDir, err := os.MkdirTemp("", "excel-mcp-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	// SaveCopyAs keeps the current format, so the copy has the extension of the workbook
+	name := oleutil.MustGetProperty(o.workbook, "Name").ToString()
+	tempPath := filepath.Join(tempDir, "copy"+filepath.Ext(name))
+	if _, err := oleutil.CallMethod(o.workbook, "SaveCopyAs", tempPath); err != nil {
+		return nil, fmt.Errorf("failed to save copy of workbook: %w", err)
+	}
+
+	app := oleutil.MustGetProperty(o.workbook, "Application").ToIDispatch()
+	defer app.Release()
+	displayAlerts := oleutil.MustGetProperty(app, "DisplayAlerts").Value()
+	// Alerts such as the confirmation of dropping the VBA project are answered by default
+	oleutil.MustPutProperty(app, "DisplayAlerts", false)
+	defer oleutil.PutProperty(app, "DisplayAlerts", displayAlerts)
+	workbooks := oleutil.MustGetProperty(app, "Workbooks").ToIDispatch()
+	defer workbooks.Release()
+	convertedVariant, err := oleutil.CallMethod(workbooks, "Open", tempPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open copy of workbook: %w", err)
+	}
+	converted := convertedVariant.ToIDispatch()
+	defer converted.Release()
+	defer oleutil.CallMethod(converted, "Close", false)
+	if _, err := oleutil.CallMethod(converted, "SaveAs", absolutePath, oleFileFormats[format]); err != nil {
+		return nil, fmt.Errorf("failed to save workbook as %s: %w", absolutePath, err)
+	}
+	return warnings, nil
+}
+
 func (o *OleWorksheet) Release() {
 	o.worksheet.Release()
 }
@@ -252,53 +729,460 @@ func (o *OleWorksheet) GetPivotTables() ([]PivotTable, error) {
 		pivotTableRange := oleutil.MustGetProperty(pivotTable, "TableRange1").ToIDispatch()
 		defer pivotTableRange.Release()
 		pivotTableList[i-1] = PivotTable{
-			Name:  name,
-			Range: NormalizeRange(oleutil.MustGetProperty(pivotTableRange, "Address").ToString()),
+			Name:         name,
+			Range:        NormalizeRange(oleutil.MustGetProperty(pivotTableRange, "Address").ToString()),
+			SourceRange:  o.getPivotTableSourceRange(pivotTable),
+			RowFields:    getOlePivotFieldNames(pivotTable, "RowFields"),
+			ColumnFields: getOlePivotFieldNames(pivotTable, "ColumnFields"),
+			FilterFields: getOlePivotFieldNames(pivotTable, "PageFields"),
+			DataFields:   getOlePivotDataFields(pivotTable),
 		}
 	}
 	return pivotTableList, nil
 }
 
-func (o *OleWorksheet) SetValue(cell string, value any) error {
-	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
-	defer range_.Release()
-	_, err := oleutil.PutProperty(range_, "Value", value)
-	return err
-}
-
-func (o *OleWorksheet) SetFormula(cell string, formula string) error {
-	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
-	defer range_.Release()
-	_, err := oleutil.PutProperty(range_, "Formula", formula)
-	return err
+var olePivotFunctions = map[string]int32{
+	"sum":     -4157, // xlSum
+	"count":   -4112, // xlCount
+	"average": -4106, // xlAverage
+	"max":     -4136, // xlMax
+	"min":     -4139, // xlMin
 }
 
-func (o *OleWorksheet) GetValue(cell string) (string, error) {
-	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
-	defer range_.Release()
-	value := oleutil.MustGetProperty(range_, "Text").Value()
-	switch v := value.(type) {
-	case string:
-		return v, nil
-	case nil:
-		return "", nil
-	default: // Handle other types as needed
-		return "", fmt.Errorf("unsupported type: %T", v)
+// getPivotTableSourceRange returns the source of the pivot table in A1 reference style
+func (o *OleWorksheet) getPivotTableSourceRange(pivotTable *ole.IDispatch) string {
+	sourceData, err := oleutil.GetProperty(pivotTable, "SourceData")
+	if err != nil {
+		return ""
+	}
+	app := oleutil.MustGetProperty(o.workbook, "Application").ToIDispatch()
+	defer app.Release()
+	// xlR1C1, xlA1
+	converted, err := oleutil.CallMethod(app, "ConvertFormula", sourceData.ToString(), -4150, 1)
+	if err != nil {
+		return sourceData.ToString()
 	}
+	return converted.ToString()
 }
 
-func (o *OleWorksheet) GetFormula(cell string) (string, error) {
-	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
-	defer range_.Release()
-	formula := oleutil.MustGetProperty(range_, "Formula").ToString()
-	return formula, nil
+// getOlePivotFieldNames returns the names of the pivot fields in the specified collection such as RowFields
+func getOlePivotFieldNames(pivotTable *ole.IDispatch, collection string) []string {
+	names := []string{}
+	// Accessing an empty collection raises an error
+	fieldsVar, err := oleutil.GetProperty(pivotTable, collection)
+	if err != nil {
+		return names
+	}
+	fields := fieldsVar.ToIDispatch()
+	defer fields.Release()
+	dataPivotFieldName := ""
+	if dataPivotFieldVar, err := oleutil.GetProperty(pivotTable, "DataPivotField"); err == nil {
+		dataPivotField := dataPivotFieldVar.ToIDispatch()
+		defer dataPivotField.Release()
+		dataPivotFieldName = oleutil.MustGetProperty(dataPivotField, "Name").ToString()
+	}
+	count := int(oleutil.MustGetProperty(fields, "Count").Val)
+	for i := 1; i <= count; i++ {
+		field := oleutil.MustCallMethod(fields, "Item", i).ToIDispatch()
+		defer field.Release()
+		// Skip the virtual field which holds data fields (e.g., "Values")
+		name := oleutil.MustGetProperty(field, "Name").ToString()
+		if name == dataPivotFieldName {
+			continue
+		}
+		names = append(names, oleutil.MustGetProperty(field, "SourceName").ToString())
+	}
+	return names
 }
 
-func (o *OleWorksheet) GetDimention() (string, error) {
-	range_ := oleutil.MustGetProperty(o.worksheet, "UsedRange").ToIDispatch()
-	defer range_.Release()
-	dimension := oleutil.MustGetProperty(range_, "Address").ToString()
-	return NormalizeRange(dimension), nil
+func getOlePivotDataFields(pivotTable *ole.IDispatch) []PivotDataField {
+	dataFields := []PivotDataField{}
+	fieldsVar, err := oleutil.GetProperty(pivotTable, "DataFields")
+	if err != nil {
+		return dataFields
+	}
+	fields := fieldsVar.ToIDispatch()
+	defer fields.Release()
+	count := int(oleutil.MustGetProperty(fields, "Count").Val)
+	for i := 1; i <= count; i++ {
+		field := oleutil.MustCallMethod(fields, "Item", i).ToIDispatch()
+		defer field.Release()
+		function := fmt.Sprintf("%d", int32(oleutil.MustGetProperty(field, "Function").Val))
+		for name, value := range olePivotFunctions {
+			if fmt.Sprintf("%d", value) == function {
+				function = name
+				break
+			}
+		}
+		dataFields = append(dataFields, PivotDataField{
+			Field:    oleutil.MustGetProperty(field, "SourceName").ToString(),
+			Function: function,
+			Name:     oleutil.MustGetProperty(field, "Name").ToString(),
+		})
+	}
+	return dataFields
+}
+
+func (o *OleWorksheet) AddPivotTable(options *PivotTableOptions) error {
+	pivotCaches := oleutil.MustCallMethod(o.workbook, "PivotCaches").ToIDispatch()
+	defer pivotCaches.Release()
+	pivotCacheVar, err := oleutil.CallMethod(pivotCaches, "Create", 1, options.SourceRange) // xlDatabase
+	if err != nil {
+		return invalidArgumentErrorf("invalid source range: %s: %v", options.SourceRange, err)
+	}
+	pivotCache := pivotCacheVar.ToIDispatch()
+	defer pivotCache.Release()
+
+	destination := oleutil.MustGetProperty(o.worksheet, "Range", options.TargetCell).ToIDispatch()
+	defer destination.Release()
+	params := []any{destination}
+	if options.Name != "" {
+		params = append(params, options.Name)
+	}
+	pivotTableVar, err := oleutil.CallMethod(pivotCache, "CreatePivotTable", params...)
+	if err != nil {
+		return fmt.Errorf("failed to create pivot table: %w", err)
+	}
+	pivotTable := pivotTableVar.ToIDispatch()
+	defer pivotTable.Release()
+
+	orientations := []struct {
+		fields      []string
+		orientation int32
+	}{
+		{options.RowFields, 1},    // xlRowField
+		{options.ColumnFields, 2}, // xlColumnField
+		{options.FilterFields, 3}, // xlPageField
+	}
+	for _, orientation := range orientations {
+		for i, name := range orientation.fields {
+			field, err := getOlePivotField(pivotTable, name)
+			if err != nil {
+				return err
+			}
+			defer field.Release()
+			oleutil.MustPutProperty(field, "Orientation", orientation.orientation)
+			oleutil.MustPutProperty(field, "Position", i+1)
+		}
+	}
+	for _, dataField := range options.DataFields {
+		function, ok := olePivotFunctions[dataField.Function]
+		if !ok {
+			return invalidArgumentErrorf("unsupported function: %s", dataField.Function)
+		}
+		field, err := getOlePivotField(pivotTable, dataField.Field)
+		if err != nil {
+			return err
+		}
+		defer field.Release()
+		if _, err := oleutil.CallMethod(pivotTable, "AddDataField", field, dataField.Name, function); err != nil {
+			return fmt.Errorf("failed to add data field %s: %w", dataField.Field, err)
+		}
+	}
+	return nil
+}
+
+func getOlePivotField(pivotTable *ole.IDispatch, name string) (*ole.IDispatch, error) {
+	field, err := oleutil.CallMethod(pivotTable, "PivotFields", name)
+	if err != nil {
+		return nil, invalidArgumentErrorf("field not found in source range: %s", name)
+	}
+	return field.ToIDispatch(), nil
+}
+
+var oleChartTypes = map[string]int32{
+	"line":    4,     // xlLine
+	"bar":     57,    // xlBarClustered
+	"column":  51,    // xlColumnClustered
+	"pie":     5,     // xlPie
+	"scatter": -4169, // xlXYScatter
+	"area":    1,     // xlArea
+}
+
+var oleLegendPositions = map[string]int32{
+	"top":      -4160, // xlLegendPositionTop
+	"bottom":   -4107, // xlLegendPositionBottom
+	"left":     -4131, // xlLegendPositionLeft
+	"right":    -4152, // xlLegendPositionRight
+	"topRight": 2,     // xlLegendPositionCorner
+}
+
+func (o *OleWorksheet) GetCharts() ([]Chart, error) {
+	chartObjects := oleutil.MustCallMethod(o.worksheet, "ChartObjects").ToIDispatch()
+	defer chartObjects.Release()
+	count := int(oleutil.MustGetProperty(chartObjects, "Count").Val)
+	chartList := make([]Chart, count)
+	for i := 1; i <= count; i++ {
+		chartObject := oleutil.MustCallMethod(chartObjects, "Item", i).ToIDispatch()
+		defer chartObject.Release()
+		topLeftCell := oleutil.MustGetProperty(chartObject, "TopLeftCell").ToIDispatch()
+		defer topLeftCell.Release()
+		chart := oleutil.MustGetProperty(chartObject, "Chart").ToIDispatch()
+		defer chart.Release()
+
+		chartType := oleutil.MustGetProperty(chart, "ChartType").Val
+		chartList[i-1] = Chart{
+			Name:       oleutil.MustGetProperty(chartObject, "Name").ToString(),
+			Type:       oleChartTypeName(int32(chartType)),
+			AnchorCell: NormalizeRange(oleutil.MustGetProperty(topLeftCell, "Address").ToString()),
+			Series:     []ChartSeries{},
+		}
+		if oleutil.MustGetProperty(chart, "HasTitle").Value().(bool) {
+			chartTitle := oleutil.MustGetProperty(chart, "ChartTitle").ToIDispatch()
+			defer chartTitle.Release()
+			chartList[i-1].Title = oleutil.MustGetProperty(chartTitle, "Text").ToString()
+		}
+
+		seriesCollection := oleutil.MustCallMethod(chart, "SeriesCollection").ToIDispatch()
+		defer seriesCollection.Release()
+		seriesCount := int(oleutil.MustGetProperty(seriesCollection, "Count").Val)
+		for j := 1; j <= seriesCount; j++ {
+			series := oleutil.MustCallMethod(seriesCollection, "Item", j).ToIDispatch()
+			defer series.Release()
+			chartList[i-1].Series = append(chartList[i-1].Series, parseSeriesFormula(oleutil.MustGetProperty(series, "Formula").ToString()))
+		}
+	}
+	return chartList, nil
+}
+
+func (o *OleWorksheet) AddChart(options *ChartOptions) error {
+	chartType, ok := oleChartTypes[options.Type]
+	if !ok {
+		return fmt.Errorf("unsupported chart type: %s", options.Type)
+	}
+	width, height := options.Width, options.Height
+	if width <= 0 {
+		width = 480
+	}
+	if height <= 0 {
+		height = 260
+	}
+
+	anchor := oleutil.MustGetProperty(o.worksheet, "Range", options.AnchorCell).ToIDispatch()
+	defer anchor.Release()
+	left := oleutil.MustGetProperty(anchor, "Left").Value()
+	top := oleutil.MustGetProperty(anchor, "Top").Value()
+
+	chartObjects := oleutil.MustCallMethod(o.worksheet, "ChartObjects").ToIDispatch()
+	defer chartObjects.Release()
+	// Size of chart objects is specified in points
+	chartObjectVar, err := oleutil.CallMethod(chartObjects, "Add", left, top, float64(width)*0.75, float64(height)*0.75)
+	if err != nil {
+		return fmt.Errorf("failed to add chart: %w", err)
+	}
+	chartObject := chartObjectVar.ToIDispatch()
+	defer chartObject.Release()
+	chart := oleutil.MustGetProperty(chartObject, "Chart").ToIDispatch()
+	defer chart.Release()
+
+	if _, err := oleutil.PutProperty(chart, "ChartType", chartType); err != nil {
+		return fmt.Errorf("failed to set chart type: %w", err)
+	}
+
+	seriesCollection := oleutil.MustCallMethod(chart, "SeriesCollection").ToIDispatch()
+	defer seriesCollection.Release()
+	// A new chart may be populated with the selected cells
+	for count := int(oleutil.MustGetProperty(seriesCollection, "Count").Val); count > 0; count-- {
+		series := oleutil.MustCallMethod(seriesCollection, "Item", count).ToIDispatch()
+		oleutil.MustCallMethod(series, "Delete")
+		series.Release()
+	}
+	for _, options := range options.Series {
+		series := oleutil.MustCallMethod(seriesCollection, "NewSeries").ToIDispatch()
+		defer series.Release()
+		if _, err := oleutil.PutProperty(series, "Values", "="+options.Values); err != nil {
+			return fmt.Errorf("failed to set series values: %w", err)
+		}
+		if options.Categories != "" {
+			if _, err := oleutil.PutProperty(series, "XValues", "="+options.Categories); err != nil {
+				return fmt.Errorf("failed to set series categories: %w", err)
+			}
+		}
+		if options.Name != "" {
+			if _, err := oleutil.PutProperty(series, "Name", "="+options.Name); err != nil {
+				return fmt.Errorf("failed to set series name: %w", err)
+			}
+		}
+	}
+
+	if options.Title != "" {
+		oleutil.MustPutProperty(chart, "HasTitle", true)
+		chartTitle := oleutil.MustGetProperty(chart, "ChartTitle").ToIDispatch()
+		defer chartTitle.Release()
+		oleutil.MustPutProperty(chartTitle, "Text", options.Title)
+	}
+	if options.Type != "pie" {
+		if err := setOleAxisTitle(chart, 1, options.XAxisTitle); err != nil { // xlCategory
+			return err
+		}
+		if err := setOleAxisTitle(chart, 2, options.YAxisTitle); err != nil { // xlValue
+			return err
+		}
+	}
+	if options.LegendPosition == "none" {
+		oleutil.MustPutProperty(chart, "HasLegend", false)
+	} else {
+		oleutil.MustPutProperty(chart, "HasLegend", true)
+		position, ok := oleLegendPositions[options.LegendPosition]
+		if !ok {
+			position = oleLegendPositions["bottom"]
+		}
+		legend := oleutil.MustGetProperty(chart, "Legend").ToIDispatch()
+		defer legend.Release()
+		oleutil.MustPutProperty(legend, "Position", position)
+	}
+	return nil
+}
+
+// oleChartTypeName returns the chart type name of XlChartType. Unknown types are returned as numbers.
+func oleChartTypeName(chartType int32) string {
+	for name, value := range oleChartTypes {
+		if value == chartType {
+			return name
+		}
+	}
+	return fmt.Sprintf("%d", chartType)
+}
+
+func setOleAxisTitle(chart *ole.IDispatch, axisType int32, title string) error {
+	if title == "" {
+		return nil
+	}
+	axisVar, err := oleutil.CallMethod(chart, "Axes", axisType)
+	if err != nil {
+		return fmt.Errorf("failed to get axis: %w", err)
+	}
+	axis := axisVar.ToIDispatch()
+	defer axis.Release()
+	oleutil.MustPutProperty(axis, "HasTitle", true)
+	axisTitle := oleutil.MustGetProperty(axis, "AxisTitle").ToIDispatch()
+	defer axisTitle.Release()
+	oleutil.MustPutProperty(axisTitle, "Text", title)
+	return nil
+}
+
+func (o *OleWorksheet) SetValue(cell string, value any) error {
+	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer range_.Release()
+	_, err := oleutil.PutProperty(range_, "Value", value)
+	return err
+}
+
+func (o *OleWorksheet) SetFormula(cell string, formula string) error {
+	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer range_.Release()
+	_, err := oleutil.PutProperty(range_, "Formula", formula)
+	return err
+}
+
+func (o *OleWorksheet) GetValue(cell string) (string, error) {
+	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer range_.Release()
+	value := oleutil.MustGetProperty(range_, "Text").Value()
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	default: // Handle other types as needed
+		return "", fmt.Errorf("unsupported type: %T", v)
+	}
+}
+
+func (o *OleWorksheet) GetTypedValue(cell string) (*TypedValue, error) {
+	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer range_.Release()
+	formatted := oleutil.MustGetProperty(range_, "Text").ToString()
+	value := oleutil.MustGetProperty(range_, "Value2")
+	if value.VT == ole.VT_ERROR {
+		// Value2 has the error code (e.g., 2007), while Text has the error value (e.g., #DIV/0!)
+		return &TypedValue{Type: CellValueError, Raw: formatted, Formatted: formatted}, nil
+	}
+	switch v := value.Value().(type) {
+	case string:
+		return &TypedValue{Type: CellValueString, Raw: v, Formatted: formatted}, nil
+	case float64:
+		typedValue := &TypedValue{Type: CellValueNumber, Raw: strconv.FormatFloat(v, 'f', -1, 64), Formatted: formatted}
+		// Value, unlike Value2, returns the numbers formatted as dates as Date
+		if oleutil.MustGetProperty(range_, "Value").VT == ole.VT_DATE {
+			typedValue.Type = CellValueDate
+		}
+		return typedValue, nil
+	case bool:
+		if v {
+			return &TypedValue{Type: CellValueBoolean, Raw: "TRUE", Formatted: formatted}, nil
+		}
+		return &TypedValue{Type: CellValueBoolean, Raw: "FALSE", Formatted: formatted}, nil
+	case nil:
+		return &TypedValue{Type: CellValueEmpty, Formatted: formatted}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type: %T", v)
+	}
+}
+
+func (o *OleWorksheet) GetFormula(cell string) (string, error) {
+	range_ := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer range_.Release()
+	formula := oleutil.MustGetProperty(range_, "Formula").ToString()
+	return formula, nil
+}
+
+// TraceFormula parses the formulas in the workbook like the Excelize backend, because Range.DirectPrecedents and
+// Range.DirectDependents do not find the cells in other sheets, and Range.Precedents does not tell the levels.
+func (o *OleWorksheet) TraceFormula(cell string, depth int) (*FormulaTrace, error) {
+	graph, err := newOleFormulaGraph(&OleExcel{workbook: o.workbook})
+	if err != nil {
+		return nil, err
+	}
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	sheetName := oleutil.MustGetProperty(o.worksheet, "Name").ToString()
+	return graph.trace(graph.findCell(sheetName, cell), depth, func(cell formulaCell) string {
+		worksheet := oleutil.MustGetProperty(worksheets, "Item", cell.sheet).ToIDispatch()
+		defer worksheet.Release()
+		range_ := oleutil.MustGetProperty(worksheet, "Range", cell.cell).ToIDispatch()
+		defer range_.Release()
+		return oleutil.MustGetProperty(range_, "Text").ToString()
+	}), nil
+}
+
+// newOleFormulaGraph creates the graph of all formulas in the workbook
+func newOleFormulaGraph(o *OleExcel) (*formulaGraph, error) {
+	sheets := []string{}
+	formulas := map[string]map[string]string{}
+	tables := map[string][]Table{}
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	count := int(oleutil.MustGetProperty(worksheets, "Count").Val)
+	for i := 1; i <= count; i++ {
+		worksheet := oleutil.MustGetProperty(worksheets, "Item", i).ToIDispatch()
+		sheetName := oleutil.MustGetProperty(worksheet, "Name").ToString()
+		sheetFormulas, err := getOleFormulas(worksheet)
+		if err != nil {
+			worksheet.Release()
+			return nil, err
+		}
+		sheetTables, err := (&OleWorksheet{workbook: o.workbook, worksheet: worksheet}).GetTables()
+		worksheet.Release()
+		if err != nil {
+			return nil, err
+		}
+		sheets = append(sheets, sheetName)
+		formulas[sheetName] = sheetFormulas
+		tables[sheetName] = sheetTables
+	}
+	definedNames, err := o.GetDefinedNames()
+	if err != nil {
+		return nil, err
+	}
+	return newFormulaGraph(sheets, formulas, definedNames, tables)
+}
+
+func (o *OleWorksheet) GetDimention() (string, error) {
+	range_ := oleutil.MustGetProperty(o.worksheet, "UsedRange").ToIDispatch()
+	defer range_.Release()
+	dimension := oleutil.MustGetProperty(range_, "Address").ToString()
+	return NormalizeRange(dimension), nil
 }
 
 func (o *OleWorksheet) GetPagingStrategy(pageSize int) (PagingStrategy, error) {
@@ -350,20 +1234,156 @@ func (o *OleWorksheet) CapturePicture(captureRange string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	// Read the image from the clipboard
+	data, err := readImageFromClipboard()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// readImageFromClipboard reads the image copied to the clipboard as PNG
+func readImageFromClipboard() ([]byte, error) {
 	buf := new(bytes.Buffer)
 	bufWriter := bufio.NewWriter(buf)
 	clipboardReader, err := clipboard.ReadFromClipboard()
 	if err != nil {
-		return "", fmt.Errorf("failed to read from clipboard: %w", err)
+		return nil, fmt.Errorf("failed to read from clipboard: %w", err)
 	}
 	if _, err := io.Copy(bufWriter, clipboardReader); err != nil {
-		return "", fmt.Errorf("failed to copy clipboard data: %w", err)
+		return nil, fmt.Errorf("failed to copy clipboard data: %w", err)
 	}
 	if err := bufWriter.Flush(); err != nil {
-		return "", fmt.Errorf("failed to flush buffer: %w", err)
+		return nil, fmt.Errorf("failed to flush buffer: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (o *OleWorksheet) GetPictures() ([]Picture, error) {
+	pictures := []Picture{}
+	err := o.forEachPictureShape(func(shape *ole.IDispatch) (bool, error) {
+		topLeftCell := oleutil.MustGetProperty(shape, "TopLeftCell").ToIDispatch()
+		defer topLeftCell.Release()
+		pictures = append(pictures, Picture{
+			Name:       oleutil.MustGetProperty(shape, "Name").ToString(),
+			AnchorCell: oleutil.MustGetProperty(topLeftCell, "Address", false, false).ToString(),
+			AltText:    oleutil.MustGetProperty(shape, "AlternativeText").ToString(),
+		})
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pictures, nil
+}
+
+func (o *OleWorksheet) GetPictureData(name string) ([]byte, string, error) {
+	var data []byte
+	found := false
+	err := o.forEachPictureShape(func(shape *ole.IDispatch) (bool, error) {
+		if !strings.EqualFold(oleutil.MustGetProperty(shape, "Name").ToString(), name) {
+			return true, nil
+		}
+		found = true
+		// The original image cannot be retrieved through OLE, so the picture is copied as it is displayed
+		_, err := oleutil.CallMethod(
+			shape,
+			"CopyPicture",
+			int(1), // xlScreen
+			int(2), // xlBitmap
+		)
+		if err != nil {
+			return false, err
+		}
+		data, err = readImageFromClipboard()
+		return false, err
+	})
+	if err != nil {
+		return nil, "", err
 	}
-	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+	if !found {
+		return nil, "", fmt.Errorf("picture not found: %s", name)
+	}
+	return data, "png", nil
+}
+
+// forEachPictureShape calls fn for each picture shape in the worksheet until fn returns false
+func (o *OleWorksheet) forEachPictureShape(fn func(shape *ole.IDispatch) (bool, error)) error {
+	shapes := oleutil.MustGetProperty(o.worksheet, "Shapes").ToIDispatch()
+	defer shapes.Release()
+	count := int(oleutil.MustGetProperty(shapes, "Count").Val)
+	for i := 1; i <= count; i++ {
+		shape := oleutil.MustCallMethod(shapes, "Item", i).ToIDispatch()
+		defer shape.Release()
+		shapeType := oleutil.MustGetProperty(shape, "Type").Val
+		if shapeType != 13 && shapeType != 11 { // msoPicture, msoLinkedPicture
+			continue
+		}
+		next, err := fn(shape)
+		if err != nil {
+			return err
+		}
+		if !next {
+			break
+		}
+	}
+	return nil
+}
+
+func (o *OleWorksheet) AddPicture(options *PictureOptions) error {
+	// Shapes.AddPicture accepts only a file, so the image is written to a temporary file
+	extension := ".png"
+	if options.Format == "jpeg" {
+		extension = ".jpg"
+	}
+	tempFile, err := os.CreateTemp("", "excel-mcp-picture-*"+extension)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	if _, err := tempFile.Write(options.Data); err != nil {
+		tempFile.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	anchor := oleutil.MustGetProperty(o.worksheet, "Range", options.AnchorCell).ToIDispatch()
+	defer anchor.Release()
+	// 1 pixel is 0.75 points at 96 DPI
+	left := oleutil.MustGetProperty(anchor, "Left").Value().(float64) + float64(options.OffsetX)*0.75
+	top := oleutil.MustGetProperty(anchor, "Top").Value().(float64) + float64(options.OffsetY)*0.75
+
+	shapes := oleutil.MustGetProperty(o.worksheet, "Shapes").ToIDispatch()
+	defer shapes.Release()
+	shapeVar, err := oleutil.CallMethod(
+		shapes,
+		"AddPicture",
+		tempFile.Name(),
+		int(0),  // msoFalse (LinkToFile)
+		int(-1), // msoTrue (SaveWithDocument)
+		left,
+		top,
+		int(-1), // original width
+		int(-1), // original height
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add picture: %w", err)
+	}
+	shape := shapeVar.ToIDispatch()
+	defer shape.Release()
+
+	oleutil.MustPutProperty(shape, "LockAspectRatio", int(0)) // msoFalse
+	if options.ScaleX > 0 && options.ScaleX != 1 {
+		oleutil.MustCallMethod(shape, "ScaleWidth", options.ScaleX, int(-1)) // msoTrue (RelativeToOriginalSize)
+	}
+	if options.ScaleY > 0 && options.ScaleY != 1 {
+		oleutil.MustCallMethod(shape, "ScaleHeight", options.ScaleY, int(-1)) // msoTrue (RelativeToOriginalSize)
+	}
+	if options.AltText != "" {
+		oleutil.MustPutProperty(shape, "AlternativeText", options.AltText)
+	}
+	return nil
 }
 
 func (o *OleWorksheet) AddTable(tableRange string, tableName string) error {
@@ -401,16 +1421,20 @@ func (o *OleWorksheet) GetCellStyle(cell string) (*CellStyle, error) {
 	font := oleutil.MustGetProperty(rng, "Font").ToIDispatch()
 	defer font.Release()
 
-	fontSize := int(oleutil.MustGetProperty(font, "Size").Value().(float64))
+	fontSize := oleutil.MustGetProperty(font, "Size").Value().(float64)
 	fontBold := oleutil.MustGetProperty(font, "Bold").Value().(bool)
 	fontItalic := oleutil.MustGetProperty(font, "Italic").Value().(bool)
 	fontColor := oleutil.MustGetProperty(font, "Color").Value().(float64)
 
 	style.Font = &FontStyle{
-		Bold:   fontBold,
-		Italic: fontItalic,
-		Size:   fontSize,
-		Color:  bgrToRgb(fontColor),
+		Size:  fontSize,
+		Color: bgrToRgb(fontColor),
+	}
+	if fontBold {
+		style.Font.Bold = &fontBold
+	}
+	if fontItalic {
+		style.Font.Italic = &fontItalic
 	}
 
 	// Get Interior (fill) information
@@ -447,7 +1471,10 @@ func (o *OleWorksheet) GetCellStyle(cell string) (*CellStyle, error) {
 		border := oleutil.MustGetProperty(rng, "Borders", pos.index).ToIDispatch()
 		defer border.Release()
 
-		borderLineStyle := excelBorderStyleToName(oleutil.MustGetProperty(border, "LineStyle").Value().(int32))
+		borderLineStyle := excelBorderStyleToName(
+			oleutil.MustGetProperty(border, "LineStyle").Value().(int32),
+			oleutil.MustGetProperty(border, "Weight").Value().(int32),
+		)
 
 		if borderLineStyle != BorderStyleNone {
 			borderColor := oleutil.MustGetProperty(border, "Color").Value().(float64)
@@ -474,20 +1501,37 @@ func bgrToRgb(bgrColor float64) string {
 	return fmt.Sprintf("#%02X%02X%02X", r, g, b)
 }
 
-// excelBorderStyleToName converts Excel border style constant to BorderStyleName
-func excelBorderStyleToName(excelStyle int32) BorderStyleName {
+// excelBorderStyleToName converts Excel border style and weight constants to BorderStyleName
+func excelBorderStyleToName(excelStyle int32, weight int32) BorderStyleName {
 	switch excelStyle {
 	case 1: // xlContinuous
+		switch weight {
+		case 1: // xlHairline
+			return BorderStyleHair
+		case -4138: // xlMedium
+			return BorderStyleMedium
+		case 4: // xlThick
+			return BorderStyleThick
+		}
 		return BorderStyleContinuous
 	case -4115: // xlDash
+		if weight == -4138 {
+			return BorderStyleMediumDash
+		}
 		return BorderStyleDash
 	case -4118: // xlDot
 		return BorderStyleDot
 	case -4119: // xlDouble
 		return BorderStyleDouble
 	case 4: // xlDashDot
+		if weight == -4138 {
+			return BorderStyleMediumDashDot
+		}
 		return BorderStyleDashDot
 	case 5: // xlDashDotDot
+		if weight == -4138 {
+			return BorderStyleMediumDashDotDot
+		}
 		return BorderStyleDashDotDot
 	case 13: // xlSlantDashDot
 		return BorderStyleSlantDashDot
@@ -558,6 +1602,1122 @@ func excelPatternToFillPattern(excelPattern int32) FillPatternName {
 	}
 }
 
+// SetCellStyle applies style to the specified range using OLE.
+// Only the specified elements are updated, so the others are kept as they are.
+func (o *OleWorksheet) SetCellStyle(cellRange string, style *CellStyle) error {
+	if style == nil {
+		return fmt.Errorf("cell style cannot be nil")
+	}
+
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", cellRange).ToIDispatch()
+	defer rng.Release()
+
+	// Font
+	if style.Font != nil {
+		font := oleutil.MustGetProperty(rng, "Font").ToIDispatch()
+		defer font.Release()
+
+		if style.Font.Bold != nil {
+			oleutil.MustPutProperty(font, "Bold", *style.Font.Bold)
+		}
+		if style.Font.Italic != nil {
+			oleutil.MustPutProperty(font, "Italic", *style.Font.Italic)
+		}
+		if style.Font.Underline != "" {
+			oleutil.MustPutProperty(font, "Underline", getOleUnderline(style.Font.Underline))
+		}
+		if style.Font.Size > 0 {
+			oleutil.MustPutProperty(font, "Size", style.Font.Size)
+		}
+		if style.Font.Strike != nil {
+			oleutil.MustPutProperty(font, "Strikethrough", *style.Font.Strike)
+		}
+		if style.Font.Color != "" {
+			oleutil.MustPutProperty(font, "Color", rgbToBgr(style.Font.Color))
+		}
+		switch style.Font.VertAlign {
+		case "superscript":
+			oleutil.MustPutProperty(font, "Superscript", true)
+		case "subscript":
+			oleutil.MustPutProperty(font, "Subscript", true)
+		case "baseline":
+			oleutil.MustPutProperty(font, "Superscript", false)
+			oleutil.MustPutProperty(font, "Subscript", false)
+		}
+	}
+
+	// Interior (fill)
+	if style.Fill != nil {
+		interior := oleutil.MustGetProperty(rng, "Interior").ToIDispatch()
+		defer interior.Release()
+
+		pattern := style.Fill.Pattern
+		if pattern == FillPatternNone && len(style.Fill.Color) > 0 {
+			pattern = FillPatternSolid
+		}
+		oleutil.MustPutProperty(interior, "Pattern", fillPatternToExcelPattern(pattern))
+		if pattern != FillPatternNone && len(style.Fill.Color) > 0 {
+			oleutil.MustPutProperty(interior, "Color", rgbToBgr(style.Fill.Color[0]))
+		}
+	}
+
+	// Borders
+	for _, borderStyle := range style.Border {
+		index, ok := getOleBorderIndex(borderStyle.Type)
+		if !ok {
+			return fmt.Errorf("unknown border type: %s", borderStyle.Type)
+		}
+		border := oleutil.MustGetProperty(rng, "Borders", index).ToIDispatch()
+		defer border.Release()
+
+		lineStyle, weight := borderStyleNameToExcel(borderStyle.Style)
+		oleutil.MustPutProperty(border, "LineStyle", lineStyle)
+		if weight != 0 {
+			oleutil.MustPutProperty(border, "Weight", weight)
+		}
+		if borderStyle.Style != BorderStyleNone && borderStyle.Color != "" {
+			oleutil.MustPutProperty(border, "Color", rgbToBgr(borderStyle.Color))
+		}
+	}
+
+	// NumberFormat
+	if style.NumFmt != "" {
+		oleutil.MustPutProperty(rng, "NumberFormat", style.NumFmt)
+	} else if style.DecimalPlaces > 0 {
+		oleutil.MustPutProperty(rng, "NumberFormat", decimalPlacesToNumFmt(style.DecimalPlaces))
+	}
+
+	return nil
+}
+
+func (o *OleWorksheet) InsertRows(row int, count int) (*ShiftResult, error) {
+	return o.shiftCells(cellShift{rows: true, start: row, offset: count}, "Rows", fmt.Sprintf("%d:%d", row, row+count-1), "Insert", -4121) // xlShiftDown
+}
+
+func (o *OleWorksheet) DeleteRows(row int, count int) (*ShiftResult, error) {
+	return o.shiftCells(cellShift{rows: true, start: row, offset: -count}, "Rows", fmt.Sprintf("%d:%d", row, row+count-1), "Delete", -4162) // xlShiftUp
+}
+
+func (o *OleWorksheet) InsertColumns(col int, count int) (*ShiftResult, error) {
+	columns, err := columnRangeName(col, count)
+	if err != nil {
+		return nil, err
+	}
+	return o.shiftCells(cellShift{rows: false, start: col, offset: count}, "Columns", columns, "Insert", -4161) // xlShiftToRight
+}
+
+func (o *OleWorksheet) DeleteColumns(col int, count int) (*ShiftResult, error) {
+	columns, err := columnRangeName(col, count)
+	if err != nil {
+		return nil, err
+	}
+	return o.shiftCells(cellShift{rows: false, start: col, offset: -count}, "Columns", columns, "Delete", -4159) // xlShiftToLeft
+}
+
+// columnRangeName returns the range name of count columns starting from col (e.g. C:E)
+func columnRangeName(col int, count int) (string, error) {
+	startName, err := excelize.ColumnNumberToName(col)
+	if err != nil {
+		return "", err
+	}
+	endName, err := excelize.ColumnNumberToName(col + count - 1)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%s", startName, endName), nil
+}
+
+// shiftCells calls Range.Insert or Range.Delete on the entire rows or columns, and reports the adjusted references.
+func (o *OleWorksheet) shiftCells(s cellShift, property string, rangeName string, method string, direction int) (*ShiftResult, error) {
+	if s.start < 1 || s.offset == 0 {
+		return nil, fmt.Errorf("invalid position or count: %d, %d", s.start, s.offset)
+	}
+	sheetName, err := o.Name()
+	if err != nil {
+		return nil, err
+	}
+	before, err := o.takeShiftSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	rng := oleutil.MustGetProperty(o.worksheet, property, rangeName).ToIDispatch()
+	defer rng.Release()
+	if _, err := oleutil.CallMethod(rng, method, direction); err != nil {
+		return nil, fmt.Errorf("failed to %s %s: %w", strings.ToLower(method), rangeName, err)
+	}
+
+	after, err := o.takeShiftSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return s.diff(sheetName, before, after), nil
+}
+
+func (o *OleWorksheet) takeShiftSnapshot() (*shiftSnapshot, error) {
+	snapshot := newShiftSnapshot()
+	tables, err := o.GetTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range tables {
+		snapshot.tables[table.Name] = table.Range
+	}
+
+	names := oleutil.MustGetProperty(o.workbook, "Names").ToIDispatch()
+	defer names.Release()
+	count := int(oleutil.MustGetProperty(names, "Count").Val)
+	for i := 1; i <= count; i++ {
+		name := oleutil.MustGetProperty(names, "Item", i).ToIDispatch()
+		snapshot.definedNames[oleutil.MustGetProperty(name, "Name").ToString()] = oleutil.MustGetProperty(name, "RefersTo").ToString()
+		name.Release()
+	}
+
+	worksheets := oleutil.MustGetProperty(o.workbook, "Worksheets").ToIDispatch()
+	defer worksheets.Release()
+	sheetCount := int(oleutil.MustGetProperty(worksheets, "Count").Val)
+	for i := 1; i <= sheetCount; i++ {
+		worksheet := oleutil.MustGetProperty(worksheets, "Item", i).ToIDispatch()
+		sheetName := oleutil.MustGetProperty(worksheet, "Name").ToString()
+		formulas, err := getOleFormulas(worksheet)
+		worksheet.Release()
+		if err != nil {
+			return nil, err
+		}
+		snapshot.formulas[sheetName] = formulas
+	}
+	return snapshot, nil
+}
+
+// getOleFormulas returns all formulas in the worksheet keyed by cell name
+func getOleFormulas(worksheet *ole.IDispatch) (map[string]string, error) {
+	formulas := make(map[string]string)
+	usedRange := oleutil.MustGetProperty(worksheet, "UsedRange").ToIDispatch()
+	defer usedRange.Release()
+
+	// SpecialCells raises an error when the sheet has no formula
+	formulaCellsVariant, err := oleutil.CallMethod(usedRange, "SpecialCells", -4123) // xlCellTypeFormulas
+	if err != nil {
+		return formulas, nil
+	}
+	formulaCells := formulaCellsVariant.ToIDispatch()
+	defer formulaCells.Release()
+
+	err = oleutil.ForEach(formulaCells, func(v *ole.VARIANT) error {
+		cell := v.ToIDispatch()
+		defer cell.Release()
+		address := oleutil.MustGetProperty(cell, "Address", false, false).ToString()
+		formulas[address] = oleutil.MustGetProperty(cell, "Formula").ToString()
+		return nil
+	})
+	return formulas, err
+}
+
+func (o *OleWorksheet) GetMergedCells() ([]string, error) {
+	mergedCells := []string{}
+	usedRange := oleutil.MustGetProperty(o.worksheet, "UsedRange").ToIDispatch()
+	defer usedRange.Release()
+
+	// MergeCells of a range is false if it contains no merged cells, and null if it contains some
+	if mergeCells, ok := oleutil.MustGetProperty(usedRange, "MergeCells").Value().(bool); ok && !mergeCells {
+		return mergedCells, nil
+	}
+
+	found := make(map[string]bool)
+	err := oleutil.ForEach(usedRange, func(v *ole.VARIANT) error {
+		cell := v.ToIDispatch()
+		defer cell.Release()
+		if mergeCells, ok := oleutil.MustGetProperty(cell, "MergeCells").Value().(bool); !ok || !mergeCells {
+			return nil
+		}
+		mergeArea := oleutil.MustGetProperty(cell, "MergeArea").ToIDispatch()
+		defer mergeArea.Release()
+		address := NormalizeRange(oleutil.MustGetProperty(mergeArea, "Address").ToString())
+		if !found[address] {
+			found[address] = true
+			mergedCells = append(mergedCells, address)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mergedCells, nil
+}
+
+func (o *OleWorksheet) MergeCells(cellRange string) error {
+	return o.callWithoutAlerts(cellRange, "Merge")
+}
+
+func (o *OleWorksheet) UnmergeCells(cellRange string) error {
+	return o.callWithoutAlerts(cellRange, "UnMerge")
+}
+
+func (o *OleWorksheet) GetComments() ([]Comment, error) {
+	comments := oleutil.MustGetProperty(o.worksheet, "Comments").ToIDispatch()
+	defer comments.Release()
+	count := int(oleutil.MustGetProperty(comments, "Count").Val)
+	result := make([]Comment, 0, count)
+	for i := 1; i <= count; i++ {
+		comment := oleutil.MustCallMethod(comments, "Item", i).ToIDispatch()
+		defer comment.Release()
+		parent := oleutil.MustGetProperty(comment, "Parent").ToIDispatch()
+		defer parent.Release()
+		result = append(result, Comment{
+			Cell:   oleutil.MustGetProperty(parent, "Address", false, false).ToString(),
+			Author: oleutil.MustGetProperty(comment, "Author").ToString(),
+			Text:   oleutil.MustCallMethod(comment, "Text").ToString(),
+		})
+	}
+	return result, nil
+}
+
+func (o *OleWorksheet) AddComment(comment *Comment) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", comment.Cell).ToIDispatch()
+	defer rng.Release()
+	oleutil.MustCallMethod(rng, "ClearComments")
+	// The author of a comment is always Application.UserName, so the author is written
+	// at the beginning of the text as Excel does
+	text := comment.Text
+	if comment.Author != "" {
+		text = comment.Author + ":\n" + text
+	}
+	if _, err := oleutil.CallMethod(rng, "AddComment", text); err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) DeleteComment(cell string) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer rng.Release()
+	// Comment is Nothing if the cell has no comment
+	comment := oleutil.MustGetProperty(rng, "Comment").ToIDispatch()
+	if comment == nil {
+		return fmt.Errorf("no comment in cell %s", cell)
+	}
+	comment.Release()
+	oleutil.MustCallMethod(rng, "ClearComments")
+	return nil
+}
+
+func (o *OleWorksheet) GetHyperlink(cell string) (*Hyperlink, error) {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer rng.Release()
+	hyperlinks := oleutil.MustGetProperty(rng, "Hyperlinks").ToIDispatch()
+	defer hyperlinks.Release()
+	if oleutil.MustGetProperty(hyperlinks, "Count").Val == 0 {
+		return nil, nil
+	}
+	hyperlink := oleutil.MustCallMethod(hyperlinks, "Item", 1).ToIDispatch()
+	defer hyperlink.Release()
+
+	// Address is empty for a location in the workbook, and SubAddress is the location (or the anchor of an external link)
+	address := oleutil.MustGetProperty(hyperlink, "Address").ToString()
+	subAddress := oleutil.MustGetProperty(hyperlink, "SubAddress").ToString()
+	target := subAddress
+	if address != "" {
+		target = address
+		if subAddress != "" {
+			target += "#" + subAddress
+		}
+	}
+	return &Hyperlink{
+		Target:  target,
+		Tooltip: oleutil.MustGetProperty(hyperlink, "ScreenTip").ToString(),
+	}, nil
+}
+
+func (o *OleWorksheet) SetHyperlink(cell string, hyperlink *Hyperlink) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", cell).ToIDispatch()
+	defer rng.Release()
+	existing := oleutil.MustGetProperty(rng, "Hyperlinks").ToIDispatch()
+	defer existing.Release()
+	oleutil.MustCallMethod(existing, "Delete")
+	if hyperlink == nil {
+		return nil
+	}
+
+	address, subAddress := "", hyperlink.Target
+	if hyperlink.IsExternal() {
+		address, subAddress = hyperlink.Target, ""
+	}
+	hyperlinks := oleutil.MustGetProperty(o.worksheet, "Hyperlinks").ToIDispatch()
+	defer hyperlinks.Release()
+	args := []any{rng, address, subAddress}
+	if hyperlink.Tooltip != "" {
+		args = append(args, hyperlink.Tooltip)
+	}
+	if _, err := oleutil.CallMethod(hyperlinks, "Add", args...); err != nil {
+		return fmt.Errorf("failed to set hyperlink: %w", err)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) GetPanes() (*Panes, error) {
+	panes := &Panes{Mode: PanesNone}
+	err := o.withWindow(func(window *ole.IDispatch) error {
+		freezePanes, _ := oleutil.MustGetProperty(window, "FreezePanes").Value().(bool)
+		split, _ := oleutil.MustGetProperty(window, "Split").Value().(bool)
+		if !freezePanes && !split {
+			return nil
+		}
+		panes.Mode = PanesSplit
+		if freezePanes {
+			panes.Mode = PanesFreeze
+		}
+		// SplitRow and SplitColumn count the rows and columns visible in the top-left pane,
+		// so the rows and columns scrolled out of the pane are added
+		windowPanes := oleutil.MustGetProperty(window, "Panes").ToIDispatch()
+		defer windowPanes.Release()
+		topLeftPane := oleutil.MustGetProperty(windowPanes, "Item", 1).ToIDispatch()
+		defer topLeftPane.Release()
+		if panes.Rows = int(oleutil.MustGetProperty(window, "SplitRow").Val); panes.Rows > 0 {
+			panes.Rows += int(oleutil.MustGetProperty(topLeftPane, "ScrollRow").Val) - 1
+		}
+		if panes.Columns = int(oleutil.MustGetProperty(window, "SplitColumn").Val); panes.Columns > 0 {
+			panes.Columns += int(oleutil.MustGetProperty(topLeftPane, "ScrollColumn").Val) - 1
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return panes, nil
+}
+
+func (o *OleWorksheet) SetPanes(panes *Panes) error {
+	if panes.Mode != PanesNone && panes.Rows == 0 && panes.Columns == 0 {
+		return fmt.Errorf("rows or columns must be greater than 0 to %s panes", panes.Mode)
+	}
+	return o.withWindow(func(window *ole.IDispatch) error {
+		oleutil.MustPutProperty(window, "FreezePanes", false)
+		oleutil.MustPutProperty(window, "Split", false)
+		if panes.Mode == PanesNone {
+			return nil
+		}
+		// The split is placed relative to the scroll position
+		oleutil.MustPutProperty(window, "ScrollRow", 1)
+		oleutil.MustPutProperty(window, "ScrollColumn", 1)
+		oleutil.MustPutProperty(window, "SplitColumn", panes.Columns)
+		oleutil.MustPutProperty(window, "SplitRow", panes.Rows)
+		if panes.Mode == PanesFreeze {
+			if _, err := oleutil.PutProperty(window, "FreezePanes", true); err != nil {
+				return fmt.Errorf("failed to freeze panes: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (o *OleWorksheet) GetViewOptions() (*ViewOptions, error) {
+	options := &ViewOptions{}
+	err := o.withWindow(func(window *ole.IDispatch) error {
+		showGridLines, _ := oleutil.MustGetProperty(window, "DisplayGridlines").Value().(bool)
+		rightToLeft, _ := oleutil.MustGetProperty(window, "DisplayRightToLeft").Value().(bool)
+		zoom := 100
+		switch value := oleutil.MustGetProperty(window, "Zoom").Value().(type) {
+		case int32:
+			zoom = int(value)
+		case float64:
+			zoom = int(value)
+		}
+		app := oleutil.MustGetProperty(o.workbook, "Application").ToIDispatch()
+		defer app.Release()
+		activeCell := oleutil.MustGetProperty(app, "ActiveCell").ToIDispatch()
+		defer activeCell.Release()
+		options.ShowGridLines = &showGridLines
+		options.RightToLeft = &rightToLeft
+		options.Zoom = &zoom
+		options.SelectedCell = oleutil.MustGetProperty(activeCell, "Address", false, false).ToString()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+func (o *OleWorksheet) SetViewOptions(options *ViewOptions) error {
+	return o.withWindow(func(window *ole.IDispatch) error {
+		if options.ShowGridLines != nil {
+			oleutil.MustPutProperty(window, "DisplayGridlines", *options.ShowGridLines)
+		}
+		if options.RightToLeft != nil {
+			oleutil.MustPutProperty(window, "DisplayRightToLeft", *options.RightToLeft)
+		}
+		if options.Zoom != nil {
+			oleutil.MustPutProperty(window, "Zoom", *options.Zoom)
+		}
+		if options.SelectedCell != "" {
+			rngVariant, err := oleutil.GetProperty(o.worksheet, "Range", options.SelectedCell)
+			if err != nil {
+				return fmt.Errorf("invalid cell: %s", options.SelectedCell)
+			}
+			rng := rngVariant.ToIDispatch()
+			defer rng.Release()
+			if _, err := oleutil.CallMethod(rng, "Select"); err != nil {
+				return fmt.Errorf("failed to select %s: %w", options.SelectedCell, err)
+			}
+		}
+		return nil
+	})
+}
+
+// withWindow activates the worksheet and calls fn with the active window, since panes and view settings belong to the window.
+// The previously active sheet is activated again afterwards.
+func (o *OleWorksheet) withWindow(fn func(window *ole.IDispatch) error) error {
+	if visible := oleutil.MustGetProperty(o.worksheet, "Visible").Val; visible != -1 { // xlSheetVisible
+		return fmt.Errorf("the view of a hidden sheet is not available")
+	}
+	activeSheet := oleutil.MustGetProperty(o.workbook, "ActiveSheet").ToIDispatch()
+	defer activeSheet.Release()
+	if _, err := oleutil.CallMethod(o.worksheet, "Activate"); err != nil {
+		return fmt.Errorf("failed to activate sheet: %w", err)
+	}
+	defer oleutil.CallMethod(activeSheet, "Activate")
+
+	app := oleutil.MustGetProperty(o.workbook, "Application").ToIDispatch()
+	defer app.Release()
+	window := oleutil.MustGetProperty(app, "ActiveWindow").ToIDispatch()
+	defer window.Release()
+	return fn(window)
+}
+
+// Constants of XlAutoFilterOperator
+const (
+	xlAnd             = 1
+	xlOr              = 2
+	xlTop10Items      = 3
+	xlBottom10Items   = 4
+	xlTop10Percent    = 5
+	xlBottom10Percent = 6
+	xlFilterValues    = 7
+)
+
+func (o *OleWorksheet) GetAutoFilter() (*AutoFilter, error) {
+	if enabled, _ := oleutil.MustGetProperty(o.worksheet, "AutoFilterMode").Value().(bool); !enabled {
+		return nil, nil
+	}
+	autoFilter := oleutil.MustGetProperty(o.worksheet, "AutoFilter").ToIDispatch()
+	defer autoFilter.Release()
+	rng := oleutil.MustGetProperty(autoFilter, "Range").ToIDispatch()
+	defer rng.Release()
+	filter := &AutoFilter{
+		Range:   oleutil.MustGetProperty(rng, "Address", false, false).ToString(),
+		Columns: []AutoFilterColumn{},
+	}
+	startCol, _, _, _, err := ParseCellOrRange(filter.Range)
+	if err != nil {
+		return nil, err
+	}
+	filters := oleutil.MustGetProperty(autoFilter, "Filters").ToIDispatch()
+	defer filters.Release()
+	count := int(oleutil.MustGetProperty(filters, "Count").Val)
+	for i := 1; i <= count; i++ {
+		item := oleutil.MustGetProperty(filters, "Item", i).ToIDispatch()
+		defer item.Release()
+		if on, _ := oleutil.MustGetProperty(item, "On").Value().(bool); !on {
+			continue
+		}
+		columnName, _ := excelize.ColumnNumberToName(startCol + i - 1)
+		column := AutoFilterColumn{Column: columnName}
+		criteria := []string{}
+		for _, property := range []string{"Criteria1", "Criteria2"} {
+			// Criteria2 is not available if the column has a single criterion
+			criterion, err := oleutil.GetProperty(item, property)
+			if err != nil {
+				continue
+			}
+			if criterion.VT&ole.VT_ARRAY != 0 {
+				for _, value := range criterion.ToArray().ToValueArray() {
+					criteria = append(criteria, fmt.Sprint(value))
+				}
+			} else {
+				criteria = append(criteria, fmt.Sprint(criterion.Value()))
+			}
+		}
+		operator := int(oleutil.MustGetProperty(item, "Operator").Val)
+		switch operator {
+		case xlFilterValues:
+			column.Type = AutoFilterEquals
+			for _, criterion := range criteria {
+				column.Values = append(column.Values, strings.TrimPrefix(criterion, "="))
+			}
+		case xlTop10Items, xlBottom10Items, xlTop10Percent, xlBottom10Percent:
+			column.Type = AutoFilterTopN
+			if len(criteria) > 0 {
+				column.Count, _ = strconv.Atoi(criteria[0])
+			}
+			column.Bottom = operator == xlBottom10Items || operator == xlBottom10Percent
+			column.Percent = operator == xlTop10Percent || operator == xlBottom10Percent
+		default:
+			conditions := []filterCondition{}
+			for _, criterion := range criteria {
+				parsed, _, err := parseFilterExpression(criterion)
+				if err != nil {
+					return nil, fmt.Errorf("unsupported filter criteria of column %s: %s", columnName, criterion)
+				}
+				conditions = append(conditions, parsed[0])
+			}
+			text, contains := "", false
+			if len(conditions) == 1 && conditions[0].Operator == "=" {
+				text, contains = containsFilterText(conditions[0].Value)
+			}
+			switch {
+			case contains:
+				column.Type = AutoFilterContains
+				column.Text = text
+			case len(conditions) == 1 && conditions[0].Operator == "=" && !strings.ContainsAny(conditions[0].Value, "*?"):
+				column.Type = AutoFilterEquals
+				column.Values = []string{conditions[0].Value}
+			default:
+				column.Type = AutoFilterCustom
+				column.Expression = formatFilterExpression(conditions, operator == xlAnd)
+			}
+		}
+		filter.Columns = append(filter.Columns, column)
+	}
+	return filter, nil
+}
+
+func (o *OleWorksheet) SetAutoFilter(filter *AutoFilter) error {
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(filter.Range)
+	if err != nil {
+		return err
+	}
+	if startRow == endRow {
+		return fmt.Errorf("auto filter range must have rows below the header row: %s", filter.Range)
+	}
+	if err := o.RemoveAutoFilter(); err != nil {
+		return err
+	}
+	rngVariant, err := oleutil.GetProperty(o.worksheet, "Range", filter.Range)
+	if err != nil {
+		return fmt.Errorf("invalid range: %s", filter.Range)
+	}
+	rng := rngVariant.ToIDispatch()
+	defer rng.Release()
+	if len(filter.Columns) == 0 {
+		if _, err := oleutil.CallMethod(rng, "AutoFilter"); err != nil {
+			return fmt.Errorf("failed to set auto filter: %w", err)
+		}
+		return nil
+	}
+	for _, column := range filter.Columns {
+		col, err := excelize.ColumnNameToNumber(column.Column)
+		if err != nil {
+			return err
+		}
+		if col < startCol || col > endCol {
+			return fmt.Errorf("column %s is out of the auto filter range %s", column.Column, filter.Range)
+		}
+		field := col - startCol + 1
+		var args []any
+		switch column.Type {
+		case AutoFilterEquals:
+			values := make([]string, len(column.Values))
+			for i, value := range column.Values {
+				values[i] = "=" + value
+			}
+			args = []any{field, values, xlFilterValues}
+		case AutoFilterContains:
+			args = []any{field, "=*" + escapeWildcard(column.Text) + "*"}
+		case AutoFilterTopN:
+			operator := xlTop10Items
+			switch {
+			case column.Bottom && column.Percent:
+				operator = xlBottom10Percent
+			case column.Bottom:
+				operator = xlBottom10Items
+			case column.Percent:
+				operator = xlTop10Percent
+			}
+			args = []any{field, column.Count, operator}
+		case AutoFilterCustom:
+			conditions, and, err := parseFilterExpression(column.Expression)
+			if err != nil {
+				return err
+			}
+			args = []any{field, conditions[0].Operator + conditions[0].Value}
+			if len(conditions) > 1 {
+				operator := xlOr
+				if and {
+					operator = xlAnd
+				}
+				args = append(args, operator, conditions[1].Operator+conditions[1].Value)
+			}
+		default:
+			return fmt.Errorf("invalid filter type: %s", column.Type)
+		}
+		if _, err := oleutil.CallMethod(rng, "AutoFilter", args...); err != nil {
+			return fmt.Errorf("failed to filter column %s: %w", column.Column, err)
+		}
+	}
+	return nil
+}
+
+func (o *OleWorksheet) RemoveAutoFilter() error {
+	if enabled, _ := oleutil.MustGetProperty(o.worksheet, "AutoFilterMode").Value().(bool); !enabled {
+		return nil
+	}
+	if _, err := oleutil.PutProperty(o.worksheet, "AutoFilterMode", false); err != nil {
+		return fmt.Errorf("failed to remove auto filter: %w", err)
+	}
+	return nil
+}
+
+func (o *OleWorksheet) SortRange(options *SortOptions) error {
+	if options.Natural {
+		return fmt.Errorf("natural ordering is not supported by the OLE backend")
+	}
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(options.Range)
+	if err != nil {
+		return err
+	}
+	if options.HasHeader {
+		startRow++
+	}
+	if startRow >= endRow {
+		return nil
+	}
+	dataStart, _ := excelize.CoordinatesToCellName(startCol, startRow)
+	dataEnd, _ := excelize.CoordinatesToCellName(endCol, endRow)
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", dataStart+":"+dataEnd).ToIDispatch()
+	defer rng.Release()
+
+	// Range.Sort accepts up to 3 keys, so the keys are applied in groups of 3 from the least significant group.
+	// Excel sorts stably, which keeps the order by the less significant keys.
+	for end := len(options.Keys); end > 0; end -= 3 {
+		group := options.Keys[max(0, end-3):end]
+		keys, orders := make([]*ole.IDispatch, 3), make([]int, 3)
+		for i := range keys {
+			// The unused keys repeat the last key of the group
+			key := group[min(i, len(group)-1)]
+			col, err := excelize.ColumnNameToNumber(key.Column)
+			if err != nil {
+				return err
+			}
+			if col < startCol || col > endCol {
+				return fmt.Errorf("sort key column %s is out of the range %s", key.Column, options.Range)
+			}
+			keyCell, _ := excelize.CoordinatesToCellName(col, startRow)
+			keys[i] = oleutil.MustGetProperty(o.worksheet, "Range", keyCell).ToIDispatch()
+			defer keys[i].Release()
+			orders[i] = 1 // xlAscending
+			if key.Descending {
+				orders[i] = 2 // xlDescending
+			}
+		}
+		// Key1, Order1, Key2, Type (xlSortValues), Order2, Key3, Order3, Header (xlNo), OrderCustom (normal), MatchCase, Orientation (xlTopToBottom)
+		_, err := oleutil.CallMethod(rng, "Sort", keys[0], orders[0], keys[1], 1, orders[1], keys[2], orders[2], 2, 1, options.CaseSensitive, 1)
+		if err != nil {
+			return fmt.Errorf("failed to sort range: %w", err)
+		}
+	}
+	return nil
+}
+
+func (o *OleWorksheet) Search(options *SearchOptions) ([]SearchMatch, error) {
+	var scope *ole.IDispatch
+	if options.Range != "" {
+		scope = oleutil.MustGetProperty(o.worksheet, "Range", options.Range).ToIDispatch()
+	} else {
+		scope = oleutil.MustGetProperty(o.worksheet, "UsedRange").ToIDispatch()
+	}
+	defer scope.Release()
+
+	matches := []SearchMatch{}
+	appendMatch := func(cell *ole.IDispatch) {
+		match := SearchMatch{
+			Cell:  oleutil.MustGetProperty(cell, "Address", false, false).ToString(),
+			Value: oleutil.MustGetProperty(cell, "Text").ToString(),
+		}
+		if oleutil.MustGetProperty(cell, "HasFormula").Value().(bool) {
+			match.Formula = oleutil.MustGetProperty(cell, "Formula").ToString()
+		}
+		matches = append(matches, match)
+	}
+
+	if options.Mode == SearchLiteral && options.Target != SearchBoth {
+		if err := o.findAll(scope, options, appendMatch); err != nil {
+			return nil, err
+		}
+	} else {
+		match, err := oleSearchMatcher(options)
+		if err != nil {
+			return nil, err
+		}
+		err = forEachOleNonEmptyCell(scope, func(cell *ole.IDispatch) error {
+			if match(cell) {
+				appendMatch(cell)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	slices.SortFunc(matches, func(a, b SearchMatch) int {
+		return compareCellNames(a.Cell, b.Cell)
+	})
+	return matches, nil
+}
+
+// findAll calls fn with each cell found by Range.Find in the scope
+func (o *OleWorksheet) findAll(scope *ole.IDispatch, options *SearchOptions, fn func(cell *ole.IDispatch)) error {
+	rows := oleutil.MustGetProperty(scope, "Rows").ToIDispatch()
+	defer rows.Release()
+	cols := oleutil.MustGetProperty(scope, "Columns").ToIDispatch()
+	defer cols.Release()
+	// Searching after the last cell starts from the first cell
+	after := oleutil.MustGetProperty(scope, "Cells", oleutil.MustGetProperty(rows, "Count").Val, oleutil.MustGetProperty(cols, "Count").Val).ToIDispatch()
+	defer after.Release()
+
+	lookIn := -4163 // xlValues
+	if options.Target == SearchFormulas {
+		lookIn = -4123 // xlFormulas
+	}
+	lookAt := 2 // xlPart
+	if options.WholeCell {
+		lookAt = 1 // xlWhole
+	}
+	// What, After, LookIn, LookAt, SearchOrder (xlByRows), SearchDirection (xlNext), MatchCase
+	foundVariant, err := oleutil.CallMethod(scope, "Find", escapeWildcard(options.Query), after, lookIn, lookAt, 1, 1, options.MatchCase)
+	if err != nil {
+		return fmt.Errorf("failed to search: %w", err)
+	}
+	found := foundVariant.ToIDispatch()
+	if found == nil {
+		return nil
+	}
+	first := oleutil.MustGetProperty(found, "Address").ToString()
+	for {
+		// Formulas are looked in also for constants, whose formula is the value
+		if options.Target != SearchFormulas || oleutil.MustGetProperty(found, "HasFormula").Value().(bool) {
+			fn(found)
+		}
+		nextVariant, err := oleutil.CallMethod(scope, "FindNext", found)
+		found.Release()
+		if err != nil {
+			return fmt.Errorf("failed to search: %w", err)
+		}
+		found = nextVariant.ToIDispatch()
+		if found == nil {
+			return nil
+		}
+		if oleutil.MustGetProperty(found, "Address").ToString() == first {
+			found.Release()
+			return nil
+		}
+	}
+}
+
+// oleSearchMatcher returns the function reporting whether the cell matches the regex, numeric or literal search in both values and formulas
+func oleSearchMatcher(options *SearchOptions) (func(cell *ole.IDispatch) bool, error) {
+	if options.Mode == SearchNumeric {
+		match, err := numericSearchMatcher(options.Query)
+		if err != nil {
+			return nil, err
+		}
+		return func(cell *ole.IDispatch) bool {
+			value, ok := oleutil.MustGetProperty(cell, "Value2").Value().(float64)
+			return ok && match(strconv.FormatFloat(value, 'f', -1, 64))
+		}, nil
+	}
+	pattern, err := searchPattern(options)
+	if err != nil {
+		return nil, err
+	}
+	return func(cell *ole.IDispatch) bool {
+		if options.Target != SearchFormulas && pattern.MatchString(oleutil.MustGetProperty(cell, "Text").ToString()) {
+			return true
+		}
+		return options.Target != SearchValues &&
+			oleutil.MustGetProperty(cell, "HasFormula").Value().(bool) &&
+			pattern.MatchString(oleutil.MustGetProperty(cell, "Formula").ToString())
+	}, nil
+}
+
+// forEachOleNonEmptyCell calls fn with each constant and formula cell in the range
+func forEachOleNonEmptyCell(rng *ole.IDispatch, fn func(cell *ole.IDispatch) error) error {
+	for _, cellType := range []int{2, -4123} { // xlCellTypeConstants, xlCellTypeFormulas
+		// SpecialCells raises an error when the range has no such cell
+		cellsVariant, err := oleutil.CallMethod(rng, "SpecialCells", cellType)
+		if err != nil {
+			continue
+		}
+		cells := cellsVariant.ToIDispatch()
+		err = oleutil.ForEach(cells, func(v *ole.VARIANT) error {
+			cell := v.ToIDispatch()
+			defer cell.Release()
+			return fn(cell)
+		})
+		cells.Release()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OleWorksheet) GetColumnWidth(column string) (float64, error) {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", column+"1").ToIDispatch()
+	defer rng.Release()
+	width, ok := oleutil.MustGetProperty(rng, "ColumnWidth").Value().(float64)
+	if !ok {
+		return 0, fmt.Errorf("failed to get width of column %s", column)
+	}
+	return width, nil
+}
+
+func (o *OleWorksheet) SetColumnWidth(column string, width float64) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", column+"1").ToIDispatch()
+	defer rng.Release()
+	_, err := oleutil.PutProperty(rng, "ColumnWidth", width)
+	return err
+}
+
+func (o *OleWorksheet) GetRowHeight(row int) (float64, error) {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", fmt.Sprintf("A%d", row)).ToIDispatch()
+	defer rng.Release()
+	height, ok := oleutil.MustGetProperty(rng, "RowHeight").Value().(float64)
+	if !ok {
+		return 0, fmt.Errorf("failed to get height of row %d", row)
+	}
+	return height, nil
+}
+
+func (o *OleWorksheet) SetRowHeight(row int, height float64) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", fmt.Sprintf("A%d", row)).ToIDispatch()
+	defer rng.Release()
+	_, err := oleutil.PutProperty(rng, "RowHeight", height)
+	return err
+}
+
+func (o *OleWorksheet) AutoFitColumns(cellRange string) error {
+	return o.autoFit(cellRange, "Columns")
+}
+
+func (o *OleWorksheet) AutoFitRows(cellRange string) error {
+	return o.autoFit(cellRange, "Rows")
+}
+
+// autoFit calls AutoFit on the columns or rows of the range, which fits them to the cells in the range
+func (o *OleWorksheet) autoFit(cellRange string, property string) error {
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", cellRange).ToIDispatch()
+	defer rng.Release()
+	lines := oleutil.MustGetProperty(rng, property).ToIDispatch()
+	defer lines.Release()
+	if _, err := oleutil.CallMethod(lines, "AutoFit"); err != nil {
+		return fmt.Errorf("failed to auto-fit %s of %s: %w", strings.ToLower(property), cellRange, err)
+	}
+	return nil
+}
+
+// oleCSVCodePages maps the CSV encodings to the code pages of TextFilePlatform
+var oleCSVCodePages = map[CSVEncoding]int{
+	CSVEncodingUTF8:     65001,
+	CSVEncodingShiftJIS: 932,
+	CSVEncodingUTF16:    1200,
+}
+
+// ImportCSV imports the file with a query table, which is the text import of Excel, and deletes the query afterwards
+func (o *OleWorksheet) ImportCSV(options *CSVImportOptions) (string, error) {
+	queryTables := oleutil.MustGetProperty(o.worksheet, "QueryTables").ToIDispatch()
+	defer queryTables.Release()
+	destination := oleutil.MustGetProperty(o.worksheet, "Range", options.StartCell).ToIDispatch()
+	defer destination.Release()
+	queryTableVariant, err := oleutil.CallMethod(queryTables, "Add", "TEXT;"+options.Path, destination)
+	if err != nil {
+		return "", fmt.Errorf("failed to import CSV file: %w", err)
+	}
+	queryTable := queryTableVariant.ToIDispatch()
+	defer queryTable.Release()
+	defer func() {
+		// Deleting the query table keeps the imported data, but leaves the connection in the workbook
+		connectionVariant, err := oleutil.GetProperty(queryTable, "WorkbookConnection")
+		oleutil.CallMethod(queryTable, "Delete")
+		if err != nil {
+			return
+		}
+		if connection := connectionVariant.ToIDispatch(); connection != nil {
+			oleutil.CallMethod(connection, "Delete")
+			connection.Release()
+		}
+	}()
+
+	oleutil.MustPutProperty(queryTable, "TextFilePlatform", oleCSVCodePages[options.Encoding])
+	oleutil.MustPutProperty(queryTable, "TextFileParseType", 1)     // xlDelimited
+	oleutil.MustPutProperty(queryTable, "TextFileTextQualifier", 1) // xlTextQualifierDoubleQuote
+	oleutil.MustPutProperty(queryTable, "TextFileConsecutiveDelimiter", false)
+	oleutil.MustPutProperty(queryTable, "TextFileCommaDelimiter", options.Delimiter == ',')
+	oleutil.MustPutProperty(queryTable, "TextFileTabDelimiter", options.Delimiter == '\t')
+	oleutil.MustPutProperty(queryTable, "TextFileSemicolonDelimiter", options.Delimiter == ';')
+	oleutil.MustPutProperty(queryTable, "TextFileSpaceDelimiter", options.Delimiter == ' ')
+	if !strings.ContainsRune(",\t; ", options.Delimiter) {
+		oleutil.MustPutProperty(queryTable, "TextFileOtherDelimiter", string(options.Delimiter))
+	}
+	if !options.InferTypes {
+		// Excel infers the types of the columns with xlGeneralFormat, so all columns are imported with xlTextFormat
+		_, columns, err := measureCSV(options)
+		if err != nil {
+			return "", err
+		}
+		dataTypes := make([]string, columns)
+		for i := range dataTypes {
+			dataTypes[i] = "2" // xlTextFormat
+		}
+		oleutil.MustPutProperty(queryTable, "TextFileColumnDataTypes", dataTypes)
+	}
+	oleutil.MustPutProperty(queryTable, "RefreshStyle", 0) // xlOverwriteCells
+	oleutil.MustPutProperty(queryTable, "AdjustColumnWidth", false)
+	oleutil.MustPutProperty(queryTable, "PreserveFormatting", true)
+	if _, err := oleutil.CallMethod(queryTable, "Refresh", false); err != nil {
+		return "", fmt.Errorf("failed to import CSV file: %w", err)
+	}
+	resultRange := oleutil.MustGetProperty(queryTable, "ResultRange").ToIDispatch()
+	defer resultRange.Release()
+	return NormalizeRange(oleutil.MustGetProperty(resultRange, "Address").ToString()), nil
+}
+
+// callWithoutAlerts calls the method of the range suppressing confirmation dialogs
+func (o *OleWorksheet) callWithoutAlerts(cellRange string, method string) error {
+	app := oleutil.MustGetProperty(o.workbook, "Application").ToIDispatch()
+	defer app.Release()
+	displayAlerts := oleutil.MustGetProperty(app, "DisplayAlerts").Value()
+	oleutil.MustPutProperty(app, "DisplayAlerts", false)
+	defer oleutil.PutProperty(app, "DisplayAlerts", displayAlerts)
+
+	rng := oleutil.MustGetProperty(o.worksheet, "Range", cellRange).ToIDispatch()
+	defer rng.Release()
+	if _, err := oleutil.CallMethod(rng, method); err != nil {
+		return fmt.Errorf("failed to call %s on %s: %w", method, cellRange, err)
+	}
+	return nil
+}
+
+// rgbToBgr converts RGB hex string to BGR color format
+func rgbToBgr(hexColor string) int32 {
+	r, g, b := parseRGBColor(hexColor)
+	return int32(r | g<<8 | b<<16)
+}
+
+// getOleUnderline converts underline name to Excel XlUnderlineStyle constant
+func getOleUnderline(underline string) int {
+	switch underline {
+	case "single":
+		return 2 // xlUnderlineStyleSingle
+	case "double":
+		return -4119 // xlUnderlineStyleDouble
+	case "singleAccounting":
+		return 4 // xlUnderlineStyleSingleAccounting
+	case "doubleAccounting":
+		return 5 // xlUnderlineStyleDoubleAccounting
+	default:
+		return -4142 // xlUnderlineStyleNone
+	}
+}
+
+// getOleBorderIndex converts border type to Excel XlBordersIndex constant
+func getOleBorderIndex(borderType string) (int, bool) {
+	switch borderType {
+	case "diagonalDown":
+		return 5, true // xlDiagonalDown
+	case "diagonalUp":
+		return 6, true // xlDiagonalUp
+	case "left":
+		return 7, true // xlEdgeLeft
+	case "top":
+		return 8, true // xlEdgeTop
+	case "bottom":
+		return 9, true // xlEdgeBottom
+	case "right":
+		return 10, true // xlEdgeRight
+	default:
+		return 0, false
+	}
+}
+
+// borderStyleNameToExcel converts BorderStyleName to Excel XlLineStyle and XlBorderWeight constants.
+// The weight is 0 for the styles which have a fixed weight.
+func borderStyleNameToExcel(style BorderStyleName) (int, int) {
+	switch style {
+	case BorderStyleContinuous:
+		return 1, 2 // xlContinuous, xlThin
+	case BorderStyleHair:
+		return 1, 1 // xlContinuous, xlHairline
+	case BorderStyleMedium:
+		return 1, -4138 // xlContinuous, xlMedium
+	case BorderStyleThick:
+		return 1, 4 // xlContinuous, xlThick
+	case BorderStyleDash:
+		return -4115, 2 // xlDash, xlThin
+	case BorderStyleMediumDash:
+		return -4115, -4138 // xlDash, xlMedium
+	case BorderStyleDot:
+		return -4118, 2 // xlDot, xlThin
+	case BorderStyleDouble:
+		return -4119, 0 // xlDouble
+	case BorderStyleDashDot:
+		return 4, 2 // xlDashDot, xlThin
+	case BorderStyleMediumDashDot:
+		return 4, -4138 // xlDashDot, xlMedium
+	case BorderStyleDashDotDot:
+		return 5, 2 // xlDashDotDot, xlThin
+	case BorderStyleMediumDashDotDot:
+		return 5, -4138 // xlDashDotDot, xlMedium
+	case BorderStyleSlantDashDot:
+		return 13, 0 // xlSlantDashDot
+	default:
+		return -4142, 0 // xlLineStyleNone
+	}
+}
+
+// fillPatternToExcelPattern converts FillPatternName to Excel XlPattern constant
+func fillPatternToExcelPattern(pattern FillPatternName) int {
+	switch pattern {
+	case FillPatternSolid:
+		return 1 // xlPatternSolid
+	case FillPatternMediumGray:
+		return -4125 // xlPatternGray50
+	case FillPatternDarkGray:
+		return -4126 // xlPatternGray75
+	case FillPatternLightGray:
+		return -4124 // xlPatternGray25
+	case FillPatternDarkHorizontal:
+		return -4128 // xlPatternHorizontal
+	case FillPatternDarkVertical:
+		return -4166 // xlPatternVertical
+	case FillPatternDarkDown:
+		return -4121 // xlPatternDown
+	case FillPatternDarkUp:
+		return -4162 // xlPatternUp
+	case FillPatternDarkGrid:
+		return 9 // xlPatternChecker
+	case FillPatternDarkTrellis:
+		return 10 // xlPatternSemiGray75
+	case FillPatternLightHorizontal:
+		return 11 // xlPatternLightHorizontal
+	case FillPatternLightVertical:
+		return 12 // xlPatternLightVertical
+	case FillPatternLightDown:
+		return 13 // xlPatternLightDown
+	case FillPatternLightUp:
+		return 14 // xlPatternLightUp
+	case FillPatternLightGrid:
+		return 15 // xlPatternGrid
+	case FillPatternLightTrellis:
+		return 16 // xlPatternCrissCross
+	case FillPatternGray125:
+		return 17 // xlPatternGray16
+	case FillPatternGray0625:
+		return 18 // xlPatternGray8
+	default:
+		return -4142 // xlPatternNone
+	}
+}
+
 func normalizePath(path string) string {
 	// Normalize the volume name to uppercase
 	vol := filepath.VolumeName(path)
@@ -809,11 +2969,11 @@ func applyOleFormatting(condition *ole.IDispatch, format *ConditionalFormattingS
 		font := oleutil.MustGetProperty(condition, "Font").ToIDispatch()
 		defer font.Release()
 
-		if format.Font.Bold {
-			oleutil.MustPutProperty(font, "Bold", true)
+		if format.Font.Bold != nil {
+			oleutil.MustPutProperty(font, "Bold", *format.Font.Bold)
 		}
-		if format.Font.Italic {
-			oleutil.MustPutProperty(font, "Italic", true)
+		if format.Font.Italic != nil {
+			oleutil.MustPutProperty(font, "Italic", *format.Font.Italic)
 		}
 		if format.Font.Color != "" {
 			r, g, b := parseRGBColor(format.Font.Color)
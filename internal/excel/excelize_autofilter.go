@@ -0,0 +1,206 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xmlWorksheetAutoFilter is the autoFilter element of a worksheet part
+type xmlWorksheetAutoFilter struct {
+	AutoFilter *xlsxAutoFilter `xml:"autoFilter"`
+}
+
+// readExcelizeAutoFilter reads the autoFilter element of the worksheet from the package Excelize writes,
+// since Excelize has no API to read it.
+func readExcelizeAutoFilter(file *excelize.File, sheetName string) (*xlsxAutoFilter, error) {
+	buf, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return nil, err
+	}
+	sheetParts, err := worksheetPartNames(reader)
+	if err != nil {
+		return nil, err
+	}
+	part, ok := sheetParts[sheetName]
+	if !ok {
+		return nil, fmt.Errorf("worksheet not found: %s", sheetName)
+	}
+	var worksheet xmlWorksheetAutoFilter
+	if err := unmarshalPackagePart(reader, part, &worksheet); err != nil {
+		return nil, err
+	}
+	return worksheet.AutoFilter, nil
+}
+
+// writeExcelizeAutoFilters writes the auto filters into the worksheet parts of the package, removing those of the
+// sheets mapped to nil, and hides the names of the filter ranges. Excelize can write neither top N filters nor more
+// than two values of a column, and it replaces the sheet properties to set the filter mode.
+func writeExcelizeAutoFilters(content []byte, autoFilters map[string]*xlsxAutoFilter) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+	workbookPart, err := workbookPartName(reader)
+	if err != nil {
+		return nil, err
+	}
+	sheetParts, err := worksheetPartNames(reader)
+	if err != nil {
+		return nil, err
+	}
+	partFilters := map[string]*xlsxAutoFilter{}
+	for sheetName, autoFilter := range autoFilters {
+		part, ok := sheetParts[sheetName]
+		if !ok {
+			return nil, fmt.Errorf("worksheet not found: %s", sheetName)
+		}
+		partFilters[part] = autoFilter
+	}
+	return rewritePackage(reader, func(name string, data []byte) ([]byte, error) {
+		if name == workbookPart {
+			return hideFilterDatabaseNames(data)
+		}
+		if autoFilter, ok := partFilters[name]; ok {
+			return rewriteAutoFilter(data, autoFilter)
+		}
+		return data, nil
+	})
+}
+
+// worksheetElementsAfterAutoFilter are the child elements of a worksheet following the autoFilter element in the schema
+var worksheetElementsAfterAutoFilter = []string{
+	"sortState", "dataConsolidate", "customSheetViews", "mergeCells", "phoneticPr", "conditionalFormatting",
+	"dataValidations", "hyperlinks", "printOptions", "pageMargins", "pageSetup", "headerFooter", "rowBreaks",
+	"colBreaks", "customProperties", "cellWatches", "ignoredErrors", "smartTags", "drawing", "legacyDrawing",
+	"legacyDrawingHF", "picture", "oleObjects", "controls", "webPublishItems", "tableParts", "extLst",
+}
+
+// rewriteAutoFilter replaces the autoFilter element of the worksheet part, or removes it if autoFilter is nil,
+// and sets the filter mode of the sheet properties when the filter has criteria. The rest of the part is copied as is.
+func rewriteAutoFilter(data []byte, autoFilter *xlsxAutoFilter) ([]byte, error) {
+	var element []byte // autoFilter element to insert
+	filterMode := ""
+	if autoFilter != nil {
+		var err error
+		if element, err = xml.Marshal(autoFilter); err != nil {
+			return nil, err
+		}
+		if len(autoFilter.FilterColumn) > 0 {
+			filterMode = "1"
+		}
+	}
+	var result bytes.Buffer
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	copied := int64(0) // offset up to which the data is copied to the result, or -1 while the data is skipped
+	depth := 0
+	var root xml.Name
+	sheetPr := false // whether the sheet properties are written
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		end := decoder.InputOffset()
+		switch token := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 1 {
+				root = token.Name
+			}
+			if depth != 2 {
+				continue
+			}
+			// The sheet properties are the first child of the worksheet
+			if !sheetPr && token.Name.Local != "sheetPr" {
+				sheetPr = true
+				if filterMode != "" {
+					result.Write(data[copied:start])
+					fmt.Fprintf(&result, `<%s filterMode="1"/>`, qualifiedXMLName(xml.Name{Space: root.Space, Local: "sheetPr"}))
+					copied = start
+				}
+			}
+			switch {
+			case token.Name.Local == "sheetPr":
+				sheetPr = true
+				result.Write(data[copied:start])
+				setXMLAttr(&token, "filterMode", filterMode)
+				// The decoder returns the end element of the self-closing element without reading the data
+				writeStartTag(&result, token, bytes.HasSuffix(data[start:end], []byte("/>")))
+				copied = end
+			case token.Name.Local == "autoFilter":
+				result.Write(data[copied:start])
+				copied = -1
+			case element != nil && slices.Contains(worksheetElementsAfterAutoFilter, token.Name.Local):
+				result.Write(data[copied:start])
+				result.Write(element)
+				copied = start
+				element = nil
+			}
+		case xml.EndElement:
+			depth--
+			switch {
+			case depth == 1 && copied < 0:
+				copied = end
+			case depth == 0 && element != nil:
+				result.Write(data[copied:start])
+				result.Write(element)
+				copied = start
+				element = nil
+			}
+		}
+	}
+	result.Write(data[copied:])
+	return result.Bytes(), nil
+}
+
+// hideFilterDatabaseNames hides the names of the filter ranges in the workbook part as Excel does,
+// since Excelize defines names without the hidden attribute.
+func hideFilterDatabaseNames(data []byte) ([]byte, error) {
+	var result bytes.Buffer
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	copied := int64(0) // offset up to which the data is copied to the result
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		element, ok := token.(xml.StartElement)
+		if !ok || element.Name.Local != "definedName" {
+			continue
+		}
+		name := ""
+		for _, attr := range element.Attr {
+			if attr.Name.Space == "" && attr.Name.Local == "name" {
+				name = attr.Value
+			}
+		}
+		if name != excelizeFilterDatabaseName {
+			continue
+		}
+		end := decoder.InputOffset()
+		result.Write(data[copied:start])
+		setXMLAttr(&element, "hidden", "1")
+		writeStartTag(&result, element, bytes.HasSuffix(data[start:end], []byte("/>")))
+		copied = end
+	}
+	result.Write(data[copied:])
+	return result.Bytes(), nil
+}
@@ -0,0 +1,177 @@
+package excel
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// newAutoFilterTestBook saves a workbook with a tab color, page margins, a header row and the rows of regions and amounts,
+// and opens it
+func newAutoFilterTestBook(t *testing.T) (string, Excel, Worksheet) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "Book.xlsx")
+	file := excelize.NewFile()
+	tabColor := "FFFF0000"
+	if err := file.SetSheetProps("Sheet1", &excelize.SheetPropsOptions{TabColorRGB: &tabColor}); err != nil {
+		t.Fatal(err)
+	}
+	margin := 1.0
+	if err := file.SetPageMargins("Sheet1", &excelize.PageLayoutMarginsOptions{Top: &margin}); err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]any{
+		{"Region", "Amount"},
+		{"East", 10},
+		{"West", 40},
+		{"North", 30},
+		{"South", 20},
+		{"East", 50},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := file.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := file.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	file, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { file.Close() })
+	workbook := NewExcelizeExcel(file)
+	worksheet, err := workbook.FindSheet("sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path, workbook, worksheet
+}
+
+// hiddenTestRows returns the hidden rows of the data in the saved workbook
+func hiddenTestRows(t *testing.T, file *excelize.File) []int {
+	t.Helper()
+	rows := []int{}
+	for row := 1; row <= 6; row++ {
+		visible, err := file.GetRowVisible("Sheet1", row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !visible {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// TestExcelizeSetAutoFilter saves an auto filter with criteria Excelize cannot write, and reads it back.
+// It fails when Excelize changes how it writes the worksheet that the autoFilter element is rewritten in.
+func TestExcelizeSetAutoFilter(t *testing.T) {
+	path, workbook, worksheet := newAutoFilterTestBook(t)
+	filter := &AutoFilter{Range: "A1:B6", Columns: []AutoFilterColumn{
+		{Column: "A", Type: AutoFilterEquals, Values: []string{"East", "West", "South"}},
+		{Column: "B", Type: AutoFilterTopN, Count: 3},
+	}}
+	if err := worksheet.SetAutoFilter(filter); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := worksheet.GetAutoFilter(); err != nil || !reflect.DeepEqual(got, filter) {
+		t.Errorf("GetAutoFilter() = %+v, %v, want %+v", got, err, filter)
+	}
+	if err := workbook.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	workbookXML := readTestPackagePart(t, path, "xl/workbook.xml")
+	if want := `<definedName localSheetId="0" name="_xlnm._FilterDatabase" hidden="1">Sheet1!$A$1:$B$6</definedName>`; !strings.Contains(workbookXML, want) {
+		t.Errorf("workbook does not contain %s:\n%s", want, workbookXML)
+	}
+	sheetXML := readTestPackagePart(t, path, "xl/worksheets/sheet1.xml")
+	for _, want := range []string{
+		`<sheetPr filterMode="1"><tabColor rgb="FFFF0000"></tabColor></sheetPr>`,
+		`<autoFilter ref="A1:B6"><filterColumn colId="0"><filters><filter val="East"></filter><filter val="West"></filter><filter val="South"></filter></filters></filterColumn>` +
+			`<filterColumn colId="1"><top10 top="1" val="3" filterVal="30"></top10></filterColumn></autoFilter><pageMargins`,
+	} {
+		if !strings.Contains(sheetXML, want) {
+			t.Errorf("worksheet does not contain %s:\n%s", want, sheetXML)
+		}
+	}
+
+	file, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	// North is not in the values, and East of 10 and South are not in the top 3
+	if got, want := hiddenTestRows(t, file), []int{2, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hidden rows = %v, want %v", got, want)
+	}
+	worksheet, err = NewExcelizeExcel(file).FindSheet("Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := worksheet.GetAutoFilter(); err != nil || !reflect.DeepEqual(got, filter) {
+		t.Errorf("GetAutoFilter() of the saved workbook = %+v, %v, want %+v", got, err, filter)
+	}
+}
+
+// TestExcelizeRemoveAutoFilter removes a saved auto filter, which shows only the rows not matching its criteria
+func TestExcelizeRemoveAutoFilter(t *testing.T) {
+	path, workbook, worksheet := newAutoFilterTestBook(t)
+	if err := worksheet.SetAutoFilter(&AutoFilter{Range: "A1:B6", Columns: []AutoFilterColumn{
+		{Column: "B", Type: AutoFilterCustom, Expression: ">= 30"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	// The row of North matches the criteria and is hidden by hand
+	if err := worksheet.(*ExcelizeWorksheet).file.SetRowVisible("Sheet1", 4, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := workbook.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	workbook = NewExcelizeExcel(file)
+	worksheet, err = workbook.FindSheet("Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := worksheet.RemoveAutoFilter(); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := worksheet.GetAutoFilter(); err != nil || got != nil {
+		t.Errorf("GetAutoFilter() = %+v, %v, want nil", got, err)
+	}
+	if err := workbook.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, part := range []string{"xl/workbook.xml", "xl/worksheets/sheet1.xml"} {
+		data := readTestPackagePart(t, path, part)
+		for _, removed := range []string{"_FilterDatabase", "<autoFilter", "filterMode"} {
+			if strings.Contains(data, removed) {
+				t.Errorf("%s contains %s:\n%s", part, removed, data)
+			}
+		}
+	}
+	file, err = excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if got, want := hiddenTestRows(t, file), []int{4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("hidden rows = %v, want %v", got, want)
+	}
+}
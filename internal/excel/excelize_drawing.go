@@ -0,0 +1,405 @@
+package excel
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Excelize does not provide APIs to enumerate drawing objects, so the functions in this file
+// read the package parts directly. They see the parts as of the last time the file was opened or saved.
+
+type xlsxRelationships struct {
+	Relationships []xlsxRelationship `xml:"Relationship"`
+}
+
+type xlsxRelationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+type xlsxWorkbookSheets struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xdrWsDr struct {
+	TwoCellAnchors []xdrAnchor `xml:"twoCellAnchor"`
+	OneCellAnchors []xdrAnchor `xml:"oneCellAnchor"`
+}
+
+type xdrAnchor struct {
+	From struct {
+		Col int `xml:"col"`
+		Row int `xml:"row"`
+	} `xml:"from"`
+	GraphicFrame *struct {
+		CNvPr xdrCNvPr `xml:"nvGraphicFramePr>cNvPr"`
+		Chart struct {
+			RID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"graphic>graphicData>chart"`
+	} `xml:"graphicFrame"`
+	Pic *struct {
+		CNvPr xdrCNvPr `xml:"nvPicPr>cNvPr"`
+		Blip  struct {
+			Embed string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships embed,attr"`
+		} `xml:"blipFill>blip"`
+	} `xml:"pic"`
+}
+
+type xdrCNvPr struct {
+	Name  string `xml:"name,attr"`
+	Descr string `xml:"descr,attr"`
+}
+
+// drawingObject is a chart or a picture placed in a worksheet
+type drawingObject struct {
+	name       string
+	descr      string
+	anchorCell string
+	// relType is the relationship type of the chart part or the picture media
+	relType string
+	// target is the path of the chart part or the picture media
+	target string
+}
+
+type cChartSpace struct {
+	Chart struct {
+		Title *struct {
+			Runs []string `xml:"tx>rich>p>r>t"`
+			Ref  string   `xml:"tx>strRef>f"`
+		} `xml:"title"`
+		PlotArea struct {
+			Plots []cPlot `xml:",any"`
+		} `xml:"plotArea"`
+	} `xml:"chart"`
+}
+
+type cPlot struct {
+	XMLName xml.Name
+	BarDir  struct {
+		Val string `xml:"val,attr"`
+	} `xml:"barDir"`
+	Series []cSer `xml:"ser"`
+}
+
+type cSer struct {
+	NameRef    string `xml:"tx>strRef>f"`
+	NameValue  string `xml:"tx>v"`
+	CatNumRef  string `xml:"cat>numRef>f"`
+	CatStrRef  string `xml:"cat>strRef>f"`
+	XValNumRef string `xml:"xVal>numRef>f"`
+	XValStrRef string `xml:"xVal>strRef>f"`
+	ValNumRef  string `xml:"val>numRef>f"`
+	YValNumRef string `xml:"yVal>numRef>f"`
+}
+
+const (
+	relationshipTypeDrawing = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
+	relationshipTypeChart   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+	relationshipTypeImage   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
+)
+
+// readExcelizePart unmarshals the package part at the specified path.
+// ok is false if the part does not exist.
+func readExcelizePart(file *excelize.File, partPath string, v any) (bool, error) {
+	content, ok := file.Pkg.Load(partPath)
+	if !ok || content == nil {
+		return false, nil
+	}
+	if err := xml.Unmarshal(content.([]byte), v); err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", partPath, err)
+	}
+	return true, nil
+}
+
+// renameSheetInExcelizeParts rewrites the references to the renamed sheet in the chart series formulas
+// and the pivot table sources, which are not updated by Excelize.
+func renameSheetInExcelizeParts(file *excelize.File, oldName string, newName string) {
+	chartFormula := regexp.MustCompile(`(<(?:c:)?f>)([^<]*)(</(?:c:)?f>)`)
+	pivotCacheSource := regexp.MustCompile(`(<worksheetSource [^>]*sheet=")([^"]*)(")`)
+	escape := func(text string) string {
+		var buf bytes.Buffer
+		_ = xml.EscapeText(&buf, []byte(text))
+		return buf.String()
+	}
+	file.Pkg.Range(func(key, value any) bool {
+		partPath, ok := key.(string)
+		content, isBytes := value.([]byte)
+		if !ok || !isBytes {
+			return true
+		}
+		var replaced []byte
+		switch {
+		case strings.HasPrefix(partPath, "xl/charts/chart"):
+			replaced = chartFormula.ReplaceAllFunc(content, func(match []byte) []byte {
+				groups := chartFormula.FindSubmatch(match)
+				formula := renameSheetInFormula(html.UnescapeString(string(groups[2])), oldName, newName)
+				return []byte(string(groups[1]) + escape(formula) + string(groups[3]))
+			})
+		case strings.HasPrefix(partPath, "xl/pivotCache/pivotCacheDefinition"):
+			replaced = pivotCacheSource.ReplaceAllFunc(content, func(match []byte) []byte {
+				groups := pivotCacheSource.FindSubmatch(match)
+				if !strings.EqualFold(html.UnescapeString(string(groups[2])), oldName) {
+					return match
+				}
+				return []byte(string(groups[1]) + escape(newName) + string(groups[3]))
+			})
+		default:
+			return true
+		}
+		if !bytes.Equal(replaced, content) {
+			file.Pkg.Store(partPath, replaced)
+		}
+		return true
+	})
+}
+
+// readExcelizeCachedPart unmarshals the part structure cached by Excelize, or the package part if it is not cached.
+// Excelize writes the cached structures to the package only when the file is saved.
+func readExcelizeCachedPart(file *excelize.File, partPath string, cached any, v any) (bool, error) {
+	if cached == nil || reflect.ValueOf(cached).IsNil() {
+		return readExcelizePart(file, partPath, v)
+	}
+	content, err := xml.Marshal(cached)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", partPath, err)
+	}
+	if err := xml.Unmarshal(content, v); err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", partPath, err)
+	}
+	return true, nil
+}
+
+// getExcelizeRelationships returns the relationships of the package part with resolved target paths.
+func getExcelizeRelationships(file *excelize.File, partPath string) ([]xlsxRelationship, error) {
+	dir, base := path.Split(partPath)
+	relsPath := path.Join(dir, "_rels", base+".rels")
+	cached, _ := file.Relationships.Load(relsPath)
+	rels := xlsxRelationships{}
+	if _, err := readExcelizeCachedPart(file, relsPath, cached, &rels); err != nil {
+		return nil, err
+	}
+	for i, rel := range rels.Relationships {
+		if strings.HasPrefix(rel.Target, "/") {
+			rels.Relationships[i].Target = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			rels.Relationships[i].Target = path.Join(dir, rel.Target)
+		}
+	}
+	return rels.Relationships, nil
+}
+
+func findRelationship(rels []xlsxRelationship, id string) (xlsxRelationship, bool) {
+	for _, rel := range rels {
+		if rel.ID == id {
+			return rel, true
+		}
+	}
+	return xlsxRelationship{}, false
+}
+
+// getExcelizeSheetPath returns the path of the worksheet part, including the sheets added after the file was opened.
+func getExcelizeSheetPath(file *excelize.File, sheetName string) (string, error) {
+	workbook := xlsxWorkbookSheets{}
+	if _, err := readExcelizeCachedPart(file, "xl/workbook.xml", file.WorkBook, &workbook); err != nil {
+		return "", err
+	}
+	rels, err := getExcelizeRelationships(file, "xl/workbook.xml")
+	if err != nil {
+		return "", err
+	}
+	for _, sheet := range workbook.Sheets {
+		if strings.EqualFold(sheet.Name, sheetName) {
+			if rel, ok := findRelationship(rels, sheet.RID); ok {
+				return rel.Target, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("sheet part not found: %s", sheetName)
+}
+
+// getExcelizeDrawingObjects returns the charts and pictures placed in the worksheet.
+func getExcelizeDrawingObjects(file *excelize.File, sheetName string) ([]drawingObject, error) {
+	sheetPath, err := getExcelizeSheetPath(file, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	sheetRels, err := getExcelizeRelationships(file, sheetPath)
+	if err != nil {
+		return nil, err
+	}
+	objects := []drawingObject{}
+	for _, sheetRel := range sheetRels {
+		if sheetRel.Type != relationshipTypeDrawing {
+			continue
+		}
+		drawing := xdrWsDr{}
+		if _, err := readExcelizePart(file, sheetRel.Target, &drawing); err != nil {
+			return nil, err
+		}
+		drawingRels, err := getExcelizeRelationships(file, sheetRel.Target)
+		if err != nil {
+			return nil, err
+		}
+		for _, anchor := range append(drawing.TwoCellAnchors, drawing.OneCellAnchors...) {
+			anchorCell, err := excelize.CoordinatesToCellName(anchor.From.Col+1, anchor.From.Row+1)
+			if err != nil {
+				return nil, err
+			}
+			var cNvPr xdrCNvPr
+			var rID string
+			switch {
+			case anchor.GraphicFrame != nil:
+				cNvPr, rID = anchor.GraphicFrame.CNvPr, anchor.GraphicFrame.Chart.RID
+			case anchor.Pic != nil:
+				cNvPr, rID = anchor.Pic.CNvPr, anchor.Pic.Blip.Embed
+			default:
+				continue
+			}
+			rel, ok := findRelationship(drawingRels, rID)
+			if !ok {
+				continue
+			}
+			objects = append(objects, drawingObject{
+				name:       cNvPr.Name,
+				descr:      cNvPr.Descr,
+				anchorCell: anchorCell,
+				relType:    rel.Type,
+				target:     rel.Target,
+			})
+		}
+	}
+	return objects, nil
+}
+
+// getExcelizeCharts returns the charts placed in the worksheet.
+func getExcelizeCharts(file *excelize.File, sheetName string) ([]Chart, error) {
+	objects, err := getExcelizeDrawingObjects(file, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	charts := []Chart{}
+	for _, object := range objects {
+		if object.relType != relationshipTypeChart {
+			continue
+		}
+		chartSpace := cChartSpace{}
+		if ok, err := readExcelizePart(file, object.target, &chartSpace); err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+		chart := Chart{
+			Name:       object.name,
+			AnchorCell: object.anchorCell,
+			Series:     []ChartSeries{},
+		}
+		if chartSpace.Chart.Title != nil {
+			chart.Title = strings.Join(chartSpace.Chart.Title.Runs, "")
+			if chart.Title == "" {
+				chart.Title = chartSpace.Chart.Title.Ref
+			}
+		}
+		for _, plot := range chartSpace.Chart.PlotArea.Plots {
+			if !strings.HasSuffix(plot.XMLName.Local, "Chart") {
+				continue
+			}
+			if chart.Type == "" {
+				chart.Type = chartTypeFromPlotName(plot.XMLName.Local, plot.BarDir.Val)
+			}
+			for _, ser := range plot.Series {
+				chart.Series = append(chart.Series, ChartSeries{
+					Name:       firstNonEmpty(ser.NameRef, ser.NameValue),
+					Categories: firstNonEmpty(ser.CatNumRef, ser.CatStrRef, ser.XValNumRef, ser.XValStrRef),
+					Values:     firstNonEmpty(ser.ValNumRef, ser.YValNumRef),
+				})
+			}
+		}
+		charts = append(charts, chart)
+	}
+	return charts, nil
+}
+
+// getExcelizePictures returns the pictures placed in the worksheet.
+func getExcelizePictures(file *excelize.File, sheetName string) ([]Picture, error) {
+	objects, err := getExcelizeDrawingObjects(file, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	pictures := []Picture{}
+	for _, object := range objects {
+		if object.relType != relationshipTypeImage {
+			continue
+		}
+		pictures = append(pictures, Picture{
+			Name:       object.name,
+			AnchorCell: object.anchorCell,
+			AltText:    object.descr,
+		})
+	}
+	return pictures, nil
+}
+
+// getExcelizePictureData returns the media of the picture and its format.
+func getExcelizePictureData(file *excelize.File, sheetName string, name string) ([]byte, string, error) {
+	objects, err := getExcelizeDrawingObjects(file, sheetName)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, object := range objects {
+		if object.relType != relationshipTypeImage || !strings.EqualFold(object.name, name) {
+			continue
+		}
+		content, ok := file.Pkg.Load(object.target)
+		if !ok || content == nil {
+			return nil, "", fmt.Errorf("picture media not found: %s", object.target)
+		}
+		format := strings.ToLower(strings.TrimPrefix(path.Ext(object.target), "."))
+		if format == "jpg" {
+			format = "jpeg"
+		}
+		return content.([]byte), format, nil
+	}
+	return nil, "", fmt.Errorf("picture not found: %s", name)
+}
+
+// chartTypeFromPlotName converts the element name in the plot area to the chart type name
+func chartTypeFromPlotName(plotName string, barDir string) string {
+	switch plotName {
+	case "lineChart", "line3DChart":
+		return "line"
+	case "barChart", "bar3DChart":
+		if barDir == "bar" {
+			return "bar"
+		}
+		return "column"
+	case "pieChart", "pie3DChart", "ofPieChart":
+		return "pie"
+	case "scatterChart":
+		return "scatter"
+	case "areaChart", "area3DChart":
+		return "area"
+	default:
+		return strings.TrimSuffix(plotName, "Chart")
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,342 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/efp"
+	"github.com/xuri/excelize/v2"
+)
+
+// newExcelizeFormulaGraph creates the graph of all formulas in the workbook
+func newExcelizeFormulaGraph(e *ExcelizeExcel) (*formulaGraph, error) {
+	sheets := e.file.GetSheetList()
+	formulas := map[string]map[string]string{}
+	tables := map[string][]Table{}
+	for _, sheetName := range sheets {
+		sheetFormulas, err := getExcelizeFormulas(e.file, sheetName)
+		if err != nil {
+			return nil, err
+		}
+		formulas[sheetName] = sheetFormulas
+		worksheet := &ExcelizeWorksheet{file: e.file, sheetName: sheetName}
+		if tables[sheetName], err = worksheet.GetTables(); err != nil {
+			return nil, err
+		}
+	}
+	definedNames, err := e.GetDefinedNames()
+	if err != nil {
+		return nil, err
+	}
+	return newFormulaGraph(sheets, formulas, definedNames, tables)
+}
+
+// formulaFunctions returns the names of the functions in the formula without duplicates
+func formulaFunctions(formula string) []string {
+	parser := efp.ExcelParser()
+	functions := []string{}
+	for _, token := range parser.Parse(strings.TrimPrefix(formula, "=")) {
+		if token.TType == efp.TokenTypeFunction && token.TSubType == efp.TokenSubTypeStart {
+			// The tokenizer joins the range preceding the function to its name (e.g., A1:INDEX)
+			name := strings.ToUpper(token.TValue[strings.LastIndex(token.TValue, ":")+1:])
+			if !slices.Contains(functions, name) {
+				functions = append(functions, name)
+			}
+		}
+	}
+	return functions
+}
+
+// unsupportedFunctionPattern matches the error messages of Excelize for the functions it does not support
+var unsupportedFunctionPattern = regexp.MustCompile(`^not support (\S+) function$`)
+
+// excelizeFunctionSupport reports whether the calculation engine of Excelize supports the functions.
+// Each function is probed by calculating it alone in a scratch workbook, which fails with the message of
+// unsupported functions regardless of the arguments.
+type excelizeFunctionSupport struct {
+	scratch   *excelize.File
+	supported map[string]bool
+}
+
+func newExcelizeFunctionSupport() *excelizeFunctionSupport {
+	return &excelizeFunctionSupport{scratch: excelize.NewFile(), supported: map[string]bool{}}
+}
+
+func (s *excelizeFunctionSupport) isSupported(function string) bool {
+	if supported, ok := s.supported[function]; ok {
+		return supported
+	}
+	supported := true
+	if err := s.scratch.SetCellFormula("Sheet1", "A1", function+"()"); err == nil {
+		if _, err := s.scratch.CalcCellValue("Sheet1", "A1"); err != nil && unsupportedFunctionPattern.MatchString(err.Error()) {
+			supported = false
+		}
+	}
+	s.supported[function] = supported
+	return supported
+}
+
+func (s *excelizeFunctionSupport) close() {
+	s.scratch.Close()
+}
+
+// excelizeCachedValue is the cached value of a formula cell with the cell type of the file format
+// (empty for numbers, "b" for booleans, "e" for errors and "str" for strings)
+type excelizeCachedValue struct {
+	cellType string
+	value    string
+}
+
+// xmlWorkbook is the sheets of the workbook part of a package
+type xmlWorkbook struct {
+	Sheets []xmlWorkbookSheet `xml:"sheets>sheet"`
+}
+
+type xmlWorkbookSheet struct {
+	Name string `xml:"name,attr"`
+	ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+}
+
+// writeExcelizeCachedValues writes the cached values of the formula cells into the worksheet parts of the package.
+// Excelize has no API for it, because setting a value removes the formula, and setting a formula makes the cached
+// value a string.
+func writeExcelizeCachedValues(content []byte, cachedValues map[formulaCell]excelizeCachedValue) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+	sheetParts, err := worksheetPartNames(reader)
+	if err != nil {
+		return nil, err
+	}
+	sheetNames := map[string]string{}
+	partValues := map[string]map[string]excelizeCachedValue{}
+	for cell, value := range cachedValues {
+		part, ok := sheetParts[cell.sheet]
+		if !ok {
+			return nil, fmt.Errorf("worksheet not found: %s", cell.sheet)
+		}
+		if partValues[part] == nil {
+			sheetNames[part] = cell.sheet
+			partValues[part] = map[string]excelizeCachedValue{}
+		}
+		partValues[part][cell.cell] = value
+	}
+	return rewritePackage(reader, func(name string, data []byte) ([]byte, error) {
+		if values, ok := partValues[name]; ok {
+			return rewriteCachedValues(data, sheetNames[name], values)
+		}
+		return data, nil
+	})
+}
+
+// worksheetPartNames returns the names of the worksheet parts of the package keyed by the sheet names
+func worksheetPartNames(reader *zip.Reader) (map[string]string, error) {
+	workbookPart, err := workbookPartName(reader)
+	if err != nil {
+		return nil, err
+	}
+	var workbook xmlWorkbook
+	if err := unmarshalPackagePart(reader, workbookPart, &workbook); err != nil {
+		return nil, err
+	}
+	var workbookRelationships xmlRelationships
+	if err := unmarshalPackagePart(reader, path.Join(path.Dir(workbookPart), "_rels", path.Base(workbookPart)+".rels"), &workbookRelationships); err != nil {
+		return nil, err
+	}
+	targets := map[string]string{}
+	for _, relationship := range workbookRelationships.Relationships {
+		targets[relationship.ID] = resolvePartName(path.Dir(workbookPart), relationship.Target)
+	}
+	parts := map[string]string{}
+	for _, sheet := range workbook.Sheets {
+		parts[sheet.Name] = targets[sheet.ID]
+	}
+	return parts, nil
+}
+
+// workbookPartName returns the name of the workbook part of the package
+func workbookPartName(reader *zip.Reader) (string, error) {
+	var rootRelationships xmlRelationships
+	if err := unmarshalPackagePart(reader, "_rels/.rels", &rootRelationships); err != nil {
+		return "", err
+	}
+	workbookPart := ""
+	for _, relationship := range rootRelationships.Relationships {
+		if strings.HasSuffix(relationship.Type, "/officeDocument") {
+			workbookPart = resolvePartName("", relationship.Target)
+		}
+	}
+	return workbookPart, nil
+}
+
+// resolvePartName resolves the target of a relationship from the directory of its source part
+func resolvePartName(directory string, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(directory, target)
+}
+
+func unmarshalPackagePart(reader *zip.Reader, name string, v any) error {
+	index := slices.IndexFunc(reader.File, func(file *zip.File) bool { return file.Name == name })
+	if index < 0 {
+		return fmt.Errorf("part not found: %s", name)
+	}
+	data, err := readPackagePart(reader.File[index])
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(data, v)
+}
+
+// rewriteCachedValues rewrites the types and the values of the cells in the worksheet part, keeping their formulas.
+// Only the cell elements are rewritten, and the rest of the part is copied as is.
+func rewriteCachedValues(data []byte, sheetName string, values map[string]excelizeCachedValue) ([]byte, error) {
+	var result bytes.Buffer
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	copied := int64(0) // offset up to which the data is copied to the result
+	rewritten := map[string]bool{}
+	var cell *xml.Name // name of the cell element being rewritten
+	cellValue := ""
+	depth := 0 // depth of the elements in the cell
+	for {
+		start := decoder.InputOffset()
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		end := decoder.InputOffset()
+		switch token := token.(type) {
+		case xml.StartElement:
+			if cell != nil {
+				// The value and the inline string of the cell are replaced
+				if depth == 0 && (token.Name.Local == "v" || token.Name.Local == "is") {
+					result.Write(data[copied:start])
+					copied = -1
+				}
+				depth++
+				continue
+			}
+			reference := ""
+			for _, attr := range token.Attr {
+				if attr.Name.Space == "" && attr.Name.Local == "r" {
+					reference = attr.Value
+				}
+			}
+			value, ok := values[reference]
+			if token.Name.Local != "c" || !ok {
+				continue
+			}
+			result.Write(data[copied:start])
+			writeCellStartTag(&result, token, value.cellType)
+			copied = end
+			rewritten[reference] = true
+			if bytes.HasSuffix(data[start:end], []byte("/>")) {
+				writeCellValue(&result, token.Name, value.value)
+				fmt.Fprintf(&result, "</%s>", qualifiedXMLName(token.Name))
+				// Skip the end element the decoder returns for the self-closing element
+				if _, err := decoder.RawToken(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			cell, cellValue, depth = &token.Name, value.value, 0
+		case xml.EndElement:
+			if cell == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				if depth == 0 && copied < 0 {
+					copied = end
+				}
+				continue
+			}
+			result.Write(data[copied:start])
+			writeCellValue(&result, *cell, cellValue)
+			copied = start
+			cell = nil
+		}
+	}
+	result.Write(data[copied:])
+	for reference := range values {
+		if !rewritten[reference] {
+			return nil, fmt.Errorf("cell not found: %s", formulaCell{sheetName, reference})
+		}
+	}
+	return result.Bytes(), nil
+}
+
+// writeCellStartTag writes the start tag of the cell element with the cell type, keeping the other attributes
+func writeCellStartTag(w *bytes.Buffer, element xml.StartElement, cellType string) {
+	setXMLAttr(&element, "t", cellType)
+	writeStartTag(w, element, false)
+}
+
+// setXMLAttr sets the attribute without namespace of the element, or removes it if the value is empty
+func setXMLAttr(element *xml.StartElement, name string, value string) {
+	element.Attr = slices.DeleteFunc(slices.Clone(element.Attr), func(attr xml.Attr) bool {
+		return attr.Name.Space == "" && attr.Name.Local == name
+	})
+	if value != "" {
+		element.Attr = append(element.Attr, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+}
+
+// writeStartTag writes the start tag of the element in the namespace prefixes of the raw token
+func writeStartTag(w *bytes.Buffer, element xml.StartElement, selfClosing bool) {
+	w.WriteString("<" + qualifiedXMLName(element.Name))
+	for _, attr := range element.Attr {
+		w.WriteString(" " + qualifiedXMLName(attr.Name) + `="`)
+		xml.EscapeText(w, []byte(attr.Value))
+		w.WriteString(`"`)
+	}
+	if selfClosing {
+		w.WriteString("/>")
+	} else {
+		w.WriteString(">")
+	}
+}
+
+// writeCellValue writes the value element of the cell in the namespace prefix of the cell
+func writeCellValue(w *bytes.Buffer, cellName xml.Name, value string) {
+	name := qualifiedXMLName(xml.Name{Space: cellName.Space, Local: "v"})
+	w.WriteString("<" + name + ">")
+	xml.EscapeText(w, []byte(value))
+	w.WriteString("</" + name + ">")
+}
+
+// qualifiedXMLName returns the name with the namespace prefix of the raw token
+func qualifiedXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// cachedValue returns the cell type and the cached value of the raw result of the calculation.
+// The calculation engine returns booleans as TRUE or FALSE.
+func cachedValue(result string) (string, string) {
+	if result == "TRUE" || result == "FALSE" {
+		if result == "TRUE" {
+			return "b", "1"
+		}
+		return "b", "0"
+	}
+	if _, err := strconv.ParseFloat(result, 64); err == nil {
+		return "", result
+	}
+	return "str", result
+}
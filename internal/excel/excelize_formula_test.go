@@ -0,0 +1,113 @@
+package excel
+
+import (
+	"archive/zip"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// TestExcelizeRecalculateWritesCachedValues saves recalculated values into a workbook written by Excelize.
+// It fails when Excelize changes how it writes the formula cells that the cached values are rewritten in.
+func TestExcelizeRecalculateWritesCachedValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Book.xlsx")
+	file := excelize.NewFile()
+	sheet := "Bob's Sheet"
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		t.Fatal(err)
+	}
+	for cell, value := range map[string]any{"A1": 10, "A2": 0, "A3": "x", "B5": "stale"} {
+		if err := file.SetCellValue(sheet, cell, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	formulas := map[string]string{
+		"B1": "A1*2",
+		"B2": "A1>5",
+		"B3": `A3&"<&>"`,
+		"B4": "A1/A2",
+		"B5": "A1+0.5",
+	}
+	for cell, formula := range formulas {
+		if err := file.SetCellFormula(sheet, cell, formula); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sharedType, sharedRef := excelize.STCellFormulaTypeShared, "C1:C2"
+	if err := file.SetCellFormula(sheet, "C1", "A1+1", excelize.FormulaOpts{Type: &sharedType, Ref: &sharedRef}); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	file, err := excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workbook := NewExcelizeExcel(file)
+	result, err := workbook.Recalculate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Calculated != 7 {
+		t.Errorf("Calculated = %d, want 7", result.Calculated)
+	}
+	if err := workbook.Save(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	sheetXML := readTestPackagePart(t, path, "xl/worksheets/sheet1.xml")
+	for _, want := range []string{
+		`<c r="B1"><f>A1*2</f><v>20</v></c>`,
+		`<c r="B2" t="b"><f>A1&gt;5</f><v>1</v></c>`,
+		`<c r="B3" t="str"><f>A3&amp;&#34;&lt;&amp;&gt;&#34;</f><v>x&lt;&amp;&gt;</v></c>`,
+		`<c r="B4" t="e"><f>A1/A2</f><v>#DIV/0!</v></c>`,
+		`<c r="B5"><f>A1+0.5</f><v>10.5</v></c>`,
+		`<c r="C1"><f t="shared" ref="C1:C2" si="0">A1+1</f><v>11</v></c>`,
+		`<c r="C2"><f t="shared" si="0"></f><v>1</v></c>`,
+	} {
+		if !strings.Contains(sheetXML, want) {
+			t.Errorf("worksheet does not contain %s:\n%s", want, sheetXML)
+		}
+	}
+
+	file, err = excelize.OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	for cell, formula := range formulas {
+		got, err := file.GetCellFormula(sheet, cell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != formula {
+			t.Errorf("formula of %s = %q, want %q", cell, got, formula)
+		}
+	}
+}
+
+func readTestPackagePart(t *testing.T, path string, name string) string {
+	t.Helper()
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	part, err := reader.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer part.Close()
+	data, err := io.ReadAll(part)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,401 @@
+package excel
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// formulaCell is a cell in the workbook, which is usually a cell having a formula
+type formulaCell struct {
+	sheet string
+	cell  string
+}
+
+// String returns the cell qualified with the sheet name (e.g., 'My Sheet'!A1)
+func (c formulaCell) String() string {
+	return QuoteSheetName(c.sheet) + "!" + c.cell
+}
+
+// positionedCell is a formula cell with its coordinates
+type positionedCell struct {
+	formulaCell
+	col int
+	row int
+}
+
+// cellArea is a rectangular area of cells in a sheet
+type cellArea struct {
+	sheet    string
+	startCol int
+	startRow int
+	endCol   int
+	endRow   int
+}
+
+func (a cellArea) contains(sheet string, col int, row int) bool {
+	return strings.EqualFold(a.sheet, sheet) && a.startCol <= col && col <= a.endCol && a.startRow <= row && row <= a.endRow
+}
+
+// maxDefinedNameDepth limits the depth of the defined names referring to other defined names
+const maxDefinedNameDepth = 16
+
+// formulaGraph is the graph of the references between the formulas in the workbook
+type formulaGraph struct {
+	sheets       []string
+	formulas     map[formulaCell]string
+	cells        map[string][]positionedCell // formula cells in each sheet in row-major order
+	definedNames []DefinedName
+	tables       map[string]cellArea           // keyed by the upper case table name
+	areas        map[formulaCell][]cellArea    // areas each formula refers to
+	precedents   map[formulaCell][]formulaCell // formula cells each formula refers to
+}
+
+// newFormulaGraph creates the graph of the formulas keyed by the sheet and cell names.
+// The defined names and the tables in each sheet are used to resolve the references to them.
+func newFormulaGraph(sheets []string, formulas map[string]map[string]string, definedNames []DefinedName, tables map[string][]Table) (*formulaGraph, error) {
+	graph := &formulaGraph{
+		sheets:       sheets,
+		formulas:     map[formulaCell]string{},
+		cells:        map[string][]positionedCell{},
+		definedNames: definedNames,
+		tables:       map[string]cellArea{},
+		areas:        map[formulaCell][]cellArea{},
+		precedents:   map[formulaCell][]formulaCell{},
+	}
+	for _, sheetName := range sheets {
+		cellNames := []string{}
+		for cell, formula := range formulas[sheetName] {
+			graph.formulas[formulaCell{sheetName, cell}] = formula
+			cellNames = append(cellNames, cell)
+		}
+		sortCellNames(cellNames)
+		for _, cell := range cellNames {
+			col, row, err := excelize.CellNameToCoordinates(cell)
+			if err != nil {
+				return nil, err
+			}
+			graph.cells[sheetName] = append(graph.cells[sheetName], positionedCell{formulaCell{sheetName, cell}, col, row})
+		}
+		for _, table := range tables[sheetName] {
+			startCol, startRow, endCol, endRow, err := ParseRange(table.Range)
+			if err != nil {
+				return nil, err
+			}
+			graph.tables[strings.ToUpper(table.Name)] = cellArea{sheetName, startCol, startRow, endCol, endRow}
+		}
+	}
+	for _, sheetName := range sheets {
+		for _, cell := range graph.cells[sheetName] {
+			areas := []cellArea{}
+			for _, reference := range ParseFormulaReferences(graph.formulas[cell.formulaCell]) {
+				areas = append(areas, graph.resolveReference(cell.formulaCell, reference, 0)...)
+			}
+			graph.areas[cell.formulaCell] = areas
+			graph.precedents[cell.formulaCell] = graph.findFormulaCells(areas)
+		}
+	}
+	return graph, nil
+}
+
+// findCell finds the cell in the graph, whose sheet name may differ in case
+func (g *formulaGraph) findCell(sheetName string, cell string) formulaCell {
+	for _, name := range g.sheets {
+		if strings.EqualFold(name, sheetName) {
+			sheetName = name
+		}
+	}
+	return formulaCell{sheetName, strings.ToUpper(strings.ReplaceAll(cell, "$", ""))}
+}
+
+// resolveReference resolves the reference in the formula of the cell into the areas of cells.
+// Defined names are resolved into the areas they refer to, and structured references into the whole tables.
+func (g *formulaGraph) resolveReference(cell formulaCell, reference FormulaReference, depth int) []cellArea {
+	switch reference.Kind {
+	case FormulaReferenceCell, FormulaReferenceRange:
+		parse := parseReferenceRange
+		if reference.Kind == FormulaReferenceCell {
+			parse = ParseCellOrRange
+		}
+		startCol, startRow, endCol, endRow, err := parse(reference.Target)
+		if err != nil {
+			return nil
+		}
+		areas := []cellArea{}
+		for _, sheetName := range g.referencedSheets(cell.sheet, reference.Sheets) {
+			areas = append(areas, cellArea{sheetName, startCol, startRow, endCol, endRow})
+		}
+		return areas
+	case FormulaReferenceTable:
+		if table, ok := g.findTable(cell, reference); ok {
+			return []cellArea{table}
+		}
+	case FormulaReferenceName:
+		definedName, ok := g.findDefinedName(cell.sheet, reference)
+		if !ok || depth >= maxDefinedNameDepth {
+			return nil
+		}
+		areas := []cellArea{}
+		for _, nameReference := range ParseFormulaReferences(definedName.RefersTo) {
+			areas = append(areas, g.resolveReference(cell, nameReference, depth+1)...)
+		}
+		return areas
+	}
+	return nil
+}
+
+// referencedSheets returns the sheets of the reference, which are all sheets between the two for 3D references
+func (g *formulaGraph) referencedSheets(sheetName string, sheets []string) []string {
+	if len(sheets) == 0 {
+		return []string{sheetName}
+	}
+	indexOf := func(name string) int {
+		return slices.IndexFunc(g.sheets, func(sheet string) bool { return strings.EqualFold(sheet, name) })
+	}
+	start := indexOf(sheets[0])
+	end := start
+	if len(sheets) == 2 {
+		end = indexOf(sheets[1])
+	}
+	if start < 0 || end < 0 {
+		return nil
+	}
+	return g.sheets[min(start, end) : max(start, end)+1]
+}
+
+// findTable finds the table of the structured reference. The references without the table name refer to the table
+// containing the cell.
+func (g *formulaGraph) findTable(cell formulaCell, reference FormulaReference) (cellArea, bool) {
+	if reference.Target != "" {
+		table, ok := g.tables[strings.ToUpper(reference.Target)]
+		return table, ok
+	}
+	col, row, err := excelize.CellNameToCoordinates(cell.cell)
+	if err != nil {
+		return cellArea{}, false
+	}
+	for _, table := range g.tables {
+		if table.contains(cell.sheet, col, row) {
+			return table, true
+		}
+	}
+	return cellArea{}, false
+}
+
+// findDefinedName finds the defined name of the reference. Unqualified names are looked up in the scope of the sheet
+// first, and then in the workbook scope.
+func (g *formulaGraph) findDefinedName(sheetName string, reference FormulaReference) (DefinedName, bool) {
+	scopes := []string{sheetName, ""}
+	if len(reference.Sheets) == 1 {
+		scopes = []string{reference.Sheets[0]}
+	}
+	for _, scope := range scopes {
+		for _, definedName := range g.definedNames {
+			if strings.EqualFold(definedName.Name, reference.Target) && strings.EqualFold(definedName.Scope, scope) {
+				return definedName, true
+			}
+		}
+	}
+	return DefinedName{}, false
+}
+
+// findFormulaCells returns the formula cells in the areas without duplicates
+func (g *formulaGraph) findFormulaCells(areas []cellArea) []formulaCell {
+	found := []formulaCell{}
+	seen := map[formulaCell]bool{}
+	for _, area := range areas {
+		for _, cell := range g.cells[area.sheet] {
+			if area.contains(cell.sheet, cell.col, cell.row) && !seen[cell.formulaCell] {
+				seen[cell.formulaCell] = true
+				found = append(found, cell.formulaCell)
+			}
+		}
+	}
+	return found
+}
+
+// findDependents returns the formula cells referring to the cell directly
+func (g *formulaGraph) findDependents(cell formulaCell) []formulaCell {
+	dependents := []formulaCell{}
+	col, row, err := excelize.CellNameToCoordinates(cell.cell)
+	if err != nil {
+		return dependents
+	}
+	for _, sheetName := range g.sheets {
+		for _, dependent := range g.cells[sheetName] {
+			if slices.ContainsFunc(g.areas[dependent.formulaCell], func(area cellArea) bool { return area.contains(cell.sheet, col, row) }) {
+				dependents = append(dependents, dependent.formulaCell)
+			}
+		}
+	}
+	return dependents
+}
+
+// sortFormulas sorts the formula cells so that each formula comes after the formulas it refers to.
+// The cells in circular references and the cells referring to them cannot be sorted and are returned separately.
+func (g *formulaGraph) sortFormulas() ([]formulaCell, []formulaCell) {
+	pending := map[formulaCell]int{}
+	dependents := map[formulaCell][]formulaCell{}
+	queue := []formulaCell{}
+	for _, sheetName := range g.sheets {
+		for _, cell := range g.cells[sheetName] {
+			precedents := g.precedents[cell.formulaCell]
+			pending[cell.formulaCell] = len(precedents)
+			for _, precedent := range precedents {
+				dependents[precedent] = append(dependents[precedent], cell.formulaCell)
+			}
+			if len(precedents) == 0 {
+				queue = append(queue, cell.formulaCell)
+			}
+		}
+	}
+	sorted := []formulaCell{}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, cell)
+		for _, dependent := range dependents[cell] {
+			if pending[dependent]--; pending[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	circular := []formulaCell{}
+	for _, sheetName := range g.sheets {
+		for _, cell := range g.cells[sheetName] {
+			if pending[cell.formulaCell] > 0 {
+				circular = append(circular, cell.formulaCell)
+			}
+		}
+	}
+	return sorted, circular
+}
+
+// formulaTracer builds the trees of the precedents and dependents in the graph
+type formulaTracer struct {
+	graph      *formulaGraph
+	depth      int
+	value      func(cell formulaCell) string
+	dependents map[formulaCell][]formulaCell
+}
+
+// trace traces the precedents and dependents of the cell up to the depth.
+// The value function returns the value of the cell displayed in the trees.
+func (g *formulaGraph) trace(cell formulaCell, depth int, value func(cell formulaCell) string) *FormulaTrace {
+	tracer := &formulaTracer{graph: g, depth: depth, value: value, dependents: map[formulaCell][]formulaCell{}}
+	trace := &FormulaTrace{Cell: cell.String(), Value: value(cell), Precedents: []*FormulaTraceNode{}}
+	if formula, ok := g.formulas[cell]; ok {
+		trace.Formula = "=" + strings.TrimPrefix(formula, "=")
+		trace.Precedents = tracer.precedentNodes(cell, formula, 1, []formulaCell{cell})
+	}
+	trace.Dependents = tracer.dependentNodes(cell, 1, []formulaCell{cell})
+	return trace
+}
+
+// expand adds the children to the node at the level, or marks the node as truncated at the depth
+func (t *formulaTracer) expand(node *FormulaTraceNode, level int, hasChildren bool, children func() []*FormulaTraceNode) {
+	if !hasChildren {
+		return
+	}
+	if level >= t.depth {
+		node.Truncated = true
+		return
+	}
+	node.Children = children()
+}
+
+// precedentNodes returns the nodes of the references in the formula of the cell at the level.
+// The path has the cells from the root to detect circular references.
+func (t *formulaTracer) precedentNodes(cell formulaCell, formula string, level int, path []formulaCell) []*FormulaTraceNode {
+	nodes := []*FormulaTraceNode{}
+	for _, reference := range ParseFormulaReferences(formula) {
+		nodes = append(nodes, t.referenceNode(cell, reference, level, path))
+	}
+	return nodes
+}
+
+// referenceNode returns the node of the reference in the formula of the cell. References to single cells are the
+// nodes of the cells, and the other references have the formula cells in their ranges as the children.
+func (t *formulaTracer) referenceNode(cell formulaCell, reference FormulaReference, level int, path []formulaCell) *FormulaTraceNode {
+	areas := t.graph.resolveReference(cell, reference, 0)
+	if reference.Kind == FormulaReferenceCell && len(areas) == 1 {
+		referenced, _ := excelize.CoordinatesToCellName(areas[0].startCol, areas[0].startRow)
+		return t.cellNode(formulaCell{areas[0].sheet, referenced}, level, path)
+	}
+	node := &FormulaTraceNode{Kind: reference.Kind, Reference: reference.Text}
+	if node.Kind == FormulaReferenceCell {
+		// 3D references to the cells in several sheets (e.g., Sheet1:Sheet3!A1)
+		node.Kind = FormulaReferenceRange
+	}
+	if (reference.Kind == FormulaReferenceCell || reference.Kind == FormulaReferenceRange) && len(reference.Sheets) == 0 {
+		node.Reference = qualifyReference([]string{cell.sheet}, reference.Text)
+	}
+	if reference.Kind == FormulaReferenceName {
+		if definedName, ok := t.graph.findDefinedName(cell.sheet, reference); ok {
+			node.Formula = "=" + definedName.RefersTo
+			t.expand(node, level, len(ParseFormulaReferences(definedName.RefersTo)) > 0, func() []*FormulaTraceNode {
+				return t.precedentNodes(cell, definedName.RefersTo, level+1, path)
+			})
+		}
+		return node
+	}
+	cells := t.graph.findFormulaCells(areas)
+	t.expand(node, level, len(cells) > 0, func() []*FormulaTraceNode {
+		nodes := []*FormulaTraceNode{}
+		for _, precedent := range cells {
+			nodes = append(nodes, t.cellNode(precedent, level+1, path))
+		}
+		return nodes
+	})
+	return node
+}
+
+// cellNode returns the node of the cell having the references in its formula as the children
+func (t *formulaTracer) cellNode(cell formulaCell, level int, path []formulaCell) *FormulaTraceNode {
+	node := &FormulaTraceNode{Kind: FormulaReferenceCell, Reference: cell.String(), Value: t.value(cell)}
+	formula, ok := t.graph.formulas[cell]
+	if !ok {
+		return node
+	}
+	node.Formula = "=" + strings.TrimPrefix(formula, "=")
+	if slices.Contains(path, cell) {
+		node.Circular = true
+		return node
+	}
+	t.expand(node, level, len(ParseFormulaReferences(formula)) > 0, func() []*FormulaTraceNode {
+		return t.precedentNodes(cell, formula, level+1, append(slices.Clone(path), cell))
+	})
+	return node
+}
+
+// dependentNodes returns the nodes of the formula cells referring to the cell at the level
+func (t *formulaTracer) dependentNodes(cell formulaCell, level int, path []formulaCell) []*FormulaTraceNode {
+	nodes := []*FormulaTraceNode{}
+	for _, dependent := range t.findDependents(cell) {
+		node := &FormulaTraceNode{
+			Kind:      FormulaReferenceCell,
+			Reference: dependent.String(),
+			Formula:   "=" + strings.TrimPrefix(t.graph.formulas[dependent], "="),
+			Value:     t.value(dependent),
+		}
+		if slices.Contains(path, dependent) {
+			node.Circular = true
+		} else {
+			t.expand(node, level, len(t.findDependents(dependent)) > 0, func() []*FormulaTraceNode {
+				return t.dependentNodes(dependent, level+1, append(slices.Clone(path), dependent))
+			})
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (t *formulaTracer) findDependents(cell formulaCell) []formulaCell {
+	if dependents, ok := t.dependents[cell]; ok {
+		return dependents
+	}
+	dependents := t.graph.findDependents(cell)
+	t.dependents[cell] = dependents
+	return dependents
+}
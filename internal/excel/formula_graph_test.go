@@ -0,0 +1,208 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestFormulaGraph(t *testing.T) *formulaGraph {
+	t.Helper()
+	graph, err := newFormulaGraph(
+		[]string{"Sheet1", "Bob's", "Sheet3"},
+		map[string]map[string]string{
+			"Sheet1": {
+				"B1": "A1*2",
+				"B2": "$B$1+1",
+				"B3": "SUM(B1:B2)",
+				"C1": "SUM(B:B)",
+				"C2": "SUM(3:3)",
+				"D1": "'Bob''s'!A1+1",
+			},
+			"Bob's": {
+				"A1": "Sheet1!$B$1*3",
+				"A2": "Rate",
+				"A3": "SUM(T1[Amount])",
+				"A4": "SUM(Sheet1:Sheet3!B1)",
+				"A5": "Local",
+				"A6": "Missing+Nope!A1+XFE1",
+			},
+			"Sheet3": {
+				"B1": "Local",
+				"A2": "A3",
+				"A3": "A2",
+			},
+		},
+		[]DefinedName{
+			{Name: "Rate", RefersTo: "Sheet1!$B$2"},
+			{Name: "Local", RefersTo: "Sheet1!$B$3"},
+			{Name: "Local", RefersTo: "Sheet3!$A$1", Scope: "Sheet3"},
+		},
+		map[string][]Table{"Sheet1": {{Name: "T1", Range: "B1:B3"}}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return graph
+}
+
+func TestFormulaGraphPrecedents(t *testing.T) {
+	graph := newTestFormulaGraph(t)
+	tests := []struct {
+		name string
+		cell formulaCell
+		want []formulaCell
+	}{
+		{"reference to value", formulaCell{"Sheet1", "B1"}, []formulaCell{}},
+		{"absolute cell", formulaCell{"Sheet1", "B2"}, []formulaCell{{"Sheet1", "B1"}}},
+		{"relative range", formulaCell{"Sheet1", "B3"}, []formulaCell{{"Sheet1", "B1"}, {"Sheet1", "B2"}}},
+		{"whole column", formulaCell{"Sheet1", "C1"}, []formulaCell{{"Sheet1", "B1"}, {"Sheet1", "B2"}, {"Sheet1", "B3"}}},
+		{"whole row", formulaCell{"Sheet1", "C2"}, []formulaCell{{"Sheet1", "B3"}}},
+		{"quoted sheet name", formulaCell{"Sheet1", "D1"}, []formulaCell{{"Bob's", "A1"}}},
+		{"qualified absolute cell", formulaCell{"Bob's", "A1"}, []formulaCell{{"Sheet1", "B1"}}},
+		{"defined name", formulaCell{"Bob's", "A2"}, []formulaCell{{"Sheet1", "B2"}}},
+		{"structured reference", formulaCell{"Bob's", "A3"}, []formulaCell{{"Sheet1", "B1"}, {"Sheet1", "B2"}, {"Sheet1", "B3"}}},
+		{"3D reference", formulaCell{"Bob's", "A4"}, []formulaCell{{"Sheet1", "B1"}, {"Sheet3", "B1"}}},
+		{"workbook scoped name", formulaCell{"Bob's", "A5"}, []formulaCell{{"Sheet1", "B3"}}},
+		{"unknown name, sheet and cell", formulaCell{"Bob's", "A6"}, []formulaCell{}},
+		{"sheet scoped name", formulaCell{"Sheet3", "B1"}, []formulaCell{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := graph.precedents[tt.cell]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("precedents of %s = %v, want %v", tt.cell, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormulaGraphFindDependents(t *testing.T) {
+	graph := newTestFormulaGraph(t)
+	got := graph.findDependents(graph.findCell("SHEET1", "$b$1"))
+	want := []formulaCell{
+		{"Sheet1", "C1"}, {"Sheet1", "B2"}, {"Sheet1", "B3"},
+		{"Bob's", "A1"}, {"Bob's", "A3"}, {"Bob's", "A4"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findDependents(Sheet1!B1) = %v, want %v", got, want)
+	}
+	if got := graph.findDependents(formulaCell{"Sheet1", "not a cell"}); len(got) != 0 {
+		t.Errorf("findDependents(invalid cell) = %v, want none", got)
+	}
+}
+
+func TestFormulaGraphSortFormulas(t *testing.T) {
+	graph := newTestFormulaGraph(t)
+	sorted, circular := graph.sortFormulas()
+	if want := []formulaCell{{"Sheet3", "A2"}, {"Sheet3", "A3"}}; !reflect.DeepEqual(circular, want) {
+		t.Errorf("circular = %v, want %v", circular, want)
+	}
+	if len(sorted)+len(circular) != len(graph.formulas) {
+		t.Errorf("sorted %d and circular %d formulas, want %d in total", len(sorted), len(circular), len(graph.formulas))
+	}
+	position := map[formulaCell]int{}
+	for i, cell := range sorted {
+		position[cell] = i
+	}
+	for _, cell := range sorted {
+		for _, precedent := range graph.precedents[cell] {
+			if position[precedent] >= position[cell] {
+				t.Errorf("%s is sorted before its precedent %s", cell, precedent)
+			}
+		}
+	}
+}
+
+func TestFormulaGraphTrace(t *testing.T) {
+	graph, err := newFormulaGraph(
+		[]string{"Sheet1"},
+		map[string]map[string]string{"Sheet1": {
+			"B1": "A1*2",
+			"C1": "SUM(B1,Rate)",
+			"D1": "C1+1",
+			"E1": "E2",
+			"E2": "E1",
+		}},
+		[]DefinedName{{Name: "Rate", RefersTo: "Sheet1!$A$1:$A$2"}},
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value := func(cell formulaCell) string { return "value of " + cell.cell }
+	cellNode := func(reference string, formula string, children ...*FormulaTraceNode) *FormulaTraceNode {
+		return &FormulaTraceNode{Kind: FormulaReferenceCell, Reference: "Sheet1!" + reference, Formula: formula, Value: "value of " + reference, Children: children}
+	}
+	tests := []struct {
+		name  string
+		cell  formulaCell
+		depth int
+		want  *FormulaTrace
+	}{
+		{"cell and name precedents", formulaCell{"Sheet1", "C1"}, 2, &FormulaTrace{
+			Cell: "Sheet1!C1", Formula: "=SUM(B1,Rate)", Value: "value of C1",
+			Precedents: []*FormulaTraceNode{
+				cellNode("B1", "=A1*2", cellNode("A1", "")),
+				{Kind: FormulaReferenceName, Reference: "Rate", Formula: "=Sheet1!$A$1:$A$2", Children: []*FormulaTraceNode{
+					{Kind: FormulaReferenceRange, Reference: "Sheet1!$A$1:$A$2"},
+				}},
+			},
+			Dependents: []*FormulaTraceNode{cellNode("D1", "=C1+1")},
+		}},
+		{"truncated at depth", formulaCell{"Sheet1", "C1"}, 1, &FormulaTrace{
+			Cell: "Sheet1!C1", Formula: "=SUM(B1,Rate)", Value: "value of C1",
+			Precedents: []*FormulaTraceNode{
+				{Kind: FormulaReferenceCell, Reference: "Sheet1!B1", Formula: "=A1*2", Value: "value of B1", Truncated: true},
+				{Kind: FormulaReferenceName, Reference: "Rate", Formula: "=Sheet1!$A$1:$A$2", Truncated: true},
+			},
+			Dependents: []*FormulaTraceNode{cellNode("D1", "=C1+1")},
+		}},
+		{"cell without formula referred by name", formulaCell{"Sheet1", "A1"}, 1, &FormulaTrace{
+			Cell: "Sheet1!A1", Value: "value of A1",
+			Precedents: []*FormulaTraceNode{},
+			Dependents: []*FormulaTraceNode{
+				{Kind: FormulaReferenceCell, Reference: "Sheet1!B1", Formula: "=A1*2", Value: "value of B1", Truncated: true},
+				{Kind: FormulaReferenceCell, Reference: "Sheet1!C1", Formula: "=SUM(B1,Rate)", Value: "value of C1", Truncated: true},
+			},
+		}},
+		{"circular reference", formulaCell{"Sheet1", "E1"}, 3, &FormulaTrace{
+			Cell: "Sheet1!E1", Formula: "=E2", Value: "value of E1",
+			Precedents: []*FormulaTraceNode{
+				cellNode("E2", "=E1", &FormulaTraceNode{Kind: FormulaReferenceCell, Reference: "Sheet1!E1", Formula: "=E2", Value: "value of E1", Circular: true}),
+			},
+			Dependents: []*FormulaTraceNode{
+				cellNode("E2", "=E1", &FormulaTraceNode{Kind: FormulaReferenceCell, Reference: "Sheet1!E1", Formula: "=E2", Value: "value of E1", Circular: true}),
+			},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := graph.trace(tt.cell, tt.depth, value)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("trace(%s, %d) = %s, want %s", tt.cell, tt.depth, formatTestTrace(got), formatTestTrace(tt.want))
+			}
+		})
+	}
+}
+
+// formatTestTrace formats the trace with the nodes expanded for the failure messages
+func formatTestTrace(trace *FormulaTrace) string {
+	var format func(nodes []*FormulaTraceNode) string
+	format = func(nodes []*FormulaTraceNode) string {
+		result := "["
+		for _, node := range nodes {
+			result += "{" + string(node.Kind) + " " + node.Reference + " " + node.Formula + " " + node.Value
+			if node.Circular {
+				result += " circular"
+			}
+			if node.Truncated {
+				result += " truncated"
+			}
+			if node.Children != nil {
+				result += " " + format(node.Children)
+			}
+			result += "}"
+		}
+		return result + "]"
+	}
+	return trace.Cell + " " + trace.Formula + " " + trace.Value + " precedents " + format(trace.Precedents) + " dependents " + format(trace.Dependents)
+}
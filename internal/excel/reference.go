@@ -0,0 +1,161 @@
+package excel
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/efp"
+	"github.com/xuri/excelize/v2"
+)
+
+// FormulaReferenceKind is the kind of a reference in a formula
+type FormulaReferenceKind string
+
+const (
+	FormulaReferenceCell     FormulaReferenceKind = "cell"     // e.g., A1, Sheet2!$B$3
+	FormulaReferenceRange    FormulaReferenceKind = "range"    // e.g., A1:B2, A:A, 1:3
+	FormulaReferenceName     FormulaReferenceKind = "name"     // a defined name
+	FormulaReferenceTable    FormulaReferenceKind = "table"    // a structured reference (e.g., Table1[Amount])
+	FormulaReferenceExternal FormulaReferenceKind = "external" // a reference to another workbook (e.g., [1]Sheet1!A1)
+)
+
+// FormulaReference is a reference in a formula
+type FormulaReference struct {
+	Kind FormulaReferenceKind
+	// Text is the reference as written in the formula, with the sheet names quoted if necessary
+	Text string
+	// Sheets are the sheets qualifying the reference, which are two for 3D references (e.g., Sheet1:Sheet3!A1)
+	// and none for the references to the sheet of the formula
+	Sheets []string
+	// Target is the cell or range without $ (e.g., A1, A1:B2, A:A, 1:3), or the name of the defined name or table.
+	// It is empty for the structured references without the table name and the external references.
+	Target string
+}
+
+var (
+	referenceCellPattern    = regexp.MustCompile(`^\$?[A-Za-z]{1,3}\$?[0-9]+$`)
+	referenceColumnsPattern = regexp.MustCompile(`^\$?([A-Za-z]{1,3}):\$?([A-Za-z]{1,3})$`)
+	referenceRowsPattern    = regexp.MustCompile(`^\$?([0-9]+):\$?([0-9]+)$`)
+	referenceNamePattern    = regexp.MustCompile(`^[\p{L}_\\][\p{L}\p{N}_.\\?]*$`)
+)
+
+// ParseFormulaReferences returns the references in the formula in the order of appearance.
+// Text in string literals, functions and error values are not references.
+func ParseFormulaReferences(formula string) []FormulaReference {
+	parser := efp.ExcelParser()
+	references := []FormulaReference{}
+	// The tokenizer splits the structured references having several items at the commas
+	// (e.g., Table1[[#This Row],[Amount]]), so the tokens are joined until the brackets are balanced.
+	structured := ""
+	for _, token := range parser.Parse(strings.TrimPrefix(formula, "=")) {
+		text := token.TValue
+		if structured != "" {
+			structured += text
+			if !isBracketBalanced(structured) {
+				continue
+			}
+			text, structured = structured, ""
+		} else if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		} else if !isBracketBalanced(text) {
+			structured = text
+			continue
+		}
+		if reference, ok := parseFormulaReference(text); ok {
+			references = append(references, reference)
+		}
+	}
+	return references
+}
+
+func isBracketBalanced(text string) bool {
+	return strings.Count(text, "[") == strings.Count(text, "]")
+}
+
+// parseFormulaReference parses the range operand of a formula. The tokenizer removes the quotes of the sheet names,
+// which are restored in the text of the reference.
+func parseFormulaReference(text string) (FormulaReference, bool) {
+	if strings.HasPrefix(text, "[") {
+		if index := strings.Index(text, "]"); index >= 0 && strings.Contains(text[index:], "!") {
+			return FormulaReference{Kind: FormulaReferenceExternal, Text: text}, true
+		}
+		// A structured reference without the table name refers to the table of the formula (e.g., [@Amount])
+		return FormulaReference{Kind: FormulaReferenceTable, Text: text}, true
+	}
+	reference := FormulaReference{Text: text}
+	target := text
+	if index := strings.Index(text, "!"); index >= 0 && !strings.Contains(text[:index], "[") {
+		reference.Sheets = strings.SplitN(text[:index], ":", 2)
+		target = text[index+1:]
+		reference.Text = qualifyReference(reference.Sheets, target)
+	}
+	name := target
+	if start, end, ok := strings.Cut(target, ":"); ok {
+		// The end of the range may be qualified with the same sheet (e.g., Sheet1!A1:Sheet1!B2)
+		if index := strings.Index(end, "!"); index >= 0 {
+			end = end[index+1:]
+		}
+		target = start + ":" + end
+	}
+	target = strings.ToUpper(strings.ReplaceAll(target, "$", ""))
+	switch {
+	case referenceCellPattern.MatchString(target):
+		if _, _, err := excelize.CellNameToCoordinates(target); err != nil {
+			return reference, false
+		}
+		reference.Kind, reference.Target = FormulaReferenceCell, target
+	case strings.Contains(target, ":"):
+		if _, _, _, _, err := parseReferenceRange(target); err != nil {
+			return reference, false
+		}
+		reference.Kind, reference.Target = FormulaReferenceRange, target
+	case strings.Contains(name, "["):
+		reference.Kind, reference.Target = FormulaReferenceTable, name[:strings.Index(name, "[")]
+	case referenceNamePattern.MatchString(name):
+		reference.Kind, reference.Target = FormulaReferenceName, name
+	default:
+		return reference, false
+	}
+	return reference, true
+}
+
+// parseReferenceRange parses the range of a reference, including the whole columns (e.g., A:C) and rows (e.g., 1:3)
+func parseReferenceRange(target string) (int, int, int, int, error) {
+	if match := referenceColumnsPattern.FindStringSubmatch(target); match != nil {
+		startCol, err := excelize.ColumnNameToNumber(match[1])
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		endCol, err := excelize.ColumnNameToNumber(match[2])
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+		return min(startCol, endCol), 1, max(startCol, endCol), excelize.TotalRows, nil
+	}
+	if match := referenceRowsPattern.FindStringSubmatch(target); match != nil {
+		startRow, _ := strconv.Atoi(match[1])
+		endRow, _ := strconv.Atoi(match[2])
+		if min(startRow, endRow) < 1 || max(startRow, endRow) > excelize.TotalRows {
+			return 0, 0, 0, 0, excelize.ErrMaxRows
+		}
+		return 1, min(startRow, endRow), excelize.MaxColumns, max(startRow, endRow), nil
+	}
+	startCol, startRow, endCol, endRow, err := ParseRange(target)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return min(startCol, endCol), min(startRow, endRow), max(startCol, endCol), max(startRow, endRow), nil
+}
+
+// qualifyReference qualifies the reference with the sheets, quoting the sheet names if necessary
+// (e.g., 'My Sheet'!A1, Sheet1:Sheet3!A1, 'Sheet 1:Sheet 3'!A1)
+func qualifyReference(sheets []string, reference string) string {
+	if len(sheets) == 1 {
+		return QuoteSheetName(sheets[0]) + "!" + reference
+	}
+	if QuoteSheetName(sheets[0]) == sheets[0] && QuoteSheetName(sheets[1]) == sheets[1] {
+		return sheets[0] + ":" + sheets[1] + "!" + reference
+	}
+	return "'" + strings.ReplaceAll(sheets[0]+":"+sheets[1], "'", "''") + "'!" + reference
+}
@@ -0,0 +1,117 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestParseFormulaReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula string
+		want    []FormulaReference
+	}{
+		{"relative cell", "=A1*2", []FormulaReference{
+			{Kind: FormulaReferenceCell, Text: "A1", Target: "A1"},
+		}},
+		{"absolute and mixed cells", "=$B$3+B$4+$c5", []FormulaReference{
+			{Kind: FormulaReferenceCell, Text: "$B$3", Target: "B3"},
+			{Kind: FormulaReferenceCell, Text: "B$4", Target: "B4"},
+			{Kind: FormulaReferenceCell, Text: "$c5", Target: "C5"},
+		}},
+		{"qualified cell", "=Sheet2!$B$3", []FormulaReference{
+			{Kind: FormulaReferenceCell, Text: "Sheet2!$B$3", Sheets: []string{"Sheet2"}, Target: "B3"},
+		}},
+		{"quoted sheet name", "=SUM('My Sheet'!A1:B2)", []FormulaReference{
+			{Kind: FormulaReferenceRange, Text: "'My Sheet'!A1:B2", Sheets: []string{"My Sheet"}, Target: "A1:B2"},
+		}},
+		{"quoted sheet name with apostrophe", "='Bob''s'!A1", []FormulaReference{
+			{Kind: FormulaReferenceCell, Text: "'Bob''s'!A1", Sheets: []string{"Bob's"}, Target: "A1"},
+		}},
+		{"range qualified at both ends", "=SUM(Sheet1!A1:Sheet1!B2)", []FormulaReference{
+			{Kind: FormulaReferenceRange, Text: "Sheet1!A1:Sheet1!B2", Sheets: []string{"Sheet1"}, Target: "A1:B2"},
+		}},
+		{"whole columns and rows", "=SUM(A:C)+SUM($1:$3)", []FormulaReference{
+			{Kind: FormulaReferenceRange, Text: "A:C", Target: "A:C"},
+			{Kind: FormulaReferenceRange, Text: "$1:$3", Target: "1:3"},
+		}},
+		{"3D reference", "=SUM(Sheet1:Sheet3!A1)", []FormulaReference{
+			{Kind: FormulaReferenceCell, Text: "Sheet1:Sheet3!A1", Sheets: []string{"Sheet1", "Sheet3"}, Target: "A1"},
+		}},
+		{"defined name", "=Rate*2", []FormulaReference{
+			{Kind: FormulaReferenceName, Text: "Rate", Target: "Rate"},
+		}},
+		{"structured references", "=SUM(Table1[Amount])+Table1[[#This Row],[Amount]]", []FormulaReference{
+			{Kind: FormulaReferenceTable, Text: "Table1[Amount]", Target: "Table1"},
+			{Kind: FormulaReferenceTable, Text: "Table1[[#This Row],[Amount]]", Target: "Table1"},
+		}},
+		{"structured reference without table", "=[@Amount]*2", []FormulaReference{
+			{Kind: FormulaReferenceTable, Text: "[@Amount]"},
+		}},
+		{"external reference", "=[1]Sheet1!A1", []FormulaReference{
+			{Kind: FormulaReferenceExternal, Text: "[1]Sheet1!A1"},
+		}},
+		{"string literals, functions and errors", `=IF(ISERROR(#REF!),"A1",NOW())`, []FormulaReference{}},
+		{"out of range cells", "=XFE1+A0+A1048577", []FormulaReference{}},
+		{"out of range rows", "=SUM(1:1048577)", []FormulaReference{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseFormulaReferences(tt.formula)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFormulaReferences(%q) = %+v, want %+v", tt.formula, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReferenceRange(t *testing.T) {
+	tests := []struct {
+		target  string
+		want    [4]int // start column, start row, end column, end row
+		wantErr bool
+	}{
+		{"A1:B2", [4]int{1, 1, 2, 2}, false},
+		{"B2:A1", [4]int{1, 1, 2, 2}, false},
+		{"A:C", [4]int{1, 1, 3, excelize.TotalRows}, false},
+		{"C:A", [4]int{1, 1, 3, excelize.TotalRows}, false},
+		{"1:3", [4]int{1, 1, excelize.MaxColumns, 3}, false},
+		{"0:3", [4]int{}, true},
+		{"1:1048577", [4]int{}, true},
+		{"A:XFE", [4]int{}, true},
+		{"A1:B", [4]int{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			startCol, startRow, endCol, endRow, err := parseReferenceRange(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseReferenceRange(%q) error = %v, want error %v", tt.target, err, tt.wantErr)
+			}
+			if got := [4]int{startCol, startRow, endCol, endRow}; got != tt.want {
+				t.Errorf("parseReferenceRange(%q) = %v, want %v", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQualifyReference(t *testing.T) {
+	tests := []struct {
+		sheets []string
+		want   string
+	}{
+		{[]string{"Sheet1"}, "Sheet1!A1"},
+		{[]string{"My Sheet"}, "'My Sheet'!A1"},
+		{[]string{"Bob's"}, "'Bob''s'!A1"},
+		{[]string{"A1"}, "'A1'!A1"},
+		{[]string{"Sheet1", "Sheet3"}, "Sheet1:Sheet3!A1"},
+		{[]string{"Sheet 1", "Sheet 3"}, "'Sheet 1:Sheet 3'!A1"},
+		{[]string{"Bob's", "Sheet3"}, "'Bob''s:Sheet3'!A1"},
+	}
+	for _, tt := range tests {
+		if got := qualifyReference(tt.sheets, "A1"); got != tt.want {
+			t.Errorf("qualifyReference(%q, A1) = %q, want %q", tt.sheets, got, tt.want)
+		}
+	}
+}
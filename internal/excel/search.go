@@ -0,0 +1,209 @@
+package excel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SearchMode is how the query of a search is interpreted
+type SearchMode string
+
+const (
+	SearchLiteral SearchMode = "literal"
+	SearchRegex   SearchMode = "regex"
+	SearchNumeric SearchMode = "numeric"
+)
+
+// SearchTarget is what a search looks in
+type SearchTarget string
+
+const (
+	SearchValues   SearchTarget = "values"
+	SearchFormulas SearchTarget = "formulas"
+	SearchBoth     SearchTarget = "both"
+)
+
+// SearchOptions specifies the cells to search for in a worksheet
+type SearchOptions struct {
+	// Query is a text, a regular expression, or a numeric comparison (e.g., ">= 100", "= 5", "> 0 and < 1") depending on Mode
+	Query  string
+	Mode   SearchMode
+	Target SearchTarget // numeric searches always look in values
+	Range  string       // range to search in, or empty to search the whole sheet
+	// MatchCase and WholeCell apply to literal and regex searches
+	MatchCase bool
+	WholeCell bool
+}
+
+// SearchMatch is a cell found by a search
+type SearchMatch struct {
+	Cell    string
+	Value   string // formatted value
+	Formula string // formula with the leading "=", or empty if the cell has no formula
+}
+
+// searchPattern returns the regular expression of the literal or regex search
+func searchPattern(options *SearchOptions) (*regexp.Regexp, error) {
+	expression := options.Query
+	if options.Mode == SearchLiteral {
+		expression = regexp.QuoteMeta(expression)
+	}
+	if options.WholeCell {
+		expression = "^(?:" + expression + ")$"
+	}
+	if !options.MatchCase {
+		expression = "(?i)" + expression
+	}
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression: %w", err)
+	}
+	return pattern, nil
+}
+
+// Validate reports whether the query is a valid regular expression or numeric comparison for the mode
+func (o *SearchOptions) Validate() error {
+	if o.Mode == SearchNumeric {
+		_, err := numericSearchMatcher(o.Query)
+		return err
+	}
+	_, err := searchPattern(o)
+	return err
+}
+
+// numericSearchMatcher returns the function reporting whether the raw value of a cell matches the numeric comparison.
+// A number without an operator matches the equal values.
+func numericSearchMatcher(query string) (func(raw string) bool, error) {
+	if _, err := strconv.ParseFloat(query, 64); err == nil {
+		query = "=" + query
+	}
+	conditions, and, err := parseFilterExpression(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid numeric comparison: %s", query)
+	}
+	for _, condition := range conditions {
+		if _, err := strconv.ParseFloat(condition.Value, 64); err != nil {
+			return nil, fmt.Errorf("invalid number in numeric comparison: %s", condition.Value)
+		}
+	}
+	return func(raw string) bool {
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return false
+		}
+		matched := matchFilterCondition(conditions[0], raw, raw)
+		switch {
+		case len(conditions) == 1:
+			return matched
+		case and:
+			return matched && matchFilterCondition(conditions[1], raw, raw)
+		default:
+			return matched || matchFilterCondition(conditions[1], raw, raw)
+		}
+	}, nil
+}
+
+// cellInRange reports whether the cell is in the range. Any cell is in an empty range.
+func cellInRange(cell string, cellRange string) bool {
+	if cellRange == "" {
+		return true
+	}
+	return rangesOverlap(cell, cellRange)
+}
+
+// ReplaceOptions specifies the texts to replace in a worksheet
+type ReplaceOptions struct {
+	SearchOptions        // Mode is literal or regex
+	Replacement   string // replacement text, which can refer to submatches as $1 in regex mode
+	DryRun        bool   // report the changes without modifying the cells
+}
+
+// Validate reports whether the options can be used for replacement
+func (o *ReplaceOptions) Validate() error {
+	if o.Mode == SearchNumeric {
+		return fmt.Errorf("numeric search cannot be used for replacement")
+	}
+	return o.SearchOptions.Validate()
+}
+
+// CellReplacement is a change of a cell made by a replacement
+type CellReplacement struct {
+	Cell    string
+	Before  string
+	After   string
+	Formula bool // the formula of the cell is changed
+}
+
+// ReplaceCells replaces the texts matching the options in the values and formulas of the worksheet.
+// Formula cells matched only by their values are left unchanged because their values are calculated.
+// Only string values are replaced, because replacing the formatted text of numbers, dates and booleans would turn
+// them into strings.
+func ReplaceCells(worksheet Worksheet, options *ReplaceOptions) ([]CellReplacement, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	pattern, err := searchPattern(&options.SearchOptions)
+	if err != nil {
+		return nil, err
+	}
+	replace := func(text string) string {
+		if options.Mode == SearchRegex {
+			return pattern.ReplaceAllString(text, options.Replacement)
+		}
+		return pattern.ReplaceAllLiteralString(text, options.Replacement)
+	}
+	matches, err := worksheet.Search(&options.SearchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	replacements := []CellReplacement{}
+	for _, match := range matches {
+		replacement := CellReplacement{Cell: match.Cell}
+		if match.Formula != "" {
+			if options.Target == SearchValues || !pattern.MatchString(match.Formula) {
+				continue
+			}
+			replacement.Before, replacement.Formula = match.Formula, true
+		} else {
+			typedValue, err := worksheet.GetTypedValue(match.Cell)
+			if err != nil {
+				return nil, err
+			}
+			if typedValue.Type != CellValueString || !pattern.MatchString(typedValue.Raw) {
+				continue
+			}
+			replacement.Before = typedValue.Raw
+		}
+		replacement.After = replace(replacement.Before)
+		if replacement.After == replacement.Before {
+			continue
+		}
+		replacements = append(replacements, replacement)
+		if options.DryRun {
+			continue
+		}
+		switch {
+		case !replacement.Formula:
+			err = worksheet.SetValue(match.Cell, replacement.After)
+		case strings.HasPrefix(replacement.After, "="):
+			err = worksheet.SetFormula(match.Cell, replacement.After)
+		default:
+			err = worksheet.SetValue(match.Cell, replacedFormulaValue(replacement.After))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return replacements, nil
+}
+
+// replacedFormulaValue returns the value to write for a formula replaced by a text without the leading "=".
+// Numbers are written as numbers, while texts such as "00123" are kept as texts.
+func replacedFormulaValue(after string) any {
+	if number, err := strconv.ParseFloat(after, 64); err == nil && strconv.FormatFloat(number, 'f', -1, 64) == after {
+		return number
+	}
+	return after
+}
@@ -0,0 +1,85 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestReplaceCellsKeepsNumbersAndDates(t *testing.T) {
+	file := excelize.NewFile()
+	defer file.Close()
+	dateFormat := "yyyy-mm-dd"
+	dateStyle, _ := file.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	numberStyle, _ := file.NewStyle(&excelize.Style{NumFmt: 4})  // #,##0.00
+	percentStyle, _ := file.NewStyle(&excelize.Style{NumFmt: 9}) // 0%
+	cells := []struct {
+		cell  string
+		value any
+		style int
+	}{
+		{"A1", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), dateStyle},
+		{"A2", 1234, numberStyle},
+		{"A3", 0.5, percentStyle},
+		{"A4", "Report 2024", 0},
+		{"A5", "1,234.00", 0},
+		{"A6", "50%", 0},
+	}
+	for _, c := range cells {
+		if err := file.SetCellValue("Sheet1", c.cell, c.value); err != nil {
+			t.Fatal(err)
+		}
+		if c.style != 0 {
+			if err := file.SetCellStyle("Sheet1", c.cell, c.cell, c.style); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	workbook := NewExcelizeExcel(file)
+	worksheet, err := workbook.FindSheet("Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		find        string
+		replacement string
+		want        []CellReplacement
+	}{
+		{"2024", "2025", []CellReplacement{{Cell: "A4", Before: "Report 2024", After: "Report 2025"}}},
+		{"1,234", "1,000", []CellReplacement{{Cell: "A5", Before: "1,234.00", After: "1,000.00"}}},
+		{"50%", "75%", []CellReplacement{{Cell: "A6", Before: "50%", After: "75%"}}},
+	}
+	for _, tt := range tests {
+		options := &ReplaceOptions{
+			SearchOptions: SearchOptions{Query: tt.find, Mode: SearchLiteral, Target: SearchValues},
+			Replacement:   tt.replacement,
+		}
+		got, err := ReplaceCells(worksheet, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ReplaceCells(%q -> %q) = %+v, want %+v", tt.find, tt.replacement, got, tt.want)
+		}
+	}
+
+	for i, want := range []TypedValue{
+		{Type: CellValueDate, Raw: "45382", Formatted: "2024-03-31"},
+		{Type: CellValueNumber, Raw: "1234", Formatted: "1,234.00"},
+		{Type: CellValueNumber, Raw: "0.5", Formatted: "50%"},
+		{Type: CellValueString, Raw: "Report 2025", Formatted: "Report 2025"},
+		{Type: CellValueString, Raw: "1,000.00", Formatted: "1,000.00"},
+		{Type: CellValueString, Raw: "75%", Formatted: "75%"},
+	} {
+		got, err := worksheet.GetTypedValue(cells[i].cell)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *got != want {
+			t.Errorf("value of %s = %+v, want %+v", cells[i].cell, *got, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package excel
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ShiftResult reports the references adjusted by inserting or deleting rows and columns.
+type ShiftResult struct {
+	Tables       []ShiftedReference
+	DefinedNames []ShiftedReference
+	Formulas     []ShiftedReference
+}
+
+// ShiftedReference describes a reference before and after the shift.
+// After is empty if the reference has been removed.
+type ShiftedReference struct {
+	Name   string
+	Before string
+	After  string
+}
+
+// cellShift describes rows or columns to be inserted or deleted.
+type cellShift struct {
+	rows   bool // true if rows are shifted, false if columns are shifted
+	start  int  // first row or column number of the shifted area
+	offset int  // positive for insertion, negative for deletion
+}
+
+// moveCell returns the coordinates of the cell after the shift.
+// ok is false if the cell has been deleted.
+func (s cellShift) moveCell(col, row int) (int, int, bool) {
+	index := col
+	if s.rows {
+		index = row
+	}
+	if index >= s.start {
+		if s.offset < 0 && index < s.start-s.offset {
+			return 0, 0, false
+		}
+		index += s.offset
+	}
+	if s.rows {
+		return col, index, true
+	}
+	return index, row, true
+}
+
+// moveRange returns the range after the shift. ok is false if the whole range has been deleted.
+func (s cellShift) moveRange(startCol, startRow, endCol, endRow int) (int, int, int, int, bool) {
+	start, end := startCol, endCol
+	if s.rows {
+		start, end = startRow, endRow
+	}
+	if s.offset > 0 {
+		if start >= s.start {
+			start += s.offset
+		}
+		if end >= s.start {
+			end += s.offset
+		}
+	} else {
+		deleteEnd := s.start - s.offset - 1
+		if start >= s.start && end <= deleteEnd {
+			return 0, 0, 0, 0, false
+		}
+		if start > deleteEnd {
+			start += s.offset
+		} else if start > s.start {
+			start = s.start
+		}
+		if end > deleteEnd {
+			end += s.offset
+		} else if end >= s.start {
+			end = s.start - 1
+		}
+	}
+	if s.rows {
+		return startCol, start, endCol, end, true
+	}
+	return start, startRow, end, endRow, true
+}
+
+// shiftSnapshot holds the references which might be adjusted by a shift.
+type shiftSnapshot struct {
+	tables       map[string]string            // table name -> range
+	definedNames map[string]string            // defined name -> refers to
+	formulas     map[string]map[string]string // sheet name -> cell -> formula
+}
+
+func newShiftSnapshot() *shiftSnapshot {
+	return &shiftSnapshot{
+		tables:       make(map[string]string),
+		definedNames: make(map[string]string),
+		formulas:     make(map[string]map[string]string),
+	}
+}
+
+// diff compares snapshots taken before and after shifting cells of the specified sheet.
+func (s cellShift) diff(sheetName string, before, after *shiftSnapshot) *ShiftResult {
+	result := &ShiftResult{
+		Tables:       diffReferences(before.tables, after.tables),
+		DefinedNames: diffReferences(before.definedNames, after.definedNames),
+		Formulas:     []ShiftedReference{},
+	}
+	for sheet, formulas := range before.formulas {
+		for cell, formula := range formulas {
+			movedCell := cell
+			if sheet == sheetName {
+				col, row, err := excelize.CellNameToCoordinates(cell)
+				if err != nil {
+					continue
+				}
+				col, row, ok := s.moveCell(col, row)
+				if !ok {
+					continue
+				}
+				movedCell, _ = excelize.CoordinatesToCellName(col, row)
+			}
+			if movedFormula := after.formulas[sheet][movedCell]; movedFormula != "" && movedFormula != formula {
+				result.Formulas = append(result.Formulas, ShiftedReference{
+					Name:   fmt.Sprintf("%s!%s", sheet, movedCell),
+					Before: formula,
+					After:  movedFormula,
+				})
+			}
+		}
+	}
+	sortShiftedReferences(result.Formulas)
+	return result
+}
+
+func diffReferences(before, after map[string]string) []ShiftedReference {
+	references := []ShiftedReference{}
+	for name, ref := range before {
+		if after[name] != ref {
+			references = append(references, ShiftedReference{
+				Name:   name,
+				Before: ref,
+				After:  after[name],
+			})
+		}
+	}
+	sortShiftedReferences(references)
+	return references
+}
+
+func sortShiftedReferences(references []ShiftedReference) {
+	sort.Slice(references, func(i, j int) bool {
+		return references[i].Name < references[j].Name
+	})
+}
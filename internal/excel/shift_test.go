@@ -0,0 +1,101 @@
+package excel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCellShiftMoveCell(t *testing.T) {
+	tests := []struct {
+		name          string
+		shift         cellShift
+		col, row      int
+		wantCol       int
+		wantRow       int
+		wantRemaining bool
+	}{
+		{"row above inserted rows", cellShift{rows: true, start: 3, offset: 2}, 1, 2, 1, 2, true},
+		{"row at inserted rows", cellShift{rows: true, start: 3, offset: 2}, 1, 3, 1, 5, true},
+		{"first deleted row", cellShift{rows: true, start: 3, offset: -2}, 1, 3, 0, 0, false},
+		{"last deleted row", cellShift{rows: true, start: 3, offset: -2}, 1, 4, 0, 0, false},
+		{"row below deleted rows", cellShift{rows: true, start: 3, offset: -2}, 1, 5, 1, 3, true},
+		{"column at inserted columns", cellShift{rows: false, start: 2, offset: 1}, 2, 7, 3, 7, true},
+		{"deleted column", cellShift{rows: false, start: 2, offset: -1}, 2, 1, 0, 0, false},
+		{"column right of deleted column", cellShift{rows: false, start: 2, offset: -1}, 3, 1, 2, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col, row, ok := tt.shift.moveCell(tt.col, tt.row)
+			if col != tt.wantCol || row != tt.wantRow || ok != tt.wantRemaining {
+				t.Errorf("moveCell(%d, %d) = (%d, %d, %v), want (%d, %d, %v)", tt.col, tt.row, col, row, ok, tt.wantCol, tt.wantRow, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestCellShiftMoveRange(t *testing.T) {
+	deleteRows := cellShift{rows: true, start: 3, offset: -2} // deletes rows 3:4
+	tests := []struct {
+		name          string
+		shift         cellShift
+		rng           [4]int // start column, start row, end column, end row
+		want          [4]int
+		wantRemaining bool
+	}{
+		{"range above deleted rows", deleteRows, [4]int{1, 1, 2, 2}, [4]int{1, 1, 2, 2}, true},
+		{"range of deleted rows", deleteRows, [4]int{1, 3, 2, 4}, [4]int{}, false},
+		{"range over deleted rows", deleteRows, [4]int{1, 2, 2, 6}, [4]int{1, 2, 2, 4}, true},
+		{"range from last deleted row", deleteRows, [4]int{1, 4, 2, 8}, [4]int{1, 3, 2, 6}, true},
+		{"range below deleted rows", deleteRows, [4]int{1, 5, 2, 6}, [4]int{1, 3, 2, 4}, true},
+		{"range before inserted columns", cellShift{rows: false, start: 2, offset: 3}, [4]int{1, 1, 2, 5}, [4]int{1, 1, 5, 5}, true},
+		{"range after inserted columns", cellShift{rows: false, start: 2, offset: 3}, [4]int{3, 1, 4, 5}, [4]int{6, 1, 7, 5}, true},
+		{"whole rows of deleted columns", cellShift{rows: false, start: 1, offset: -16384}, [4]int{1, 1, 16384, 3}, [4]int{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startCol, startRow, endCol, endRow, ok := tt.shift.moveRange(tt.rng[0], tt.rng[1], tt.rng[2], tt.rng[3])
+			got := [4]int{startCol, startRow, endCol, endRow}
+			if got != tt.want || ok != tt.wantRemaining {
+				t.Errorf("moveRange(%v) = (%v, %v), want (%v, %v)", tt.rng, got, ok, tt.want, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestCellShiftDiff(t *testing.T) {
+	before := newShiftSnapshot()
+	before.tables["T1"] = "A1:B5"
+	before.definedNames["Rng"] = "Sheet1!$A$1"
+	before.definedNames["Gone"] = "Sheet1!$A$3"
+	before.formulas["Sheet1"] = map[string]string{
+		"A3": "A1",           // deleted
+		"A5": "SUM(A1:A4)",   // moved to A4
+		"A6": "A1",           // moved but unchanged
+		"XX": "SUM(A1:A4)",   // invalid cell
+		"B1": "'Other'!A1*2", // refers to the other sheet only
+	}
+	before.formulas["Other"] = map[string]string{"B1": "Sheet1!A4"}
+
+	after := newShiftSnapshot()
+	after.tables["T1"] = "A1:B4"
+	after.definedNames["Rng"] = "Sheet1!$A$1"
+	after.formulas["Sheet1"] = map[string]string{
+		"A4": "SUM(A1:A3)",
+		"A5": "A1",
+		"B1": "'Other'!A1*2",
+	}
+	after.formulas["Other"] = map[string]string{"B1": "Sheet1!A3"}
+
+	got := cellShift{rows: true, start: 3, offset: -1}.diff("Sheet1", before, after)
+	want := &ShiftResult{
+		Tables:       []ShiftedReference{{Name: "T1", Before: "A1:B5", After: "A1:B4"}},
+		DefinedNames: []ShiftedReference{{Name: "Gone", Before: "Sheet1!$A$3", After: ""}},
+		Formulas: []ShiftedReference{
+			{Name: "Other!B1", Before: "Sheet1!A4", After: "Sheet1!A3"},
+			{Name: "Sheet1!A4", Before: "SUM(A1:A4)", After: "SUM(A1:A3)"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diff() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package excel
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// SortOptions specifies how to sort the rows of a range
+type SortOptions struct {
+	Range         string // range to sort including the header row if HasHeader is true (e.g., "A1:D10")
+	Keys          []SortKey
+	HasHeader     bool // the first row of the range is a header row, which is not sorted
+	CaseSensitive bool // lowercase letters are sorted before uppercase letters
+	Natural       bool // numbers in text are compared by their values (e.g., "item2" < "item10")
+}
+
+// SortKey is a column to sort by
+type SortKey struct {
+	Column     string // column name in the sheet (e.g., "B")
+	Descending bool
+}
+
+// sortValueKind is the kind of a cell value in the order Excel sorts them ascending
+type sortValueKind int
+
+const (
+	sortValueNumber sortValueKind = iota
+	sortValueText
+	sortValueBool
+	sortValueError
+	sortValueBlank
+)
+
+// sortValue is a cell value compared by sorting
+type sortValue struct {
+	kind   sortValueKind
+	number float64
+	text   string
+}
+
+// compareSortValues compares the values as Excel sorts them.
+// Numbers come before text, text before booleans and booleans before errors, in reverse for descending order.
+// Blank cells always come last.
+func compareSortValues(a sortValue, b sortValue, key SortKey, options *SortOptions) int {
+	switch {
+	case a.kind == sortValueBlank && b.kind == sortValueBlank:
+		return 0
+	case a.kind == sortValueBlank:
+		return 1
+	case b.kind == sortValueBlank:
+		return -1
+	}
+	result := int(a.kind) - int(b.kind)
+	if result == 0 {
+		switch a.kind {
+		case sortValueNumber:
+			switch {
+			case a.number < b.number:
+				result = -1
+			case a.number > b.number:
+				result = 1
+			}
+		case sortValueText:
+			result = compareSortText(a.text, b.text, options.CaseSensitive, options.Natural)
+		case sortValueBool:
+			result = strings.Compare(a.text, b.text)
+		}
+	}
+	if key.Descending {
+		return -result
+	}
+	return result
+}
+
+// compareSortText compares the texts case-insensitively, breaking ties by lowercase first if caseSensitive is true
+func compareSortText(a string, b string, caseSensitive bool, natural bool) int {
+	result := 0
+	if natural {
+		result = compareNaturalText(strings.ToLower(a), strings.ToLower(b))
+	} else {
+		result = strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	if result != 0 || !caseSensitive {
+		return result
+	}
+	aRunes, bRunes := []rune(a), []rune(b)
+	for i := 0; i < len(aRunes) && i < len(bRunes); i++ {
+		if aRunes[i] == bRunes[i] {
+			continue
+		}
+		if unicode.IsLower(aRunes[i]) {
+			return -1
+		}
+		return 1
+	}
+	return len(aRunes) - len(bRunes)
+}
+
+// compareNaturalText compares the texts comparing runs of digits by their numeric values
+func compareNaturalText(a string, b string) int {
+	aRunes, bRunes := []rune(a), []rune(b)
+	i, j := 0, 0
+	for i < len(aRunes) && j < len(bRunes) {
+		if unicode.IsDigit(aRunes[i]) && unicode.IsDigit(bRunes[j]) {
+			aStart, bStart := i, j
+			for i < len(aRunes) && unicode.IsDigit(aRunes[i]) {
+				i++
+			}
+			for j < len(bRunes) && unicode.IsDigit(bRunes[j]) {
+				j++
+			}
+			aDigits := strings.TrimLeft(string(aRunes[aStart:i]), "0")
+			bDigits := strings.TrimLeft(string(bRunes[bStart:j]), "0")
+			if len(aDigits) != len(bDigits) {
+				return len(aDigits) - len(bDigits)
+			}
+			if result := strings.Compare(aDigits, bDigits); result != 0 {
+				return result
+			}
+			continue
+		}
+		if aRunes[i] != bRunes[j] {
+			return int(aRunes[i]) - int(bRunes[j])
+		}
+		i++
+		j++
+	}
+	return (len(aRunes) - i) - (len(bRunes) - j)
+}
+
+// parseSortValue converts the raw value of a cell into a sort value
+func parseSortValue(raw string, cellType excelize.CellType) sortValue {
+	if raw == "" {
+		return sortValue{kind: sortValueBlank}
+	}
+	switch cellType {
+	case excelize.CellTypeBool:
+		return sortValue{kind: sortValueBool, text: raw}
+	case excelize.CellTypeError:
+		return sortValue{kind: sortValueError, text: raw}
+	case excelize.CellTypeUnset, excelize.CellTypeNumber:
+		if number, err := strconv.ParseFloat(raw, 64); err == nil {
+			return sortValue{kind: sortValueNumber, number: number}
+		}
+	}
+	return sortValue{kind: sortValueText, text: raw}
+}
@@ -0,0 +1,117 @@
+package excel
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCompareSortText(t *testing.T) {
+	tests := []struct {
+		a, b          string
+		caseSensitive bool
+		natural       bool
+		want          int // sign of the result
+	}{
+		{"apple", "Banana", false, false, -1},
+		{"Apple", "apple", false, false, 0},
+		{"apple", "Apple", true, false, -1},
+		{"Apple", "apple", true, false, 1},
+		{"aB", "Ab", true, false, -1},
+		{"apple", "apples", true, false, -1},
+		{"item10", "item2", false, false, -1},
+		{"item10", "item2", false, true, 1},
+		{"Item2", "item10", false, true, -1},
+		{"item02", "item2", false, true, 0},
+		{"item2", "Item2", true, true, -1},
+		{"v1.10", "v1.9", false, true, 1},
+		{"a1b", "a1", false, true, 1},
+		{"x9", "x10a", false, true, -1},
+	}
+	for _, tt := range tests {
+		if got := sign(compareSortText(tt.a, tt.b, tt.caseSensitive, tt.natural)); got != tt.want {
+			t.Errorf("compareSortText(%q, %q, caseSensitive=%v, natural=%v) = %d, want %d", tt.a, tt.b, tt.caseSensitive, tt.natural, got, tt.want)
+		}
+	}
+}
+
+func TestCompareSortValues(t *testing.T) {
+	values := []sortValue{
+		{kind: sortValueText, text: "b"},
+		{kind: sortValueBlank},
+		{kind: sortValueNumber, number: 10},
+		{kind: sortValueError, text: "#N/A"},
+		{kind: sortValueBool, text: "1"},
+		{kind: sortValueNumber, number: 2},
+		{kind: sortValueText, text: "A"},
+		{kind: sortValueBool, text: "0"},
+	}
+	tests := []struct {
+		name string
+		key  SortKey
+		want []sortValue
+	}{
+		{"ascending", SortKey{Column: "A"}, []sortValue{
+			{kind: sortValueNumber, number: 2},
+			{kind: sortValueNumber, number: 10},
+			{kind: sortValueText, text: "A"},
+			{kind: sortValueText, text: "b"},
+			{kind: sortValueBool, text: "0"},
+			{kind: sortValueBool, text: "1"},
+			{kind: sortValueError, text: "#N/A"},
+			{kind: sortValueBlank},
+		}},
+		{"descending with blanks last", SortKey{Column: "A", Descending: true}, []sortValue{
+			{kind: sortValueError, text: "#N/A"},
+			{kind: sortValueBool, text: "1"},
+			{kind: sortValueBool, text: "0"},
+			{kind: sortValueText, text: "b"},
+			{kind: sortValueText, text: "A"},
+			{kind: sortValueNumber, number: 10},
+			{kind: sortValueNumber, number: 2},
+			{kind: sortValueBlank},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Clone(values)
+			slices.SortStableFunc(got, func(a, b sortValue) int { return compareSortValues(a, b, tt.key, &SortOptions{}) })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sorted %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSortValue(t *testing.T) {
+	tests := []struct {
+		raw      string
+		cellType excelize.CellType
+		want     sortValue
+	}{
+		{"", excelize.CellTypeNumber, sortValue{kind: sortValueBlank}},
+		{"1", excelize.CellTypeBool, sortValue{kind: sortValueBool, text: "1"}},
+		{"#DIV/0!", excelize.CellTypeError, sortValue{kind: sortValueError, text: "#DIV/0!"}},
+		{"3.5", excelize.CellTypeUnset, sortValue{kind: sortValueNumber, number: 3.5}},
+		{"-1E3", excelize.CellTypeNumber, sortValue{kind: sortValueNumber, number: -1000}},
+		{"3.5", excelize.CellTypeSharedString, sortValue{kind: sortValueText, text: "3.5"}},
+		{"abc", excelize.CellTypeNumber, sortValue{kind: sortValueText, text: "abc"}},
+	}
+	for _, tt := range tests {
+		if got := parseSortValue(tt.raw, tt.cellType); got != tt.want {
+			t.Errorf("parseSortValue(%q, %v) = %+v, want %+v", tt.raw, tt.cellType, got, tt.want)
+		}
+	}
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
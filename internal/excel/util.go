@@ -5,6 +5,9 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -27,6 +30,35 @@ func ParseRange(rangeStr string) (int, int, int, int, error) {
 	return startCol, startRow, endCol, endRow, nil
 }
 
+// ParseCellOrRange parses a range string like ParseRange, but also accepts a single cell (e.g. A1)
+func ParseCellOrRange(rangeStr string) (int, int, int, int, error) {
+	if strings.Contains(rangeStr, ":") {
+		return ParseRange(rangeStr)
+	}
+	col, row, err := excelize.CellNameToCoordinates(strings.ReplaceAll(rangeStr, "$", ""))
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	return col, row, col, row, nil
+}
+
+// splitRange splits a range string into its top-left and bottom-right cell names
+func splitRange(rangeStr string) (string, string, error) {
+	startCol, startRow, endCol, endRow, err := ParseCellOrRange(rangeStr)
+	if err != nil {
+		return "", "", err
+	}
+	startCell, err := excelize.CoordinatesToCellName(startCol, startRow)
+	if err != nil {
+		return "", "", err
+	}
+	endCell, err := excelize.CoordinatesToCellName(endCol, endRow)
+	if err != nil {
+		return "", "", err
+	}
+	return startCell, endCell, nil
+}
+
 func NormalizeRange(rangeStr string) string {
 	startCol, startRow, endCol, endRow, _ := ParseRange(rangeStr)
 	startCell, _ := excelize.CoordinatesToCellName(startCol, startRow)
@@ -34,6 +66,79 @@ func NormalizeRange(rangeStr string) string {
 	return fmt.Sprintf("%s:%s", startCell, endCell)
 }
 
+// QuoteSheetName quotes the sheet name for use in references if necessary (e.g. 'My Sheet')
+func QuoteSheetName(sheetName string) string {
+	isCellName := regexp.MustCompile(`^[A-Za-z]{1,3}[0-9]+$`).MatchString(sheetName)
+	if !isCellName && regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`).MatchString(sheetName) {
+		return sheetName
+	}
+	return "'" + strings.ReplaceAll(sheetName, "'", "''") + "'"
+}
+
+// SplitSheetReference splits a reference such as 'My Sheet'!A1:B2 into the unquoted sheet name and the range.
+// The sheet name is empty if the reference is not qualified.
+func SplitSheetReference(reference string) (string, string) {
+	index := strings.LastIndex(reference, "!")
+	if index < 0 {
+		return "", reference
+	}
+	sheetName := reference[:index]
+	if strings.HasPrefix(sheetName, "'") && strings.HasSuffix(sheetName, "'") && len(sheetName) >= 2 {
+		sheetName = strings.ReplaceAll(sheetName[1:len(sheetName)-1], "''", "'")
+	}
+	return sheetName, reference[index+1:]
+}
+
+// AbsoluteReference returns the absolute reference of the range qualified with the sheet name (e.g. Sheet1!$A$1:$B$2)
+func AbsoluteReference(sheetName string, startCol int, startRow int, endCol int, endRow int) string {
+	startCell, _ := excelize.CoordinatesToCellName(startCol, startRow, true)
+	if startCol == endCol && startRow == endRow {
+		return QuoteSheetName(sheetName) + "!" + startCell
+	}
+	endCell, _ := excelize.CoordinatesToCellName(endCol, endRow, true)
+	return QuoteSheetName(sheetName) + "!" + startCell + ":" + endCell
+}
+
+// IsExternalLink reports whether the hyperlink target is a URL or a file path (e.g., https://example.com, mailto:a@example.com, C:\doc.xlsx)
+// rather than a location in the workbook (e.g., Sheet2!A1, 'My Sheet'!B2, A1:B2)
+func IsExternalLink(target string) bool {
+	if regexp.MustCompile(`^\$?[A-Za-z]+\$?[0-9]+:\$?[A-Za-z]+\$?[0-9]+$`).MatchString(target) {
+		return false
+	}
+	if regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.-]*:`).MatchString(target) {
+		return true
+	}
+	return strings.HasPrefix(target, `\\`) || strings.HasPrefix(target, "/")
+}
+
+// rangesOverlap reports whether the two ranges (e.g., A1:B2, B2:C3) have cells in common
+func rangesOverlap(a string, b string) bool {
+	aStartCol, aStartRow, aEndCol, aEndRow, err := ParseCellOrRange(a)
+	if err != nil {
+		return false
+	}
+	bStartCol, bStartRow, bEndCol, bEndRow, err := ParseCellOrRange(b)
+	if err != nil {
+		return false
+	}
+	return aStartCol <= bEndCol && bStartCol <= aEndCol && aStartRow <= bEndRow && bStartRow <= aEndRow
+}
+
+// sortCellNames sorts the cell names in row-major order (e.g., A1, B1, A2)
+func sortCellNames(cells []string) {
+	slices.SortFunc(cells, compareCellNames)
+}
+
+// compareCellNames compares the cell names in row-major order
+func compareCellNames(a string, b string) int {
+	aCol, aRow, _ := excelize.CellNameToCoordinates(a)
+	bCol, bRow, _ := excelize.CellNameToCoordinates(b)
+	if aRow != bRow {
+		return aRow - bRow
+	}
+	return aCol - bCol
+}
+
 // FileIsNotReadable checks if a file is not writable
 func FileIsNotWritable(absolutePath string) bool {
 	f, err := os.OpenFile(path.Clean(absolutePath), os.O_WRONLY, os.ModePerm)
@@ -43,3 +148,260 @@ func FileIsNotWritable(absolutePath string) bool {
 	defer f.Close()
 	return false
 }
+
+// decimalPlacesToNumFmt returns a number format code showing the specified decimal places (e.g. 0.00)
+func decimalPlacesToNumFmt(decimalPlaces int) string {
+	if decimalPlaces <= 0 {
+		return "0"
+	}
+	return "0." + strings.Repeat("0", decimalPlaces)
+}
+
+// parseSeriesFormula parses a chart series formula such as "=SERIES(Sheet1!$B$1,Sheet1!$A$2:$A$5,Sheet1!$B$2:$B$5,1)"
+func parseSeriesFormula(formula string) ChartSeries {
+	formula = strings.TrimPrefix(strings.TrimSpace(formula), "=")
+	if !strings.HasPrefix(strings.ToUpper(formula), "SERIES(") || !strings.HasSuffix(formula, ")") {
+		return ChartSeries{}
+	}
+	args := []string{}
+	var current strings.Builder
+	depth, quote := 0, rune(0)
+	for _, c := range formula[len("SERIES(") : len(formula)-1] {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			args = append(args, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(c)
+	}
+	args = append(args, current.String())
+	for len(args) < 3 {
+		args = append(args, "")
+	}
+	return ChartSeries{
+		Name:       strings.Trim(args[0], "\""),
+		Categories: args[1],
+		Values:     args[2],
+	}
+}
+
+// sheetReference is a sheet name prefix of a reference in a formula (e.g., Sheet1!, 'My Sheet'!, Sheet1:Sheet3!)
+type sheetReference struct {
+	start  int // byte offset of the prefix
+	end    int // byte offset just after "!"
+	sheets []string
+}
+
+// findSheetReferences returns the sheet name prefixes of references in the formula.
+// Prefixes of external workbook references (e.g., [1]Sheet1!) and text in string literals are ignored.
+func findSheetReferences(formula string) []sheetReference {
+	isNameChar := func(r rune) bool {
+		return r == '_' || r == '.' || r == '\\' || r >= 0x80 ||
+			('A' <= r && r <= 'Z') || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9')
+	}
+	references := []sheetReference{}
+	runes := []rune(formula)
+	offset := func(i int) int { return len(string(runes[:i])) }
+	for i := 0; i < len(runes); {
+		switch {
+		case runes[i] == '"':
+			// Skip string literal ("" is an escaped quote)
+			for i++; i < len(runes); i++ {
+				if runes[i] == '"' {
+					if i+1 < len(runes) && runes[i+1] == '"' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			i++
+		case runes[i] == '\'':
+			start := i
+			var name strings.Builder
+			for i++; i < len(runes); i++ {
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						name.WriteRune('\'')
+						i++
+						continue
+					}
+					break
+				}
+				name.WriteRune(runes[i])
+			}
+			i++
+			if i < len(runes) && runes[i] == '!' && !strings.HasPrefix(name.String(), "[") {
+				i++
+				references = append(references, sheetReference{
+					start:  offset(start),
+					end:    offset(i),
+					sheets: strings.Split(name.String(), ":"),
+				})
+			}
+		case isNameChar(runes[i]) && (i == 0 || (runes[i-1] != ']' && !isNameChar(runes[i-1]))):
+			start := i
+			sheets := []string{}
+			for {
+				nameStart := i
+				for i < len(runes) && isNameChar(runes[i]) {
+					i++
+				}
+				sheets = append(sheets, string(runes[nameStart:i]))
+				// The first sheet of a 3D reference (e.g., Sheet1:Sheet3!A1)
+				if len(sheets) == 1 && i+1 < len(runes) && runes[i] == ':' && isNameChar(runes[i+1]) {
+					i++
+					continue
+				}
+				break
+			}
+			if i < len(runes) && runes[i] == '!' {
+				i++
+				references = append(references, sheetReference{
+					start:  offset(start),
+					end:    offset(i),
+					sheets: sheets,
+				})
+			}
+		default:
+			i++
+		}
+	}
+	return references
+}
+
+// formulaRefersToSheet reports whether the formula refers to the sheet
+func formulaRefersToSheet(formula string, sheetName string) bool {
+	for _, reference := range findSheetReferences(formula) {
+		for _, sheet := range reference.sheets {
+			if strings.EqualFold(sheet, sheetName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// replaceSheetReferences replaces the sheet name prefixes referring to the sheet with the result of replaceFn
+func replaceSheetReferences(formula string, sheetName string, replaceFn func(sheets []string) string) string {
+	var result strings.Builder
+	last := 0
+	for _, reference := range findSheetReferences(formula) {
+		if !slices.ContainsFunc(reference.sheets, func(sheet string) bool { return strings.EqualFold(sheet, sheetName) }) {
+			continue
+		}
+		result.WriteString(formula[last:reference.start])
+		result.WriteString(replaceFn(reference.sheets))
+		last = reference.end
+	}
+	result.WriteString(formula[last:])
+	return result.String()
+}
+
+// renameSheetInFormula rewrites the references to the sheet in the formula with the new sheet name
+func renameSheetInFormula(formula string, oldName string, newName string) string {
+	return replaceSheetReferences(formula, oldName, func(sheets []string) string {
+		renamed := make([]string, len(sheets))
+		for i, sheet := range sheets {
+			renamed[i] = sheet
+			if strings.EqualFold(sheet, oldName) {
+				renamed[i] = newName
+			}
+		}
+		return qualifyReference(renamed, "")
+	})
+}
+
+// invalidateSheetInFormula replaces the references to the sheet in the formula with #REF! as Excel does when the sheet is deleted
+func invalidateSheetInFormula(formula string, sheetName string) string {
+	replaced := replaceSheetReferences(formula, sheetName, func(sheets []string) string { return "\x00" })
+	// Drop the cell or range following the invalidated sheet prefix (e.g., \x00$A$1:$B$2)
+	return regexp.MustCompile(`\x00(\$?[A-Za-z]*\$?[0-9]*(:\$?[A-Za-z]*\$?[0-9]*)?)`).ReplaceAllString(replaced, "#REF!")
+}
+
+var cellReferencePattern = regexp.MustCompile(`^(\$?)([A-Za-z]{1,3})(\$?)([0-9]+)`)
+
+// offsetFormulaReferences shifts the relative cell references in the formula as Excel does when the formula is copied
+// by the specified number of rows and columns (e.g., A1+$B$1 becomes A3+$B$1 by 2 rows).
+// References shifted out of the sheet become #REF!.
+func offsetFormulaReferences(formula string, rowOffset int, colOffset int) string {
+	isNameChar := func(r rune) bool {
+		return r == '_' || r == '.' || r == '\\' || r == '$' || r >= 0x80 ||
+			('A' <= r && r <= 'Z') || ('a' <= r && r <= 'z') || ('0' <= r && r <= '9')
+	}
+	var result strings.Builder
+	runes := []rune(formula)
+	for i := 0; i < len(runes); {
+		switch {
+		case runes[i] == '"' || runes[i] == '\'' || runes[i] == '[':
+			// Copy string literals, quoted sheet names and structured references as they are
+			end, depth := i+1, 1
+			for ; end < len(runes) && depth > 0; end++ {
+				switch {
+				case runes[i] == '[' && runes[end] == '[':
+					depth++
+				case runes[i] == '[' && runes[end] == ']':
+					depth--
+				case runes[i] != '[' && runes[end] == runes[i]:
+					if end+1 < len(runes) && runes[end+1] == runes[i] {
+						end++
+						continue
+					}
+					depth--
+				}
+			}
+			result.WriteString(string(runes[i:end]))
+			i = end
+		case isNameChar(runes[i]) && (i == 0 || !isNameChar(runes[i-1])):
+			matches := cellReferencePattern.FindStringSubmatch(string(runes[i:]))
+			length := 0
+			if matches != nil {
+				length = len([]rune(matches[0]))
+			}
+			if matches == nil || (i+length < len(runes) && (isNameChar(runes[i+length]) || strings.ContainsRune("(![", runes[i+length]))) {
+				// Copy the token which is not a cell reference (e.g., a function name, a defined name, a sheet name or a table name)
+				start := i
+				for i < len(runes) && isNameChar(runes[i]) {
+					i++
+				}
+				result.WriteString(string(runes[start:i]))
+				continue
+			}
+			col, err := excelize.ColumnNameToNumber(matches[2])
+			row, _ := strconv.Atoi(matches[4])
+			if err != nil {
+				result.WriteString(matches[0])
+				i += length
+				continue
+			}
+			if matches[1] == "" {
+				col += colOffset
+			}
+			if matches[3] == "" {
+				row += rowOffset
+			}
+			columnName, err := excelize.ColumnNumberToName(col)
+			if err != nil || row < 1 || row > excelize.TotalRows {
+				result.WriteString("#REF!")
+			} else {
+				result.WriteString(matches[1] + columnName + matches[3] + strconv.Itoa(row))
+			}
+			i += length
+		default:
+			result.WriteRune(runes[i])
+			i++
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,81 @@
+package excel
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CellValueType is the type of the value of a cell
+type CellValueType string
+
+const (
+	CellValueEmpty   CellValueType = "empty"
+	CellValueNumber  CellValueType = "number"
+	CellValueString  CellValueType = "string"
+	CellValueBoolean CellValueType = "boolean"
+	CellValueDate    CellValueType = "date" // a number formatted as a date or time
+	CellValueError   CellValueType = "error"
+)
+
+// TypedValue is the value of a cell with its type
+type TypedValue struct {
+	Type CellValueType
+	// Raw is the underlying value. Numbers are in the invariant format (e.g., 45322.5, which is a serial number for dates),
+	// booleans are TRUE or FALSE, and errors are error values (e.g., #DIV/0!).
+	Raw string
+	// Formatted is the text displayed in the cell with its number format applied.
+	Formatted string
+}
+
+// formulaErrorValues are the error values of the formulas
+var formulaErrorValues = []string{"#NULL!", "#DIV/0!", "#VALUE!", "#REF!", "#NAME?", "#NUM!", "#N/A", "#GETTING_DATA", "#SPILL!", "#CALC!"}
+
+// isFormulaErrorValue reports whether the value is an error value of the formulas (e.g., #DIV/0!)
+func isFormulaErrorValue(value string) bool {
+	return slices.Contains(formulaErrorValues, value)
+}
+
+// builtInDateNumFmtIDs are the IDs of the built-in number formats for dates and times, including the ones of East Asian locales
+var builtInDateNumFmtIDs = []int{14, 15, 16, 17, 18, 19, 20, 21, 22, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 45, 46, 47, 50, 51, 52, 53, 54, 55, 56, 57, 58}
+
+// numFmtLiteralPattern matches the parts of a number format which are not format codes:
+// quoted text, escaped characters, and colors, conditions or elapsed times in brackets
+var numFmtLiteralPattern = regexp.MustCompile(`"[^"]*"|\\.|_.|\*.|\[[^\]]*\]`)
+
+// elapsedTimePattern matches the elapsed times in brackets (e.g., [h], [mm])
+var elapsedTimePattern = regexp.MustCompile(`^\[(?i:h+|m+|s+)\]$`)
+
+// isDateNumFmt reports whether the number format code (e.g., yyyy/m/d, [h]:mm) formats numbers as dates or times
+func isDateNumFmt(code string) bool {
+	code = numFmtLiteralPattern.ReplaceAllStringFunc(code, func(literal string) string {
+		if elapsedTimePattern.MatchString(literal) {
+			return "h"
+		}
+		return ""
+	})
+	return strings.ContainsAny(code, "dDmMyYhHsS")
+}
+
+// inferTypedValue infers the type of a value which has no type in the file, such as the result of a formula calculated
+// on the fly. Numbers formatted as dates or times are dates.
+func inferTypedValue(raw string, formatted string, dateFormatted bool) *TypedValue {
+	typedValue := &TypedValue{Type: CellValueString, Raw: raw, Formatted: formatted}
+	switch {
+	case raw == "":
+		typedValue.Type = CellValueEmpty
+	case isFormulaErrorValue(raw):
+		typedValue.Type = CellValueError
+	case raw == "TRUE" || raw == "FALSE":
+		typedValue.Type = CellValueBoolean
+	default:
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			typedValue.Type = CellValueNumber
+			if dateFormatted {
+				typedValue.Type = CellValueDate
+			}
+		}
+	}
+	return typedValue
+}
@@ -40,6 +40,33 @@ func New(version string) *ExcelServer {
 	tools.AddExcelCopySheetTool(s.server)
 	tools.AddExcelAddDataValidationTool(s.server)
 	tools.AddExcelAddConditionalFormattingTool(s.server)
+	tools.AddExcelFormatRangeTool(s.server)
+	tools.AddExcelInsertRowsTool(s.server)
+	tools.AddExcelDeleteRowsTool(s.server)
+	tools.AddExcelInsertColumnsTool(s.server)
+	tools.AddExcelDeleteColumnsTool(s.server)
+	tools.AddExcelMergeCellsTool(s.server)
+	tools.AddExcelUnmergeCellsTool(s.server)
+	tools.AddExcelAddChartTool(s.server)
+	tools.AddExcelCreatePivotTableTool(s.server)
+	tools.AddExcelInsertPictureTool(s.server)
+	tools.AddExcelGetPictureTool(s.server)
+	tools.AddExcelManageNamesTool(s.server)
+	tools.AddExcelCommentsTool(s.server)
+	tools.AddExcelSetHyperlinkTool(s.server)
+	tools.AddExcelManageSheetTool(s.server)
+	tools.AddExcelSetViewTool(s.server)
+	tools.AddExcelAutoFilterTool(s.server)
+	tools.AddExcelSortRangeTool(s.server)
+	tools.AddExcelSearchTool(s.server)
+	tools.AddExcelFindReplaceTool(s.server)
+	tools.AddExcelSetDimensionsTool(s.server)
+	tools.AddExcelCreateWorkbookTool(s.server)
+	tools.AddExcelSaveAsTool(s.server)
+	tools.AddExcelImportCSVTool(s.server)
+	tools.AddExcelExportCSVTool(s.server)
+	tools.AddExcelRecalculateTool(s.server)
+	tools.AddExcelTraceFormulaTool(s.server)
 	tools.AddExcelExecuteVBATool(s.server)
 	tools.AddExcelAddVBAModuleTool(s.server)
 
@@ -331,45 +331,129 @@ func convertToYAMLFlow(value any) string {
 	return yamlStr
 }
 
-func CreateHTMLTableOfValues(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*string, error) {
-	return createHTMLTable(startCol, startRow, endCol, endRow, func(cellRange string) (string, error) {
-		return worksheet.GetValue(cellRange)
-	})
+// HTMLTableOptions specifies optional information attached to the cells of the HTML table
+type HTMLTableOptions struct {
+	// ShowComments attaches the comment of each cell as data-comment attributes
+	ShowComments bool
+	// ShowHyperlinks wraps the value of each cell having a hyperlink in <a href>
+	ShowHyperlinks bool
 }
 
-func CreateHTMLTableOfFormula(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*string, error) {
-	return createHTMLTable(startCol, startRow, endCol, endRow, func(cellRange string) (string, error) {
-		return worksheet.GetFormula(cellRange)
-	})
+// cellAnnotations holds the information of the worksheet attached to the cells of the HTML table
+type cellAnnotations struct {
+	mergedCells []string
+	comments    map[string]excel.Comment // keyed by cell name
+	hyperlinks  func(cell string) (*excel.Hyperlink, error)
+}
+
+func getCellAnnotations(worksheet excel.Worksheet, options HTMLTableOptions) (*cellAnnotations, error) {
+	mergedCells, err := worksheet.GetMergedCells()
+	if err != nil {
+		return nil, err
+	}
+	annotations := &cellAnnotations{mergedCells: mergedCells}
+	if options.ShowComments {
+		comments, err := worksheet.GetComments()
+		if err != nil {
+			return nil, err
+		}
+		annotations.comments = make(map[string]excel.Comment, len(comments))
+		for _, comment := range comments {
+			annotations.comments[strings.ToUpper(strings.ReplaceAll(comment.Cell, "$", ""))] = comment
+		}
+	}
+	if options.ShowHyperlinks {
+		annotations.hyperlinks = worksheet.GetHyperlink
+	}
+	return annotations, nil
+}
+
+func CreateHTMLTableOfValues(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, options HTMLTableOptions) (*string, error) {
+	annotations, err := getCellAnnotations(worksheet, options)
+	if err != nil {
+		return nil, err
+	}
+	return createHTMLTable(worksheet, startCol, startRow, endCol, endRow, false, annotations)
+}
+
+func CreateHTMLTableOfFormula(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, options HTMLTableOptions) (*string, error) {
+	annotations, err := getCellAnnotations(worksheet, options)
+	if err != nil {
+		return nil, err
+	}
+	return createHTMLTable(worksheet, startCol, startRow, endCol, endRow, true, annotations)
 }
 
 // CreateHTMLTable creates a table data in HTML format
-func createHTMLTable(startCol int, startRow int, endCol int, endRow int, extractor func(cellRange string) (string, error)) (*string, error) {
-	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow, extractor, nil)
+func createHTMLTable(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, showFormula bool, annotations *cellAnnotations) (*string, error) {
+	return createHTMLTableWithStyle(worksheet, startCol, startRow, endCol, endRow, showFormula, nil, annotations)
 }
 
-func CreateHTMLTableOfValuesWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*string, error) {
-	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow,
-		func(cellRange string) (string, error) {
-			return worksheet.GetValue(cellRange)
-		},
+func CreateHTMLTableOfValuesWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, options HTMLTableOptions) (*string, error) {
+	annotations, err := getCellAnnotations(worksheet, options)
+	if err != nil {
+		return nil, err
+	}
+	return createHTMLTableWithStyle(worksheet, startCol, startRow, endCol, endRow, false,
 		func(cellRange string) (*excel.CellStyle, error) {
 			return worksheet.GetCellStyle(cellRange)
-		})
+		},
+		annotations)
 }
 
-func CreateHTMLTableOfFormulaWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) (*string, error) {
-	return createHTMLTableWithStyle(startCol, startRow, endCol, endRow,
-		func(cellRange string) (string, error) {
-			return worksheet.GetFormula(cellRange)
-		},
+func CreateHTMLTableOfFormulaWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, options HTMLTableOptions) (*string, error) {
+	annotations, err := getCellAnnotations(worksheet, options)
+	if err != nil {
+		return nil, err
+	}
+	return createHTMLTableWithStyle(worksheet, startCol, startRow, endCol, endRow, true,
 		func(cellRange string) (*excel.CellStyle, error) {
 			return worksheet.GetCellStyle(cellRange)
-		})
+		},
+		annotations)
+}
+
+// mergedCellSpan describes a merged region clipped to the output range
+type mergedCellSpan struct {
+	axis    string // top-left cell of the merged region, which holds the value
+	rowSpan int
+	colSpan int
 }
 
-func createHTMLTableWithStyle(startCol int, startRow int, endCol int, endRow int, extractor func(cellRange string) (string, error), styleExtractor func(cellRange string) (*excel.CellStyle, error)) (*string, error) {
+// layoutMergedCells maps the top-left cell of each merged region visible in the output range to its span.
+// Other cells covered by the merged regions are marked as covered.
+func layoutMergedCells(startCol int, startRow int, endCol int, endRow int, mergedCells []string) (map[[2]int]mergedCellSpan, map[[2]int]bool) {
+	spans := make(map[[2]int]mergedCellSpan)
+	covered := make(map[[2]int]bool)
+	for _, mergedCell := range mergedCells {
+		mergeStartCol, mergeStartRow, mergeEndCol, mergeEndRow, err := excel.ParseCellOrRange(mergedCell)
+		if err != nil {
+			continue
+		}
+		axis, _ := excelize.CoordinatesToCellName(mergeStartCol, mergeStartRow)
+		clippedStartCol, clippedStartRow := max(mergeStartCol, startCol), max(mergeStartRow, startRow)
+		clippedEndCol, clippedEndRow := min(mergeEndCol, endCol), min(mergeEndRow, endRow)
+		if clippedStartCol > clippedEndCol || clippedStartRow > clippedEndRow {
+			continue
+		}
+		for row := clippedStartRow; row <= clippedEndRow; row++ {
+			for col := clippedStartCol; col <= clippedEndCol; col++ {
+				covered[[2]int{col, row}] = true
+			}
+		}
+		delete(covered, [2]int{clippedStartCol, clippedStartRow})
+		spans[[2]int{clippedStartCol, clippedStartRow}] = mergedCellSpan{
+			axis:    axis,
+			rowSpan: clippedEndRow - clippedStartRow + 1,
+			colSpan: clippedEndCol - clippedStartCol + 1,
+		}
+	}
+	return spans, covered
+}
+
+func createHTMLTableWithStyle(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int, showFormula bool, styleExtractor func(cellRange string) (*excel.CellStyle, error), annotations *cellAnnotations) (*string, error) {
 	registry := NewStyleRegistry()
+	spans, covered := layoutMergedCells(startCol, startRow, endCol, endRow, annotations.mergedCells)
 
 	// データとスタイルを収集
 	var result strings.Builder
@@ -388,27 +472,51 @@ func createHTMLTableWithStyle(startCol int, startRow int, endCol int, endRow int
 		result.WriteString(fmt.Sprintf("<th>%d</th>", row))
 
 		for col := startCol; col <= endCol; col++ {
+			if covered[[2]int{col, row}] {
+				continue
+			}
 			axis, _ := excelize.CoordinatesToCellName(col, row)
-			value, _ := extractor(axis)
+			span, merged := spans[[2]int{col, row}]
+			if merged {
+				axis = span.axis
+			}
+			typedValue, err := worksheet.GetTypedValue(axis)
+			if err != nil {
+				typedValue = &excel.TypedValue{Type: excel.CellValueEmpty}
+			}
+			value := typedValue.Formatted
+			if showFormula {
+				value, _ = worksheet.GetFormula(axis)
+			}
 
-			var tdTag string
+			// attributes of the <td> tag
+			var attributes string
 			if styleExtractor != nil {
 				cellStyle, err := styleExtractor(axis)
 				if err == nil && cellStyle != nil {
-					styleIDs := registry.RegisterStyle(cellStyle)
-					if len(styleIDs) > 0 {
-						tdTag = fmt.Sprintf("<td style-ref=\"%s\">", strings.Join(styleIDs, " "))
-					} else {
-						tdTag = "<td>"
+					if styleIDs := registry.RegisterStyle(cellStyle); len(styleIDs) > 0 {
+						attributes += fmt.Sprintf(" style-ref=\"%s\"", strings.Join(styleIDs, " "))
 					}
-				} else {
-					tdTag = "<td>"
 				}
-			} else {
-				tdTag = "<td>"
+			}
+			if merged {
+				attributes += mergedCellSpanAttributes(span)
+			}
+			if typedValue.Type != excel.CellValueEmpty {
+				attributes += fmt.Sprintf(" data-type=\"%s\"", typedValue.Type)
+			}
+			if comment, ok := annotations.comments[axis]; ok {
+				attributes += commentAttributes(comment)
+			}
+
+			content := strings.ReplaceAll(html.EscapeString(value), "\n", "<br>")
+			if annotations.hyperlinks != nil {
+				if hyperlink, err := annotations.hyperlinks(axis); err == nil && hyperlink != nil {
+					content = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(hyperlinkHref(hyperlink)), content)
+				}
 			}
 
-			result.WriteString(fmt.Sprintf("%s%s</td>", tdTag, strings.ReplaceAll(html.EscapeString(value), "\n", "<br>")))
+			result.WriteString(fmt.Sprintf("<td%s>%s</td>", attributes, content))
 		}
 		result.WriteString("</tr>\n")
 	}
@@ -429,6 +537,33 @@ func createHTMLTableWithStyle(startCol int, startRow int, endCol int, endRow int
 	return &finalResultStr, nil
 }
 
+func mergedCellSpanAttributes(span mergedCellSpan) string {
+	var attributes string
+	if span.rowSpan > 1 {
+		attributes += fmt.Sprintf(" rowspan=\"%d\"", span.rowSpan)
+	}
+	if span.colSpan > 1 {
+		attributes += fmt.Sprintf(" colspan=\"%d\"", span.colSpan)
+	}
+	return attributes
+}
+
+func commentAttributes(comment excel.Comment) string {
+	attributes := fmt.Sprintf(" data-comment=\"%s\"", strings.ReplaceAll(html.EscapeString(comment.Text), "\n", "&#10;"))
+	if comment.Author != "" {
+		attributes += fmt.Sprintf(" data-comment-author=\"%s\"", html.EscapeString(comment.Author))
+	}
+	return attributes
+}
+
+// hyperlinkHref returns the href of the hyperlink. A location in the workbook is prefixed with "#".
+func hyperlinkHref(hyperlink *excel.Hyperlink) string {
+	if hyperlink.IsExternal() {
+		return hyperlink.Target
+	}
+	return "#" + hyperlink.Target
+}
+
 func AbsolutePathTest() z.Test[*string] {
 	return z.Test[*string]{
 		Func: func(path *string, ctx z.Ctx) {
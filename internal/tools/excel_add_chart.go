@@ -0,0 +1,315 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelAddChartArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	ChartType        string `zog:"chartType"`
+	AnchorCell       string `zog:"anchorCell"`
+	Title            string `zog:"title"`
+	XAxisTitle       string `zog:"xAxisTitle"`
+	YAxisTitle       string `zog:"yAxisTitle"`
+	LegendPosition   string `zog:"legendPosition"`
+	Width            int    `zog:"width"`
+	Height           int    `zog:"height"`
+}
+
+var excelAddChartArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"chartType":        z.String().OneOf([]string{"line", "bar", "column", "pie", "scatter", "area"}).Required(),
+	"anchorCell":       z.String().Required(),
+	"title":            z.String(),
+	"xAxisTitle":       z.String(),
+	"yAxisTitle":       z.String(),
+	"legendPosition":   z.String().OneOf([]string{"top", "bottom", "left", "right", "topRight", "none"}).Default("bottom"),
+	"width":            z.Int().GT(0).Default(480),
+	"height":           z.Int().GT(0).Default(260),
+})
+
+// chartSeriesArgument specifies the data of a series by ranges or by table columns
+type chartSeriesArgument struct {
+	Name           string `yaml:"name"`
+	Categories     string `yaml:"categories"`
+	Values         string `yaml:"values"`
+	Table          string `yaml:"table"`
+	CategoryColumn string `yaml:"categoryColumn"`
+	ValueColumn    string `yaml:"valueColumn"`
+}
+
+func AddExcelAddChartTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_add_chart",
+		mcp.WithDescription("Add a chart to the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name where the chart is placed"),
+		),
+		mcp.WithString("chartType",
+			mcp.Required(),
+			mcp.Enum("line", "bar", "column", "pie", "scatter", "area"),
+			mcp.Description("Type of the chart"),
+		),
+		mcp.WithString("anchorCell",
+			mcp.Required(),
+			mcp.Description("Cell where the top-left corner of the chart is placed (e.g., \"E2\")"),
+		),
+		mcp.WithArray("series",
+			mcp.Required(),
+			mcp.Description("Series of the chart. Each series is an object with either {name, categories, values} ranges (e.g., \"Sheet1!$B$2:$B$10\"; ranges without sheet name refer to sheetName) or {table, categoryColumn, valueColumn} table column names. name is a cell reference (e.g., \"B1\") holding the series name, and defaults to the column header for table columns."),
+		),
+		mcp.WithString("title",
+			mcp.Description("Title of the chart"),
+		),
+		mcp.WithString("xAxisTitle",
+			mcp.Description("Title of the horizontal (category) axis"),
+		),
+		mcp.WithString("yAxisTitle",
+			mcp.Description("Title of the vertical (value) axis"),
+		),
+		mcp.WithString("legendPosition",
+			mcp.Enum("top", "bottom", "left", "right", "topRight", "none"),
+			mcp.Description("Position of the legend [default: bottom]"),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Width of the chart in pixels [default: 480]"),
+		),
+		mcp.WithNumber("height",
+			mcp.Description("Height of the chart in pixels [default: 260]"),
+		),
+	), handleAddChart)
+}
+
+func handleAddChart(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelAddChartArguments{}
+	if issues := excelAddChartArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+
+	seriesArg, ok := request.Params.Arguments["series"].([]any)
+	if !ok || len(seriesArg) == 0 {
+		return imcp.NewToolResultInvalidArgumentError("series must be a non-empty array"), nil
+	}
+	series, err := parseChartSeriesArguments(seriesArg)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid series: %v", err)), nil
+	}
+
+	return addChart(args, series)
+}
+
+func parseChartSeriesArguments(seriesArg []any) ([]chartSeriesArgument, error) {
+	jsonBytes, err := json.Marshal(seriesArg)
+	if err != nil {
+		return nil, err
+	}
+	series := []chartSeriesArgument{}
+	if err := yaml.UnmarshalWithOptions(jsonBytes, &series, yaml.DisallowUnknownField()); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func addChart(args ExcelAddChartArguments, seriesArgs []chartSeriesArgument) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	options := &excel.ChartOptions{
+		Type:           args.ChartType,
+		Title:          args.Title,
+		XAxisTitle:     args.XAxisTitle,
+		YAxisTitle:     args.YAxisTitle,
+		LegendPosition: args.LegendPosition,
+		AnchorCell:     args.AnchorCell,
+		Width:          args.Width,
+		Height:         args.Height,
+	}
+	for i, seriesArg := range seriesArgs {
+		series, err := resolveChartSeries(workbook, args.SheetName, seriesArg)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("series[%d]: %v", i, err)), nil
+		}
+		options.Series = append(options.Series, *series)
+	}
+
+	if err := worksheet.AddChart(options); err != nil {
+		return nil, err
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("%s chart added at %s in sheet [%s].\n", args.ChartType, args.AnchorCell, args.SheetName)
+	result += "# Series\n"
+	for _, series := range options.Series {
+		result += fmt.Sprintf("- name: %s, categories: %s, values: %s\n", series.Name, series.Categories, series.Values)
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// resolveChartSeries converts ranges and table columns of the series argument into sheet-qualified references
+func resolveChartSeries(workbook excel.Excel, sheetName string, seriesArg chartSeriesArgument) (*excel.ChartSeries, error) {
+	if seriesArg.Table == "" {
+		if seriesArg.Values == "" {
+			return nil, fmt.Errorf("values or table is required")
+		}
+		values, err := qualifyRange(sheetName, seriesArg.Values)
+		if err != nil {
+			return nil, err
+		}
+		name, err := qualifySeriesName(sheetName, seriesArg.Name)
+		if err != nil {
+			return nil, err
+		}
+		categories := ""
+		if seriesArg.Categories != "" {
+			if categories, err = qualifyRange(sheetName, seriesArg.Categories); err != nil {
+				return nil, err
+			}
+		}
+		return &excel.ChartSeries{
+			Name:       name,
+			Categories: categories,
+			Values:     values,
+		}, nil
+	}
+
+	if seriesArg.ValueColumn == "" {
+		return nil, fmt.Errorf("valueColumn is required for table series")
+	}
+	tableColumns, err := findTableColumns(workbook, seriesArg.Table)
+	if err != nil {
+		return nil, err
+	}
+	valueColumn, ok := tableColumns[seriesArg.ValueColumn]
+	if !ok {
+		return nil, fmt.Errorf("column %s not found in table %s", seriesArg.ValueColumn, seriesArg.Table)
+	}
+	name, err := qualifySeriesName(sheetName, seriesArg.Name)
+	if err != nil {
+		return nil, err
+	}
+	series := &excel.ChartSeries{
+		Name:   name,
+		Values: valueColumn.data,
+	}
+	if series.Name == "" {
+		series.Name = valueColumn.header
+	}
+	if seriesArg.CategoryColumn != "" {
+		categoryColumn, ok := tableColumns[seriesArg.CategoryColumn]
+		if !ok {
+			return nil, fmt.Errorf("column %s not found in table %s", seriesArg.CategoryColumn, seriesArg.Table)
+		}
+		series.Categories = categoryColumn.data
+	}
+	return series, nil
+}
+
+// qualifyRange returns the absolute reference of the range, adding the sheet name if omitted
+func qualifyRange(sheetName string, cellRange string) (string, error) {
+	if qualifiedSheetName, rangePart := excel.SplitSheetReference(cellRange); qualifiedSheetName != "" {
+		sheetName, cellRange = qualifiedSheetName, rangePart
+	}
+	startCol, startRow, endCol, endRow, err := excel.ParseCellOrRange(cellRange)
+	if err != nil {
+		return "", err
+	}
+	return excel.AbsoluteReference(sheetName, startCol, startRow, endCol, endRow), nil
+}
+
+// qualifySeriesName returns the absolute reference of the cell holding the series name, or empty if the name is omitted
+func qualifySeriesName(sheetName string, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	reference, err := qualifyRange(sheetName, name)
+	if err != nil || strings.Contains(reference, ":") {
+		return "", fmt.Errorf("name must be a cell reference (e.g., \"B1\"): %s", name)
+	}
+	return reference, nil
+}
+
+// tableColumn holds references of the header cell and the data cells of a table column
+type tableColumn struct {
+	header string
+	data   string
+}
+
+// findTableColumns finds the table in the workbook and returns its columns keyed by header name
+func findTableColumns(workbook excel.Excel, tableName string) (map[string]tableColumn, error) {
+	worksheets, err := workbook.GetSheets()
+	if err != nil {
+		return nil, err
+	}
+	for _, worksheet := range worksheets {
+		defer worksheet.Release()
+	}
+	for _, worksheet := range worksheets {
+		tables, err := worksheet.GetTables()
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			if !strings.EqualFold(table.Name, tableName) {
+				continue
+			}
+			sheetName, err := worksheet.Name()
+			if err != nil {
+				return nil, err
+			}
+			startCol, startRow, endCol, endRow, err := excel.ParseRange(table.Range)
+			if err != nil {
+				return nil, err
+			}
+			if endRow <= startRow {
+				return nil, fmt.Errorf("table %s has no data rows", tableName)
+			}
+			columns := make(map[string]tableColumn)
+			for col := startCol; col <= endCol; col++ {
+				axis, err := excelize.CoordinatesToCellName(col, startRow)
+				if err != nil {
+					return nil, err
+				}
+				header, err := worksheet.GetValue(axis)
+				if err != nil {
+					return nil, err
+				}
+				columns[header] = tableColumn{
+					header: excel.AbsoluteReference(sheetName, col, startRow, col, startRow),
+					data:   excel.AbsoluteReference(sheetName, col, startRow+1, col, endRow),
+				}
+			}
+			return columns, nil
+		}
+	}
+	return nil, fmt.Errorf("table not found: %s", tableName)
+}
@@ -84,16 +84,16 @@ func handleAddConditionalFormatting(ctx context.Context, request mcp.CallToolReq
 			if fontArg, ok := formatArg["font"].(map[string]interface{}); ok {
 				conditions.Format.Font = &excel.FontStyle{}
 				if bold, ok := fontArg["bold"].(bool); ok {
-					conditions.Format.Font.Bold = bold
+					conditions.Format.Font.Bold = &bold
 				}
 				if italic, ok := fontArg["italic"].(bool); ok {
-					conditions.Format.Font.Italic = italic
+					conditions.Format.Font.Italic = &italic
 				}
 				if color, ok := fontArg["color"].(string); ok {
 					conditions.Format.Font.Color = color
 				}
 				if size, ok := fontArg["size"].(float64); ok {
-					conditions.Format.Font.Size = int(size)
+					conditions.Format.Font.Size = size
 				}
 			}
 
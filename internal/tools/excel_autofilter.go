@@ -0,0 +1,225 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelAutoFilterArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Action           string `zog:"action"`
+	Range            string `zog:"range"`
+}
+
+var excelAutoFilterArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"action":           z.String().OneOf([]string{"get", "set", "remove"}).Required(),
+	"range":            z.String(),
+})
+
+// autoFilterColumnArgument specifies the filter criteria of a column
+type autoFilterColumnArgument struct {
+	Column     string   `yaml:"column"`
+	Type       string   `yaml:"type"`
+	Values     []string `yaml:"values"`
+	Text       string   `yaml:"text"`
+	Count      int      `yaml:"count"`
+	Bottom     bool     `yaml:"bottom"`
+	Percent    bool     `yaml:"percent"`
+	Expression string   `yaml:"expression"`
+}
+
+func AddExcelAutoFilterTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_autofilter",
+		mcp.WithDescription("Get, set or remove the AutoFilter of the Excel sheet. Setting the filter hides the rows not matching the criteria."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum("get", "set", "remove"),
+			mcp.Description("Action to perform. \"set\" replaces the existing filter."),
+		),
+		mcp.WithString("range",
+			mcp.Description("Range of the filter including the header row (e.g., \"A1:D100\") for set. Defaults to the used range of the sheet."),
+		),
+		mcp.WithArray("filters",
+			mcp.Description("Filter criteria of columns for set. Each criteria is an object with column (e.g., \"B\") and type, which is one of "+
+				"\"equals\" with values (values to show; \"\" shows blank cells), "+
+				"\"contains\" with text, "+
+				"\"topN\" with count, bottom (default false) and percent (default false), or "+
+				"\"custom\" with expression of one or two conditions joined by \"and\" or \"or\" (e.g., \">= 10 and < 20\", \"<> *draft*\"; * and ? are wildcards). "+
+				"Omit to add the filter buttons without criteria."),
+		),
+	), handleAutoFilter)
+}
+
+func handleAutoFilter(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelAutoFilterArguments{}
+	if issues := excelAutoFilterArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if args.Range != "" {
+		if _, _, _, _, err := excel.ParseCellOrRange(args.Range); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid range: %s", args.Range)), nil
+		}
+	}
+	columns := []excel.AutoFilterColumn{}
+	if filtersArg, ok := request.Params.Arguments["filters"].([]any); ok && args.Action == "set" {
+		var err error
+		if columns, err = parseAutoFilterColumnArguments(filtersArg); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid filters: %v", err)), nil
+		}
+	}
+	return autoFilter(args, columns)
+}
+
+func parseAutoFilterColumnArguments(filtersArg []any) ([]excel.AutoFilterColumn, error) {
+	jsonBytes, err := json.Marshal(filtersArg)
+	if err != nil {
+		return nil, err
+	}
+	filters := []autoFilterColumnArgument{}
+	if err := yaml.UnmarshalWithOptions(jsonBytes, &filters, yaml.DisallowUnknownField()); err != nil {
+		return nil, err
+	}
+	columns := make([]excel.AutoFilterColumn, len(filters))
+	for i, filter := range filters {
+		if filter.Column == "" {
+			return nil, fmt.Errorf("column is required")
+		}
+		switch excel.AutoFilterType(filter.Type) {
+		case excel.AutoFilterEquals:
+			if len(filter.Values) == 0 {
+				return nil, fmt.Errorf("values are required for equals filter of column %s", filter.Column)
+			}
+		case excel.AutoFilterContains:
+			if filter.Text == "" {
+				return nil, fmt.Errorf("text is required for contains filter of column %s", filter.Column)
+			}
+		case excel.AutoFilterTopN:
+			if filter.Count <= 0 || (filter.Percent && filter.Count > 100) || (!filter.Percent && filter.Count > 500) {
+				return nil, fmt.Errorf("count must be 1-500 items or 1-100 percent for topN filter of column %s", filter.Column)
+			}
+		case excel.AutoFilterCustom:
+			if filter.Expression == "" {
+				return nil, fmt.Errorf("expression is required for custom filter of column %s", filter.Column)
+			}
+		default:
+			return nil, fmt.Errorf("invalid type of column %s: %s", filter.Column, filter.Type)
+		}
+		columns[i] = excel.AutoFilterColumn{
+			Column:     strings.ToUpper(filter.Column),
+			Type:       excel.AutoFilterType(filter.Type),
+			Values:     filter.Values,
+			Text:       filter.Text,
+			Count:      filter.Count,
+			Bottom:     filter.Bottom,
+			Percent:    filter.Percent,
+			Expression: filter.Expression,
+		}
+	}
+	return columns, nil
+}
+
+func autoFilter(args ExcelAutoFilterArguments, columns []excel.AutoFilterColumn) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	message := ""
+	switch args.Action {
+	case "set":
+		filterRange := args.Range
+		if filterRange == "" {
+			if filterRange, err = worksheet.GetDimention(); err != nil {
+				return nil, err
+			}
+		}
+		if err := worksheet.SetAutoFilter(&excel.AutoFilter{Range: filterRange, Columns: columns}); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		message = fmt.Sprintf("AutoFilter of sheet [%s] set.\n", args.SheetName)
+	case "remove":
+		if err := worksheet.RemoveAutoFilter(); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		message = fmt.Sprintf("AutoFilter of sheet [%s] removed.\n", args.SheetName)
+	}
+	if args.Action != "get" {
+		if err := workbook.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	filter, err := worksheet.GetAutoFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += message
+	result += "# AutoFilter\n"
+	if filter == nil {
+		result += "No AutoFilter.\n"
+		return mcp.NewToolResultText(result), nil
+	}
+	result += fmt.Sprintf("- range: %s\n", filter.Range)
+	if len(filter.Columns) == 0 {
+		result += "- criteria: none\n"
+	}
+	for _, column := range filter.Columns {
+		result += fmt.Sprintf("- column %s: %s\n", column.Column, formatAutoFilterColumn(column))
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// formatAutoFilterColumn formats the filter criteria of a column (e.g., equals "a", "b"; top 10 percent)
+func formatAutoFilterColumn(column excel.AutoFilterColumn) string {
+	switch column.Type {
+	case excel.AutoFilterEquals:
+		values := make([]string, len(column.Values))
+		for i, value := range column.Values {
+			values[i] = fmt.Sprintf("\"%s\"", value)
+		}
+		return "equals " + strings.Join(values, ", ")
+	case excel.AutoFilterContains:
+		return fmt.Sprintf("contains \"%s\"", column.Text)
+	case excel.AutoFilterTopN:
+		order, unit := "top", "items"
+		if column.Bottom {
+			order = "bottom"
+		}
+		if column.Percent {
+			unit = "percent"
+		}
+		return fmt.Sprintf("%s %d %s", order, column.Count, unit)
+	default:
+		return fmt.Sprintf("custom \"%s\"", column.Expression)
+	}
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelCommentsArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Action           string `zog:"action"`
+	Cell             string `zog:"cell"`
+	Text             string `zog:"text"`
+	Author           string `zog:"author"`
+}
+
+var excelCommentsArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"action":           z.String().OneOf([]string{"list", "add", "delete"}).Required(),
+	"cell":             z.String(),
+	"text":             z.String(),
+	"author":           z.String(),
+})
+
+func AddExcelCommentsTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_comments",
+		mcp.WithDescription("List, add or delete comments (notes) of cells in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum("list", "add", "delete"),
+			mcp.Description("Action to perform. \"add\" replaces the existing comment of the cell."),
+		),
+		mcp.WithString("cell",
+			mcp.Description("Cell of the comment (e.g., \"B3\") (required for add and delete)"),
+		),
+		mcp.WithString("text",
+			mcp.Description("Text of the comment (required for add)"),
+		),
+		mcp.WithString("author",
+			mcp.Description("Author of the comment (add only)"),
+		),
+	), handleComments)
+}
+
+func handleComments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelCommentsArguments{}
+	if issues := excelCommentsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if args.Action != "list" {
+		if args.Cell == "" {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("cell is required for %s", args.Action)), nil
+		}
+		if startCol, startRow, endCol, endRow, err := excel.ParseCellOrRange(args.Cell); err != nil || startCol != endCol || startRow != endRow {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid cell: %s", args.Cell)), nil
+		}
+	}
+	if args.Action == "add" && args.Text == "" {
+		return imcp.NewToolResultInvalidArgumentError("text is required for add"), nil
+	}
+	return comments(args)
+}
+
+func comments(args ExcelCommentsArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	message := ""
+	switch args.Action {
+	case "add":
+		err = worksheet.AddComment(&excel.Comment{
+			Cell:   args.Cell,
+			Author: args.Author,
+			Text:   args.Text,
+		})
+		message = fmt.Sprintf("Comment added to %s in sheet [%s].\n", args.Cell, args.SheetName)
+	case "delete":
+		err = worksheet.DeleteComment(args.Cell)
+		message = fmt.Sprintf("Comment deleted from %s in sheet [%s].\n", args.Cell, args.SheetName)
+	}
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if args.Action != "list" {
+		if err := workbook.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	commentList, err := worksheet.GetComments()
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += message
+	result += "# Comments\n"
+	if len(commentList) == 0 {
+		result += "(none)\n"
+	}
+	for _, comment := range commentList {
+		text := strings.ReplaceAll(comment.Text, "\n", "\\n")
+		result += fmt.Sprintf("- %s (%s): %s\n", comment.Cell, comment.Author, text)
+	}
+	return mcp.NewToolResultText(result), nil
+}
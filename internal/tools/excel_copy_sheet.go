@@ -3,7 +3,6 @@ package tools
 import (
 	"context"
 	"fmt"
-	"html"
 
 	z "github.com/Oudwins/zog"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -76,6 +75,6 @@ func copySheet(fileAbsolutePath string, srcSheetName string, dstSheetName string
 
 	result := "# Notice\n"
 	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
-	result += fmt.Sprintf("Sheet [%s] copied to [%s].\n", html.EscapeString(srcSheetName), html.EscapeString(dstSheetName))
+	result += fmt.Sprintf("Sheet [%s] copied to [%s].\n", srcSheetName, dstSheetName)
 	return mcp.NewToolResultText(result), nil
 }
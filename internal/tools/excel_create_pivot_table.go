@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelCreatePivotTableArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	TargetCell       string `zog:"targetCell"`
+	Source           string `zog:"source"`
+	PivotTableName   string `zog:"pivotTableName"`
+}
+
+var excelCreatePivotTableArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"targetCell":       z.String().Required(),
+	"source":           z.String().Required(),
+	"pivotTableName":   z.String(),
+})
+
+// pivotTableFieldsArgument holds the array arguments of the pivot table fields
+type pivotTableFieldsArgument struct {
+	RowFields    []string               `yaml:"rowFields"`
+	ColumnFields []string               `yaml:"columnFields"`
+	FilterFields []string               `yaml:"filterFields"`
+	DataFields   []excel.PivotDataField `yaml:"dataFields"`
+}
+
+var pivotTableFunctionLabels = map[string]string{
+	"sum":     "Sum",
+	"count":   "Count",
+	"average": "Average",
+	"max":     "Max",
+	"min":     "Min",
+}
+
+func AddExcelCreatePivotTableTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_create_pivot_table",
+		mcp.WithDescription("Create a pivot table in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name where the pivot table is placed"),
+		),
+		mcp.WithString("targetCell",
+			mcp.Required(),
+			mcp.Description("Cell where the top-left corner of the pivot table is placed (e.g., \"H2\")"),
+		),
+		mcp.WithString("source",
+			mcp.Required(),
+			mcp.Description("Source data as a range including the header row (e.g., \"Sheet1!A1:E31\"; a range without sheet name refers to sheetName) or a table name"),
+		),
+		mcp.WithString("pivotTableName",
+			mcp.Description("Name of the pivot table [default: PivotTable<n>]"),
+		),
+		mcp.WithArray("rowFields",
+			mcp.Description("Header names of the source columns used as row fields"),
+		),
+		mcp.WithArray("columnFields",
+			mcp.Description("Header names of the source columns used as column fields"),
+		),
+		mcp.WithArray("filterFields",
+			mcp.Description("Header names of the source columns used as filter fields"),
+		),
+		mcp.WithArray("dataFields",
+			mcp.Required(),
+			mcp.Description("Fields to aggregate. Each item is an object {field, function, name}. function is one of sum, count, average, max, min [default: sum]. name defaults to \"<Function> of <field>\"."),
+		),
+	), handleCreatePivotTable)
+}
+
+func handleCreatePivotTable(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelCreatePivotTableArguments{}
+	if issues := excelCreatePivotTableArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+
+	fields, err := parsePivotTableFieldsArgument(request.Params.Arguments)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid fields: %v", err)), nil
+	}
+	if len(fields.DataFields) == 0 {
+		return imcp.NewToolResultInvalidArgumentError("dataFields must be a non-empty array"), nil
+	}
+	for i, dataField := range fields.DataFields {
+		if dataField.Field == "" {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("dataFields[%d]: field is required", i)), nil
+		}
+		if dataField.Function == "" {
+			fields.DataFields[i].Function = "sum"
+		}
+		label, ok := pivotTableFunctionLabels[fields.DataFields[i].Function]
+		if !ok {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("dataFields[%d]: unsupported function: %s", i, dataField.Function)), nil
+		}
+		if dataField.Name == "" {
+			fields.DataFields[i].Name = fmt.Sprintf("%s of %s", label, dataField.Field)
+		}
+	}
+
+	return createPivotTable(args, fields)
+}
+
+func parsePivotTableFieldsArgument(arguments map[string]any) (*pivotTableFieldsArgument, error) {
+	fieldsArg := make(map[string]any)
+	for _, key := range []string{"rowFields", "columnFields", "filterFields", "dataFields"} {
+		if value, ok := arguments[key]; ok {
+			fieldsArg[key] = value
+		}
+	}
+	jsonBytes, err := json.Marshal(fieldsArg)
+	if err != nil {
+		return nil, err
+	}
+	fields := &pivotTableFieldsArgument{}
+	if err := yaml.UnmarshalWithOptions(jsonBytes, fields, yaml.DisallowUnknownField()); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func createPivotTable(args ExcelCreatePivotTableArguments, fields *pivotTableFieldsArgument) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	source := args.Source
+	if _, rangePart := excel.SplitSheetReference(source); strings.Contains(source, "!") || isCellOrRange(rangePart) {
+		if source, err = qualifyRange(args.SheetName, source); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+	}
+	name := args.PivotTableName
+	if name == "" {
+		if name, err = newPivotTableName(workbook); err != nil {
+			return nil, err
+		}
+	}
+
+	options := &excel.PivotTableOptions{
+		Name:         name,
+		SourceRange:  source,
+		TargetCell:   args.TargetCell,
+		RowFields:    fields.RowFields,
+		ColumnFields: fields.ColumnFields,
+		FilterFields: fields.FilterFields,
+		DataFields:   fields.DataFields,
+	}
+	if err := worksheet.AddPivotTable(options); err != nil {
+		if excel.IsInvalidArgument(err) {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		return nil, err
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Pivot table [%s] created at %s in sheet [%s].\n", name, args.TargetCell, args.SheetName)
+	if workbook.GetBackendName() == "excelize" {
+		result += "The pivot table is laid out when the file is opened in Excel, so its range is an estimate until then.\n"
+	}
+	pivotTables, err := worksheet.GetPivotTables()
+	if err != nil {
+		return nil, err
+	}
+	for _, pivotTable := range pivotTables {
+		if pivotTable.Name == name {
+			result += "# Pivot table\n"
+			result += fmt.Sprintf("- range: %s\n", pivotTable.Range)
+			result += fmt.Sprintf("- source: %s\n", pivotTable.SourceRange)
+			result += fmt.Sprintf("- rowFields: %s\n", strings.Join(pivotTable.RowFields, ", "))
+			result += fmt.Sprintf("- columnFields: %s\n", strings.Join(pivotTable.ColumnFields, ", "))
+			result += fmt.Sprintf("- filterFields: %s\n", strings.Join(pivotTable.FilterFields, ", "))
+			for _, dataField := range pivotTable.DataFields {
+				result += fmt.Sprintf("- dataField: %s (%s of %s)\n", dataField.Name, dataField.Function, dataField.Field)
+			}
+		}
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+func isCellOrRange(reference string) bool {
+	_, _, _, _, err := excel.ParseCellOrRange(reference)
+	return err == nil
+}
+
+// newPivotTableName returns a pivot table name which is not used in the workbook
+func newPivotTableName(workbook excel.Excel) (string, error) {
+	worksheets, err := workbook.GetSheets()
+	if err != nil {
+		return "", err
+	}
+	names := make(map[string]bool)
+	for _, worksheet := range worksheets {
+		defer worksheet.Release()
+		pivotTables, err := worksheet.GetPivotTables()
+		if err != nil {
+			return "", err
+		}
+		for _, pivotTable := range pivotTables {
+			names[strings.ToLower(pivotTable.Name)] = true
+		}
+	}
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("PivotTable%d", i)
+		if !names[strings.ToLower(name)] {
+			return name, nil
+		}
+	}
+}
@@ -3,7 +3,6 @@ package tools
 import (
 	"context"
 	"fmt"
-	"html"
 
 	z "github.com/Oudwins/zog"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -76,6 +75,6 @@ func createTable(fileAbsolutePath string, sheetName string, tableRange string, t
 
 	result := "# Notice\n"
 	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
-	result += fmt.Sprintf("Table [%s] created.\n", html.EscapeString(tableName))
+	result += fmt.Sprintf("Table [%s] created.\n", tableName)
 	return mcp.NewToolResultText(result), nil
 }
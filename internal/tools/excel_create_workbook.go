@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelCreateWorkbookArguments struct {
+	FileAbsolutePath     string   `zog:"fileAbsolutePath"`
+	SheetNames           []string `zog:"sheetNames"`
+	TemplateAbsolutePath string   `zog:"templateAbsolutePath"`
+	Overwrite            bool     `zog:"overwrite"`
+}
+
+var excelCreateWorkbookArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath":     z.String().Test(AbsolutePathTest()).Required(),
+	"sheetNames":           z.Slice(z.String().Min(1).Max(31)),
+	"templateAbsolutePath": z.String().Test(AbsolutePathTest()),
+	"overwrite":            z.Bool().Default(false),
+})
+
+func AddExcelCreateWorkbookTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_create_workbook",
+		mcp.WithDescription("Create a new Excel file (.xlsx or .xlsm) with the initial sheets, optionally from a template"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file to create. The extension must be .xlsx or .xlsm."),
+		),
+		mcp.WithArray("sheetNames",
+			mcp.Items(map[string]any{"type": "string"}),
+			mcp.Description("Names of the sheets to create in order. With a template, the sheets are added after the sheets of the template. [default: [\"Sheet1\"] without a template]"),
+		),
+		mcp.WithString("templateAbsolutePath",
+			mcp.Description("Absolute path to a template (.xltx, or .xltm for .xlsm files) to create the file from"),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace the file if it already exists [default: false]"),
+		),
+	), handleCreateWorkbook)
+}
+
+func handleCreateWorkbook(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelCreateWorkbookArguments{}
+	if issues := excelCreateWorkbookArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if len(args.SheetNames) == 0 && args.TemplateAbsolutePath == "" {
+		args.SheetNames = []string{"Sheet1"}
+	}
+	return createWorkbook(args)
+}
+
+func createWorkbook(args ExcelCreateWorkbookArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.CreateFile(args.FileAbsolutePath, &excel.CreateFileOptions{
+		SheetNames:   args.SheetNames,
+		TemplatePath: args.TemplateAbsolutePath,
+		Overwrite:    args.Overwrite,
+	})
+	if err != nil {
+		if excel.IsInvalidArgument(err) {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		return nil, err
+	}
+	defer release()
+
+	worksheets, err := workbook.GetSheets()
+	if err != nil {
+		return nil, err
+	}
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("File [%s] created.\n", args.FileAbsolutePath)
+	result += "# Sheets\n"
+	for _, worksheet := range worksheets {
+		defer worksheet.Release()
+		name, err := worksheet.Name()
+		if err != nil {
+			return nil, err
+		}
+		result += fmt.Sprintf("- %s\n", name)
+	}
+	return mcp.NewToolResultText(result), nil
+}
@@ -40,15 +40,28 @@ func handleDescribeSheets(ctx context.Context, request mcp.CallToolRequest) (*mc
 }
 
 type Response struct {
-	Backend string      `json:"backend"`
-	Sheets  []Worksheet `json:"sheets"`
+	Backend      string        `json:"backend"`
+	Sheets       []Worksheet   `json:"sheets"`
+	DefinedNames []DefinedName `json:"definedNames"`
 }
 type Worksheet struct {
-	Name         string       `json:"name"`
-	UsedRange    string       `json:"usedRange"`
-	Tables       []Table      `json:"tables"`
-	PivotTables  []PivotTable `json:"pivotTables"`
-	PagingRanges []string     `json:"pagingRanges"`
+	Name          string       `json:"name"`
+	UsedRange     string       `json:"usedRange"`
+	FrozenRows    int          `json:"frozenRows"`
+	FrozenColumns int          `json:"frozenColumns"`
+	Tables        []Table      `json:"tables"`
+	PivotTables   []PivotTable `json:"pivotTables"`
+	Charts        []Chart      `json:"charts"`
+	Pictures      []Picture    `json:"pictures"`
+	MergedCells   []string     `json:"mergedCells"`
+	PagingRanges  []string     `json:"pagingRanges"`
+}
+
+type DefinedName struct {
+	Name     string `json:"name"`
+	RefersTo string `json:"refersTo"`
+	Scope    string `json:"scope,omitempty"`
+	Comment  string `json:"comment,omitempty"`
 }
 
 type Table struct {
@@ -57,8 +70,39 @@ type Table struct {
 }
 
 type PivotTable struct {
-	Name  string `json:"name"`
-	Range string `json:"range"`
+	Name         string           `json:"name"`
+	Range        string           `json:"range"`
+	SourceRange  string           `json:"sourceRange"`
+	RowFields    []string         `json:"rowFields"`
+	ColumnFields []string         `json:"columnFields"`
+	FilterFields []string         `json:"filterFields"`
+	DataFields   []PivotDataField `json:"dataFields"`
+}
+
+type PivotDataField struct {
+	Field    string `json:"field"`
+	Function string `json:"function"`
+	Name     string `json:"name"`
+}
+
+type Chart struct {
+	Name       string        `json:"name"`
+	Type       string        `json:"type"`
+	Title      string        `json:"title,omitempty"`
+	AnchorCell string        `json:"anchorCell"`
+	Series     []ChartSeries `json:"series"`
+}
+
+type Picture struct {
+	Name       string `json:"name"`
+	AnchorCell string `json:"anchorCell"`
+	AltText    string `json:"altText,omitempty"`
+}
+
+type ChartSeries struct {
+	Name       string `json:"name,omitempty"`
+	Categories string `json:"categories,omitempty"`
+	Values     string `json:"values"`
 }
 
 func describeSheets(fileAbsolutePath string) (*mcp.CallToolResult, error) {
@@ -112,11 +156,67 @@ func describeSheets(fileAbsolutePath string) (*mcp.CallToolResult, error) {
 		}
 		pivotTableList := make([]PivotTable, len(pivotTables))
 		for i, pivotTable := range pivotTables {
+			dataFields := make([]PivotDataField, len(pivotTable.DataFields))
+			for j, dataField := range pivotTable.DataFields {
+				dataFields[j] = PivotDataField{
+					Field:    dataField.Field,
+					Function: dataField.Function,
+					Name:     dataField.Name,
+				}
+			}
 			pivotTableList[i] = PivotTable{
-				Name:  pivotTable.Name,
-				Range: pivotTable.Range,
+				Name:         pivotTable.Name,
+				Range:        pivotTable.Range,
+				SourceRange:  pivotTable.SourceRange,
+				RowFields:    pivotTable.RowFields,
+				ColumnFields: pivotTable.ColumnFields,
+				FilterFields: pivotTable.FilterFields,
+				DataFields:   dataFields,
 			}
 		}
+		charts, err := sheet.GetCharts()
+		if err != nil {
+			return nil, err
+		}
+		chartList := make([]Chart, len(charts))
+		for i, chart := range charts {
+			seriesList := make([]ChartSeries, len(chart.Series))
+			for j, series := range chart.Series {
+				seriesList[j] = ChartSeries{
+					Name:       series.Name,
+					Categories: series.Categories,
+					Values:     series.Values,
+				}
+			}
+			chartList[i] = Chart{
+				Name:       chart.Name,
+				Type:       chart.Type,
+				Title:      chart.Title,
+				AnchorCell: chart.AnchorCell,
+				Series:     seriesList,
+			}
+		}
+		pictures, err := sheet.GetPictures()
+		if err != nil {
+			return nil, err
+		}
+		pictureList := make([]Picture, len(pictures))
+		for i, picture := range pictures {
+			pictureList[i] = Picture{
+				Name:       picture.Name,
+				AnchorCell: picture.AnchorCell,
+				AltText:    picture.AltText,
+			}
+		}
+		mergedCells, err := sheet.GetMergedCells()
+		if err != nil {
+			return nil, err
+		}
+		// The panes of hidden sheets are not available on OLE, so they are reported as not frozen
+		frozenRows, frozenColumns := 0, 0
+		if panes, err := sheet.GetPanes(); err == nil && panes.Mode == excel.PanesFreeze {
+			frozenRows, frozenColumns = panes.Rows, panes.Columns
+		}
 		var pagingRanges []string
 		strategy, err := sheet.GetPagingStrategy(config.EXCEL_MCP_PAGING_CELLS_LIMIT)
 		if err == nil {
@@ -124,16 +224,35 @@ func describeSheets(fileAbsolutePath string) (*mcp.CallToolResult, error) {
 			pagingRanges = pagingService.GetPagingRanges()
 		}
 		worksheets[i] = Worksheet{
-			Name:         name,
-			UsedRange:    usedRange,
-			Tables:       tableList,
-			PivotTables:  pivotTableList,
-			PagingRanges: pagingRanges,
+			Name:          name,
+			UsedRange:     usedRange,
+			FrozenRows:    frozenRows,
+			FrozenColumns: frozenColumns,
+			Tables:        tableList,
+			PivotTables:   pivotTableList,
+			Charts:        chartList,
+			Pictures:      pictureList,
+			MergedCells:   mergedCells,
+			PagingRanges:  pagingRanges,
+		}
+	}
+	definedNames, err := workbook.GetDefinedNames()
+	if err != nil {
+		return nil, err
+	}
+	definedNameList := make([]DefinedName, len(definedNames))
+	for i, definedName := range definedNames {
+		definedNameList[i] = DefinedName{
+			Name:     definedName.Name,
+			RefersTo: definedName.RefersTo,
+			Scope:    definedName.Scope,
+			Comment:  definedName.Comment,
 		}
 	}
 	response := Response{
-		Backend: workbook.GetBackendName(),
-		Sheets:  worksheets,
+		Backend:      workbook.GetBackendName(),
+		Sheets:       worksheets,
+		DefinedNames: definedNameList,
 	}
 	jsonBytes, err := json.MarshalIndent(response, "", "  ")
 	if err != nil {
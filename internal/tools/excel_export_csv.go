@@ -0,0 +1,171 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelExportCSVArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	CsvAbsolutePath  string `zog:"csvAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Range            string `zog:"range"`
+	Table            string `zog:"table"`
+	Values           string `zog:"values"`
+	Delimiter        string `zog:"delimiter"`
+	Quoting          string `zog:"quoting"`
+	LineEnding       string `zog:"lineEnding"`
+	Overwrite        bool   `zog:"overwrite"`
+}
+
+var excelExportCSVArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"csvAbsolutePath":  z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"range":            z.String(),
+	"table":            z.String(),
+	"values":           z.String().OneOf([]string{"formatted", "raw"}).Default("formatted"),
+	"delimiter":        z.String(),
+	"quoting":          z.String().OneOf([]string{"minimal", "all", "nonnumeric"}).Default("minimal"),
+	"lineEnding":       z.String().OneOf([]string{"crlf", "lf"}).Default("crlf"),
+	"overwrite":        z.Bool().Default(false),
+})
+
+func AddExcelExportCSVTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_export_csv",
+		mcp.WithDescription("Export a range, a table or the whole sheet of the Excel file to a CSV or TSV file encoded in UTF-8"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("csvAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the CSV or TSV file to write"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("range",
+			mcp.Description("Range to export (e.g., \"A1:D100\"). Exports the used range of the sheet if neither range nor table is given."),
+		),
+		mcp.WithString("table",
+			mcp.Description("Table name to export, including its header row"),
+		),
+		mcp.WithString("values",
+			mcp.Enum("formatted", "raw"),
+			mcp.Description("Export the values as displayed with their number formats (formatted), or the underlying values such as date serial numbers (raw) [default: formatted]"),
+		),
+		mcp.WithString("delimiter",
+			mcp.Description("Field delimiter character (e.g., \",\", \";\", \"|\") or \"tab\". Defaults to tab for .tsv files and comma otherwise."),
+		),
+		mcp.WithString("quoting",
+			mcp.Enum("minimal", "all", "nonnumeric"),
+			mcp.Description("Fields to quote: only fields containing the delimiter, quotes or line breaks (minimal), all fields (all), or all fields except numbers (nonnumeric) [default: minimal]"),
+		),
+		mcp.WithString("lineEnding",
+			mcp.Enum("crlf", "lf"),
+			mcp.Description("Line ending of the rows [default: crlf]"),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace the CSV file if it already exists [default: false]"),
+		),
+	), handleExportCSV)
+}
+
+func handleExportCSV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelExportCSVArguments{}
+	if issues := excelExportCSVArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if args.Range != "" && args.Table != "" {
+		return imcp.NewToolResultInvalidArgumentError("range and table cannot be specified together"), nil
+	}
+	args.Range = strings.ToUpper(args.Range)
+	if args.Range != "" {
+		if _, _, _, _, err := excel.ParseCellOrRange(args.Range); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid range: %s", args.Range)), nil
+		}
+	}
+	if _, err := os.Stat(args.CsvAbsolutePath); err == nil && !args.Overwrite {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("file already exists: %s", args.CsvAbsolutePath)), nil
+	}
+	if args.Delimiter == "" {
+		args.Delimiter = ","
+		if strings.EqualFold(filepath.Ext(args.CsvAbsolutePath), ".tsv") {
+			args.Delimiter = "tab"
+		}
+	}
+	delimiter, err := excel.ParseCSVDelimiter(args.Delimiter)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	return exportCSV(args, delimiter)
+}
+
+func exportCSV(args ExcelExportCSVArguments, delimiter rune) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	exportRange := args.Range
+	if args.Table != "" {
+		tables, err := worksheet.GetTables()
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			if strings.EqualFold(table.Name, args.Table) {
+				exportRange = table.Range
+			}
+		}
+		if exportRange == "" {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("table not found in sheet %s: %s", args.SheetName, args.Table)), nil
+		}
+	}
+	if exportRange == "" {
+		exportRange, err = worksheet.GetDimention()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lineEnding := "\r\n"
+	if args.LineEnding == "lf" {
+		lineEnding = "\n"
+	}
+	rows, columns, err := excel.ExportCSV(worksheet, &excel.CSVExportOptions{
+		Path:       args.CsvAbsolutePath,
+		Range:      exportRange,
+		Delimiter:  delimiter,
+		Raw:        args.Values == "raw",
+		Quoting:    excel.CSVQuoting(args.Quoting),
+		LineEnding: lineEnding,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Exported %d rows and %d columns in range [%s] of sheet [%s] to [%s] with %s values.\n",
+		rows, columns, exportRange, args.SheetName, args.CsvAbsolutePath, args.Values)
+	return mcp.NewToolResultText(result), nil
+}
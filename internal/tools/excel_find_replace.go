@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelFindReplaceArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	Find             string `zog:"find"`
+	Replace          string `zog:"replace"`
+	Mode             string `zog:"mode"`
+	Target           string `zog:"target"`
+	SheetName        string `zog:"sheetName"`
+	Range            string `zog:"range"`
+	MatchCase        bool   `zog:"matchCase"`
+	WholeCell        bool   `zog:"wholeCell"`
+	DryRun           bool   `zog:"dryRun"`
+}
+
+var excelFindReplaceArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"find":             z.String().Required(),
+	"replace":          z.String().Default(""),
+	"mode":             z.String().OneOf([]string{"literal", "regex"}).Default("literal"),
+	"target":           z.String().OneOf([]string{"values", "formulas", "both"}).Default("values"),
+	"sheetName":        z.String(),
+	"range":            z.String(),
+	"matchCase":        z.Bool().Default(false),
+	"wholeCell":        z.Bool().Default(false),
+	"dryRun":           z.Bool().Default(false),
+})
+
+func AddExcelFindReplaceTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_find_replace",
+		mcp.WithDescription("Find and replace text in the values and/or formulas of cells in a range, a sheet or the whole Excel file"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("find",
+			mcp.Required(),
+			mcp.Description("Text or regular expression (RE2 syntax) to find"),
+		),
+		mcp.WithString("replace",
+			mcp.Description("Replacement text. In regex mode, $1 or ${name} refers to a submatch. [default: \"\"]"),
+		),
+		mcp.WithString("mode",
+			mcp.Enum("literal", "regex"),
+			mcp.Description("How to interpret find [default: literal]"),
+		),
+		mcp.WithString("target",
+			mcp.Enum("values", "formulas", "both"),
+			mcp.Description("Where to replace. Formulas are matched with the leading \"=\" (e.g., find \"OldSheet!\" to repoint references). Calculated values of formulas are never replaced, and only text values are replaced. [default: values]"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Description("Sheet name to replace in. Defaults to all sheets."),
+		),
+		mcp.WithString("range",
+			mcp.Description("Range to replace in (e.g., \"A1:D100\"). Requires sheetName."),
+		),
+		mcp.WithBoolean("matchCase",
+			mcp.Description("Match letter case [default: false]"),
+		),
+		mcp.WithBoolean("wholeCell",
+			mcp.Description("Replace only cells whose whole content matches [default: false]"),
+		),
+		mcp.WithBoolean("dryRun",
+			mcp.Description("List the cells that would change with their before and after texts without saving the file [default: false]"),
+		),
+	), handleFindReplace)
+}
+
+func handleFindReplace(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelFindReplaceArguments{}
+	if issues := excelFindReplaceArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if args.Find == "" {
+		return imcp.NewToolResultInvalidArgumentError("find must not be empty"), nil
+	}
+	if args.Range != "" {
+		if args.SheetName == "" {
+			return imcp.NewToolResultInvalidArgumentError("sheetName is required with range"), nil
+		}
+		if _, _, _, _, err := excel.ParseCellOrRange(args.Range); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid range: %s", args.Range)), nil
+		}
+	}
+	options := &excel.ReplaceOptions{
+		SearchOptions: excel.SearchOptions{
+			Query:     args.Find,
+			Mode:      excel.SearchMode(args.Mode),
+			Target:    excel.SearchTarget(args.Target),
+			Range:     args.Range,
+			MatchCase: args.MatchCase,
+			WholeCell: args.WholeCell,
+		},
+		Replacement: args.Replace,
+		DryRun:      args.DryRun,
+	}
+	if err := options.Validate(); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	return findReplace(args, options)
+}
+
+func findReplace(args ExcelFindReplaceArguments, options *excel.ReplaceOptions) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var worksheets []excel.Worksheet
+	if args.SheetName != "" {
+		worksheet, err := workbook.FindSheet(args.SheetName)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		worksheets = []excel.Worksheet{worksheet}
+	} else if worksheets, err = workbook.GetSheets(); err != nil {
+		return nil, err
+	}
+
+	total := 0
+	changes := ""
+	for _, worksheet := range worksheets {
+		defer worksheet.Release()
+		sheetName, err := worksheet.Name()
+		if err != nil {
+			return nil, err
+		}
+		replacements, err := excel.ReplaceCells(worksheet, options)
+		if err != nil {
+			return nil, err
+		}
+		if len(replacements) == 0 {
+			continue
+		}
+		total += len(replacements)
+		changes += fmt.Sprintf("# %s\n", sheetName)
+		for _, replacement := range replacements {
+			kind := "value"
+			if replacement.Formula {
+				kind = "formula"
+			}
+			changes += fmt.Sprintf("- %s (%s): %s -> %s\n", replacement.Cell, kind, replacement.Before, replacement.After)
+		}
+	}
+	if total > 0 && !args.DryRun {
+		if err := workbook.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	if args.DryRun {
+		result += fmt.Sprintf("Dry run: %d cells would change. The file is not modified.\n", total)
+	} else {
+		result += fmt.Sprintf("%d cells changed.\n", total)
+	}
+	result += changes
+	return mcp.NewToolResultText(result), nil
+}
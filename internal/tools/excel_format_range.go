@@ -0,0 +1,109 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelFormatRangeArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Range            string `zog:"range"`
+}
+
+var excelFormatRangeArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"range":            z.String().Required(),
+})
+
+func AddExcelFormatRangeTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_format_range",
+		mcp.WithDescription("Apply style to a range of cells. The style is merged with the existing style of each cell."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description("Range of cells to format (e.g., \"A1:C10\")"),
+		),
+		mcp.WithObject("style",
+			mcp.Required(),
+			mcp.Description("Style to apply. Same structure as the style definitions shown by excel_read_sheet with showStyle (border, font, fill, numFmt, decimalPlaces)"),
+		),
+	), handleFormatRange)
+}
+
+func handleFormatRange(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelFormatRangeArguments{}
+	if issues := excelFormatRangeArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+
+	styleArg, ok := request.Params.Arguments["style"].(map[string]any)
+	if !ok {
+		return imcp.NewToolResultInvalidArgumentError("style must be an object"), nil
+	}
+	style, err := parseCellStyle(styleArg)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid style: %v", err)), nil
+	}
+
+	return formatRange(args.FileAbsolutePath, args.SheetName, args.Range, style)
+}
+
+// parseCellStyle converts the style argument into CellStyle using the same keys as its YAML representation
+func parseCellStyle(styleArg map[string]any) (*excel.CellStyle, error) {
+	jsonBytes, err := json.Marshal(styleArg)
+	if err != nil {
+		return nil, err
+	}
+	style := &excel.CellStyle{}
+	if err := yaml.UnmarshalWithOptions(jsonBytes, style, yaml.DisallowUnknownField()); err != nil {
+		return nil, err
+	}
+	return style, nil
+}
+
+func formatRange(fileAbsolutePath string, sheetName string, cellRange string, style *excel.CellStyle) (*mcp.CallToolResult, error) {
+	if _, _, _, _, err := excel.ParseCellOrRange(cellRange); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	workbook, release, err := excel.OpenFile(fileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(sheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	if err := worksheet.SetCellStyle(cellRange, style); err != nil {
+		return nil, err
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Style applied to range %s in sheet [%s].\n", cellRange, sheetName)
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelGetPictureArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	PictureName      string `zog:"pictureName"`
+}
+
+var excelGetPictureArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"pictureName":      z.String().Required(),
+})
+
+func AddExcelGetPictureTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_get_picture",
+		mcp.WithDescription("Get the image of a picture placed in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("pictureName",
+			mcp.Required(),
+			mcp.Description("Name of the picture listed by excel_describe_sheets"),
+		),
+	), handleGetPicture)
+}
+
+func handleGetPicture(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelGetPictureArguments{}
+	if issues := excelGetPictureArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return getPicture(args)
+}
+
+func getPicture(args ExcelGetPictureArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	data, format, err := worksheet.GetPictureData(args.PictureName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	text := "# Metadata\n"
+	text += fmt.Sprintf("- backend: %s\n", workbook.GetBackendName())
+	text += fmt.Sprintf("- sheet name: %s\n", args.SheetName)
+	text += fmt.Sprintf("- picture name: %s\n", args.PictureName)
+	text += fmt.Sprintf("- format: %s\n", format)
+	if workbook.GetBackendName() == "ole" {
+		text += "# Notice\n"
+		text += "The picture is captured as it is displayed in the sheet.\n"
+	}
+	return mcp.NewToolResultImage(
+		text,
+		base64.StdEncoding.EncodeToString(data),
+		"image/"+format,
+	), nil
+}
@@ -0,0 +1,157 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelImportCSVArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	CsvAbsolutePath  string `zog:"csvAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	NewSheet         bool   `zog:"newSheet"`
+	StartCell        string `zog:"startCell"`
+	Delimiter        string `zog:"delimiter"`
+	Encoding         string `zog:"encoding"`
+	HasHeader        bool   `zog:"hasHeader"`
+	InferTypes       bool   `zog:"inferTypes"`
+}
+
+var excelImportCSVArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"csvAbsolutePath":  z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"newSheet":         z.Bool().Default(false),
+	"startCell":        z.String().Default("A1"),
+	"delimiter":        z.String(),
+	"encoding":         z.String().OneOf([]string{"utf-8", "shift_jis", "utf-16"}).Default("utf-8"),
+	"hasHeader":        z.Bool().Default(true),
+	"inferTypes":       z.Bool().Default(true),
+})
+
+func AddExcelImportCSVTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_import_csv",
+		mcp.WithDescription("Import a CSV or TSV file into a new or existing sheet of the Excel file"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("csvAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the CSV or TSV file to import"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name to import into"),
+		),
+		mcp.WithBoolean("newSheet",
+			mcp.Description("Create a new sheet if true, otherwise import into the existing sheet [default: false]"),
+		),
+		mcp.WithString("startCell",
+			mcp.Description("Top-left cell to import into [default: A1]"),
+		),
+		mcp.WithString("delimiter",
+			mcp.Description("Field delimiter character (e.g., \",\", \";\", \"|\") or \"tab\". Defaults to tab for .tsv files and comma otherwise."),
+		),
+		mcp.WithString("encoding",
+			mcp.Enum("utf-8", "shift_jis", "utf-16"),
+			mcp.Description("Character encoding of the file. UTF-16 files must start with a BOM. [default: utf-8]"),
+		),
+		mcp.WithBoolean("hasHeader",
+			mcp.Description("The first row is a header row, which is imported as text [default: true]"),
+		),
+		mcp.WithBoolean("inferTypes",
+			mcp.Description("Import numbers, booleans (TRUE/FALSE) and dates (e.g., 2024-01-31, 2024/01/31 12:00) as typed values. Numbers with leading zeros stay text. Imports all fields as text if false. [default: true]"),
+		),
+	), handleImportCSV)
+}
+
+func handleImportCSV(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelImportCSVArguments{}
+	if issues := excelImportCSVArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if _, _, err := excelize.CellNameToCoordinates(args.StartCell); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid startCell: %s", args.StartCell)), nil
+	}
+	if _, err := os.Stat(args.CsvAbsolutePath); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("CSV file not found: %s", args.CsvAbsolutePath)), nil
+	}
+	if args.Delimiter == "" {
+		args.Delimiter = ","
+		if strings.EqualFold(filepath.Ext(args.CsvAbsolutePath), ".tsv") {
+			args.Delimiter = "tab"
+		}
+	}
+	delimiter, err := excel.ParseCSVDelimiter(args.Delimiter)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	return importCSV(args, delimiter)
+}
+
+func importCSV(args ExcelImportCSVArguments, delimiter rune) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if args.NewSheet {
+		if err := workbook.CreateNewSheet(args.SheetName); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+	}
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	importedRange, err := worksheet.ImportCSV(&excel.CSVImportOptions{
+		Path:       args.CsvAbsolutePath,
+		Delimiter:  delimiter,
+		Encoding:   excel.CSVEncoding(args.Encoding),
+		StartCell:  strings.ToUpper(args.StartCell),
+		HasHeader:  args.HasHeader,
+		InferTypes: args.InferTypes,
+	})
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	startCol, startRow, endCol, endRow, err := excel.ParseCellOrRange(importedRange)
+	if err != nil {
+		return nil, err
+	}
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Imported %d rows and %d columns from [%s] into range [%s] of sheet [%s].\n",
+		endRow-startRow+1, endCol-startCol+1, args.CsvAbsolutePath, importedRange, args.SheetName)
+	if args.HasHeader {
+		headers := []string{}
+		for col := startCol; col <= endCol; col++ {
+			cell, _ := excelize.CoordinatesToCellName(col, startRow)
+			header, err := worksheet.GetValue(cell)
+			if err != nil {
+				return nil, err
+			}
+			headers = append(headers, header)
+		}
+		result += fmt.Sprintf("Header: %s\n", strings.Join(headers, ", "))
+	}
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelInsertPictureArguments struct {
+	FileAbsolutePath  string  `zog:"fileAbsolutePath"`
+	SheetName         string  `zog:"sheetName"`
+	AnchorCell        string  `zog:"anchorCell"`
+	ImageBase64       string  `zog:"imageBase64"`
+	ImageAbsolutePath string  `zog:"imageAbsolutePath"`
+	ScaleX            float64 `zog:"scaleX"`
+	ScaleY            float64 `zog:"scaleY"`
+	OffsetX           int     `zog:"offsetX"`
+	OffsetY           int     `zog:"offsetY"`
+	AltText           string  `zog:"altText"`
+}
+
+var excelInsertPictureArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath":  z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":         z.String().Required(),
+	"anchorCell":        z.String().Required(),
+	"imageBase64":       z.String(),
+	"imageAbsolutePath": z.String(),
+	"scaleX":            z.Float().GT(0).Default(1),
+	"scaleY":            z.Float().GT(0).Default(1),
+	"offsetX":           z.Int().GTE(0).Default(0),
+	"offsetY":           z.Int().GTE(0).Default(0),
+	"altText":           z.String(),
+})
+
+// pictureFormats maps the detected content types to the supported picture formats
+var pictureFormats = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpeg",
+}
+
+func AddExcelInsertPictureTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_insert_picture",
+		mcp.WithDescription("Insert a picture (PNG or JPEG) into the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name where the picture is placed"),
+		),
+		mcp.WithString("anchorCell",
+			mcp.Required(),
+			mcp.Description("Cell where the top-left corner of the picture is placed (e.g., \"E2\")"),
+		),
+		mcp.WithString("imageBase64",
+			mcp.Description("Base64 encoded image data. Either imageBase64 or imageAbsolutePath is required."),
+		),
+		mcp.WithString("imageAbsolutePath",
+			mcp.Description("Absolute path to the image file. Either imageBase64 or imageAbsolutePath is required."),
+		),
+		mcp.WithNumber("scaleX",
+			mcp.Description("Horizontal scale of the picture [default: 1]"),
+		),
+		mcp.WithNumber("scaleY",
+			mcp.Description("Vertical scale of the picture [default: 1]"),
+		),
+		mcp.WithNumber("offsetX",
+			mcp.Description("Horizontal offset from the anchor cell in pixels [default: 0]"),
+		),
+		mcp.WithNumber("offsetY",
+			mcp.Description("Vertical offset from the anchor cell in pixels [default: 0]"),
+		),
+		mcp.WithString("altText",
+			mcp.Description("Alternative text of the picture"),
+		),
+	), handleInsertPicture)
+}
+
+func handleInsertPicture(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelInsertPictureArguments{}
+	if issues := excelInsertPictureArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if startCol, startRow, endCol, endRow, err := excel.ParseCellOrRange(args.AnchorCell); err != nil || startCol != endCol || startRow != endRow {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid anchor cell: %s", args.AnchorCell)), nil
+	}
+
+	data, err := loadPictureData(args.ImageBase64, args.ImageAbsolutePath)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	format, ok := pictureFormats[http.DetectContentType(data)]
+	if !ok {
+		return imcp.NewToolResultInvalidArgumentError("unsupported image format: only PNG and JPEG are supported"), nil
+	}
+
+	return insertPicture(args, &excel.PictureOptions{
+		Data:       data,
+		Format:     format,
+		AnchorCell: args.AnchorCell,
+		ScaleX:     args.ScaleX,
+		ScaleY:     args.ScaleY,
+		OffsetX:    args.OffsetX,
+		OffsetY:    args.OffsetY,
+		AltText:    args.AltText,
+	})
+}
+
+// loadPictureData decodes the base64 image data or reads the image file
+func loadPictureData(imageBase64 string, imageAbsolutePath string) ([]byte, error) {
+	switch {
+	case imageBase64 != "" && imageAbsolutePath != "":
+		return nil, fmt.Errorf("specify either imageBase64 or imageAbsolutePath, not both")
+	case imageBase64 != "":
+		// Accept data URLs (e.g., data:image/png;base64,...)
+		if index := strings.Index(imageBase64, ";base64,"); index >= 0 && strings.HasPrefix(imageBase64, "data:") {
+			imageBase64 = imageBase64[index+len(";base64,"):]
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(imageBase64))
+		if err != nil {
+			return nil, fmt.Errorf("invalid imageBase64: %w", err)
+		}
+		return data, nil
+	case imageAbsolutePath != "":
+		if !filepath.IsAbs(imageAbsolutePath) {
+			return nil, fmt.Errorf("path '%s' is not absolute", imageAbsolutePath)
+		}
+		data, err := os.ReadFile(imageAbsolutePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image file: %w", err)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("either imageBase64 or imageAbsolutePath is required")
+	}
+}
+
+func insertPicture(args ExcelInsertPictureArguments, options *excel.PictureOptions) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	if err := worksheet.AddPicture(options); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("%s picture inserted at %s in sheet [%s].\n", strings.ToUpper(options.Format), args.AnchorCell, args.SheetName)
+	pictures, err := worksheet.GetPictures()
+	if err != nil {
+		return nil, err
+	}
+	result += "# Pictures\n"
+	for _, picture := range pictures {
+		result += fmt.Sprintf("- %s (anchor: %s)\n", picture.Name, picture.AnchorCell)
+	}
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelManageNamesArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	Action           string `zog:"action"`
+	Name             string `zog:"name"`
+	RefersTo         string `zog:"refersTo"`
+	Scope            string `zog:"scope"`
+	Comment          string `zog:"comment"`
+}
+
+var excelManageNamesArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"action":           z.String().OneOf([]string{"list", "set", "delete"}).Required(),
+	"name":             z.String(),
+	"refersTo":         z.String(),
+	"scope":            z.String(),
+	"comment":          z.String(),
+})
+
+func AddExcelManageNamesTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_manage_names",
+		mcp.WithDescription("List, create, update or delete defined names in the Excel file"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum("list", "set", "delete"),
+			mcp.Description("Action to perform. \"set\" creates the name, or updates it if it already exists in the scope."),
+		),
+		mcp.WithString("name",
+			mcp.Description("Defined name (required for set and delete)"),
+		),
+		mcp.WithString("refersTo",
+			mcp.Description("Range, constant or formula the name refers to (e.g., \"Sheet1!$A$1:$C$10\") (required for set)"),
+		),
+		mcp.WithString("scope",
+			mcp.Description("Sheet name for a sheet-scoped name [default: workbook scope]"),
+		),
+		mcp.WithString("comment",
+			mcp.Description("Comment of the name (set only)"),
+		),
+	), handleManageNames)
+}
+
+func handleManageNames(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelManageNamesArguments{}
+	if issues := excelManageNamesArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if args.Action != "list" && args.Name == "" {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("name is required for %s", args.Action)), nil
+	}
+	if args.Action == "set" && args.RefersTo == "" {
+		return imcp.NewToolResultInvalidArgumentError("refersTo is required for set"), nil
+	}
+	return manageNames(args)
+}
+
+func manageNames(args ExcelManageNamesArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	message := ""
+	switch args.Action {
+	case "set":
+		err = workbook.SetDefinedName(&excel.DefinedName{
+			Name:     args.Name,
+			RefersTo: args.RefersTo,
+			Scope:    args.Scope,
+			Comment:  args.Comment,
+		})
+		message = fmt.Sprintf("Name [%s] set to %s.\n", args.Name, strings.TrimPrefix(args.RefersTo, "="))
+	case "delete":
+		err = workbook.DeleteDefinedName(args.Name, args.Scope)
+		message = fmt.Sprintf("Name [%s] deleted.\n", args.Name)
+	}
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if args.Action != "list" {
+		if err := workbook.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	definedNames, err := workbook.GetDefinedNames()
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += message
+	result += "# Defined names\n"
+	if len(definedNames) == 0 {
+		result += "(none)\n"
+	}
+	for _, definedName := range definedNames {
+		scope := "workbook"
+		if definedName.Scope != "" {
+			scope = fmt.Sprintf("sheet [%s]", definedName.Scope)
+		}
+		line := fmt.Sprintf("- %s: %s (scope: %s)", definedName.Name, definedName.RefersTo, scope)
+		if definedName.Comment != "" {
+			line += fmt.Sprintf(" // %s", definedName.Comment)
+		}
+		result += line + "\n"
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// findDefinedName finds the defined name visible from the sheet, preferring the sheet-scoped name
+func findDefinedName(workbook excel.Excel, sheetName string, name string) (*excel.DefinedName, error) {
+	definedNames, err := workbook.GetDefinedNames()
+	if err != nil {
+		return nil, err
+	}
+	var found *excel.DefinedName
+	for i, definedName := range definedNames {
+		if !strings.EqualFold(definedName.Name, name) {
+			continue
+		}
+		if strings.EqualFold(definedName.Scope, sheetName) {
+			return &definedNames[i], nil
+		}
+		if definedName.Scope == "" {
+			found = &definedNames[i]
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelManageSheetArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	Action           string `zog:"action"`
+	SheetName        string `zog:"sheetName"`
+	NewName          string `zog:"newName"`
+	Position         int    `zog:"position"`
+	Visibility       string `zog:"visibility"`
+	TabColor         string `zog:"tabColor"`
+	Force            bool   `zog:"force"`
+}
+
+var excelManageSheetArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"action":           z.String().OneOf([]string{"list", "rename", "delete", "move", "setVisibility", "setTabColor"}).Required(),
+	"sheetName":        z.String(),
+	"newName":          z.String(),
+	"position":         z.Int().GT(0),
+	"visibility":       z.String().OneOf([]string{string(excel.SheetVisible), string(excel.SheetHidden), string(excel.SheetVeryHidden)}),
+	"tabColor":         z.String(),
+	"force":            z.Bool().Default(false),
+})
+
+func AddExcelManageSheetTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_manage_sheet",
+		mcp.WithDescription("List, rename, delete, move, hide/show sheets or set their tab colors in the Excel file"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("action",
+			mcp.Required(),
+			mcp.Enum("list", "rename", "delete", "move", "setVisibility", "setTabColor"),
+			mcp.Description("Action to perform. \"rename\" also rewrites formulas referring to the sheet. \"delete\" refuses if formulas in other sheets refer to the sheet unless force is true."),
+		),
+		mcp.WithString("sheetName",
+			mcp.Description("Sheet name to manage (required except for list)"),
+		),
+		mcp.WithString("newName",
+			mcp.Description("New sheet name (required for rename)"),
+		),
+		mcp.WithNumber("position",
+			mcp.Description("1-based position to move the sheet to (required for move)"),
+		),
+		mcp.WithString("visibility",
+			mcp.Enum(string(excel.SheetVisible), string(excel.SheetHidden), string(excel.SheetVeryHidden)),
+			mcp.Description("Visibility of the sheet (required for setVisibility). \"veryHidden\" sheets cannot be shown from the Excel UI."),
+		),
+		mcp.WithString("tabColor",
+			mcp.Description("Tab color in hex (e.g., \"#FF0000\") for setTabColor. Omit to clear the tab color."),
+		),
+		mcp.WithBoolean("force",
+			mcp.Description("Delete the sheet even if formulas in other sheets refer to it. The references become #REF!. [default: false]"),
+		),
+	), handleManageSheet)
+}
+
+func handleManageSheet(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelManageSheetArguments{}
+	if issues := excelManageSheetArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if args.Action != "list" && args.SheetName == "" {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("sheetName is required for %s", args.Action)), nil
+	}
+	switch {
+	case args.Action == "rename" && args.NewName == "":
+		return imcp.NewToolResultInvalidArgumentError("newName is required for rename"), nil
+	case args.Action == "move" && args.Position == 0:
+		return imcp.NewToolResultInvalidArgumentError("position is required for move"), nil
+	case args.Action == "setVisibility" && args.Visibility == "":
+		return imcp.NewToolResultInvalidArgumentError("visibility is required for setVisibility"), nil
+	case args.TabColor != "" && !regexp.MustCompile(`^#?[0-9A-Fa-f]{6}$`).MatchString(args.TabColor):
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid tabColor: %s (expected hex color such as #FF0000)", args.TabColor)), nil
+	}
+	return manageSheet(args)
+}
+
+func manageSheet(args ExcelManageSheetArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	message := ""
+	switch args.Action {
+	case "rename":
+		err = workbook.RenameSheet(args.SheetName, args.NewName)
+		message = fmt.Sprintf("Sheet [%s] renamed to [%s].\n", args.SheetName, args.NewName)
+	case "delete":
+		if !args.Force {
+			dependents, err := workbook.FindSheetDependents(args.SheetName)
+			if err != nil {
+				return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+			}
+			if len(dependents) > 0 {
+				result := fmt.Sprintf("Sheet [%s] is not deleted because formulas in other sheets refer to it. Set force to true to delete it anyway.\n", args.SheetName)
+				result += "# Dependent cells\n"
+				for _, dependent := range dependents {
+					result += fmt.Sprintf("- %s\n", dependent)
+				}
+				return imcp.NewToolResultInvalidArgumentError(result), nil
+			}
+		}
+		err = workbook.DeleteSheet(args.SheetName)
+		message = fmt.Sprintf("Sheet [%s] deleted.\n", args.SheetName)
+	case "move":
+		err = workbook.MoveSheet(args.SheetName, args.Position)
+		message = fmt.Sprintf("Sheet [%s] moved to position %d.\n", args.SheetName, args.Position)
+	case "setVisibility":
+		err = workbook.SetSheetVisibility(args.SheetName, excel.SheetVisibility(args.Visibility))
+		message = fmt.Sprintf("Sheet [%s] is now %s.\n", args.SheetName, args.Visibility)
+	case "setTabColor":
+		tabColor := ""
+		if args.TabColor != "" {
+			tabColor = "#" + strings.ToUpper(strings.TrimPrefix(args.TabColor, "#"))
+		}
+		err = workbook.SetTabColor(args.SheetName, tabColor)
+		if tabColor == "" {
+			message = fmt.Sprintf("Tab color of sheet [%s] cleared.\n", args.SheetName)
+		} else {
+			message = fmt.Sprintf("Tab color of sheet [%s] set to %s.\n", args.SheetName, tabColor)
+		}
+	}
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if args.Action != "list" {
+		if err := workbook.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	worksheets, err := workbook.GetSheets()
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += message
+	result += "# Sheets\n"
+	for i, worksheet := range worksheets {
+		defer worksheet.Release()
+		name, err := worksheet.Name()
+		if err != nil {
+			return nil, err
+		}
+		visibility, err := workbook.GetSheetVisibility(name)
+		if err != nil {
+			return nil, err
+		}
+		tabColor, err := workbook.GetTabColor(name)
+		if err != nil {
+			return nil, err
+		}
+		line := fmt.Sprintf("%d. %s (%s", i+1, name, visibility)
+		if tabColor != "" {
+			line += fmt.Sprintf(", tab color: %s", tabColor)
+		}
+		result += line + ")\n"
+	}
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelMergeCellsArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Range            string `zog:"range"`
+}
+
+var excelMergeCellsArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"range":            z.String().Required(),
+})
+
+func AddExcelMergeCellsTool(server *server.MCPServer) {
+	server.AddTool(newMergeCellsTool("excel_merge_cells",
+		"Merge a range of cells. Only the value of the top-left cell is kept.",
+		"Range of cells to merge (e.g., \"A1:C1\")",
+	), handleMergeCells)
+}
+
+func AddExcelUnmergeCellsTool(server *server.MCPServer) {
+	server.AddTool(newMergeCellsTool("excel_unmerge_cells",
+		"Unmerge all merged cells overlapping a range",
+		"Range of cells to unmerge (e.g., \"A1:C1\")",
+	), handleUnmergeCells)
+}
+
+func newMergeCellsTool(name string, description string, rangeDescription string) mcp.Tool {
+	return mcp.NewTool(name,
+		mcp.WithDescription(description),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("range",
+			mcp.Required(),
+			mcp.Description(rangeDescription),
+		),
+	)
+}
+
+func handleMergeCells(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelMergeCellsArguments{}
+	if issues := excelMergeCellsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return mergeCells(args.FileAbsolutePath, args.SheetName, args.Range, "merged", func(worksheet excel.Worksheet) error {
+		return worksheet.MergeCells(args.Range)
+	})
+}
+
+func handleUnmergeCells(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelMergeCellsArguments{}
+	if issues := excelMergeCellsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return mergeCells(args.FileAbsolutePath, args.SheetName, args.Range, "unmerged", func(worksheet excel.Worksheet) error {
+		return worksheet.UnmergeCells(args.Range)
+	})
+}
+
+func mergeCells(fileAbsolutePath string, sheetName string, cellRange string, action string, mergeFn func(worksheet excel.Worksheet) error) (*mcp.CallToolResult, error) {
+	if _, _, _, _, err := excel.ParseCellOrRange(cellRange); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+
+	workbook, release, err := excel.OpenFile(fileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(sheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	if err := mergeFn(worksheet); err != nil {
+		return nil, err
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+	mergedCells, err := worksheet.GetMergedCells()
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Range %s %s in sheet [%s].\n", cellRange, action, sheetName)
+	result += "# Merged cells\n"
+	if len(mergedCells) == 0 {
+		result += "(none)\n"
+	}
+	for _, mergedCell := range mergedCells {
+		result += fmt.Sprintf("- %s\n", mergedCell)
+	}
+	return mcp.NewToolResultText(result), nil
+}
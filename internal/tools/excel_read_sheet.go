@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"html"
+	"slices"
 
 	z "github.com/Oudwins/zog"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	excel "github.com/vKenjo/ms-excel-mcp-server/internal/excel"
 	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+	"github.com/xuri/excelize/v2"
 )
 
 type ExcelReadSheetArguments struct {
@@ -18,6 +20,10 @@ type ExcelReadSheetArguments struct {
 	Range            string `zog:"range"`
 	ShowFormula      bool   `zog:"showFormula"`
 	ShowStyle        bool   `zog:"showStyle"`
+	ShowComments     bool   `zog:"showComments"`
+	ShowHyperlinks   bool   `zog:"showHyperlinks"`
+	OutputFormat     string `zog:"outputFormat"`
+	HeaderRow        *int   `zog:"headerRow"`
 }
 
 var excelReadSheetArgumentsSchema = z.Struct(z.Schema{
@@ -26,6 +32,10 @@ var excelReadSheetArgumentsSchema = z.Struct(z.Schema{
 	"range":            z.String(),
 	"showFormula":      z.Bool().Default(false),
 	"showStyle":        z.Bool().Default(false),
+	"showComments":     z.Bool().Default(false),
+	"showHyperlinks":   z.Bool().Default(false),
+	"outputFormat":     z.String().OneOf([]string{"html", "json", "records", "markdown"}).Default("html"),
+	"headerRow":        z.Ptr(z.Int().GTE(1)),
 })
 
 func AddExcelReadSheetTool(server *server.MCPServer) {
@@ -40,7 +50,7 @@ func AddExcelReadSheetTool(server *server.MCPServer) {
 			mcp.Description("Sheet name in the Excel file"),
 		),
 		mcp.WithString("range",
-			mcp.Description("Range of cells to read in the Excel sheet (e.g., \"A1:C10\"). [default: first paging range]"),
+			mcp.Description("Range of cells to read in the Excel sheet (e.g., \"A1:C10\") or a defined name referring to a range. [default: first paging range]"),
 		),
 		mcp.WithBoolean("showFormula",
 			mcp.Description("Show formula instead of value"),
@@ -48,6 +58,19 @@ func AddExcelReadSheetTool(server *server.MCPServer) {
 		mcp.WithBoolean("showStyle",
 			mcp.Description("Show style information for cells"),
 		),
+		mcp.WithBoolean("showComments",
+			mcp.Description("Show comments (notes) of cells as data-comment attributes"),
+		),
+		mcp.WithBoolean("showHyperlinks",
+			mcp.Description("Show hyperlinks of cells as <a href> (locations in the workbook are prefixed with \"#\")"),
+		),
+		mcp.WithString("outputFormat",
+			mcp.Enum("html", "json", "records", "markdown"),
+			mcp.Description("Output format: an HTML table (html), a JSON object with the cells in rows (json), a JSON object with the rows keyed by the header row (records), or a Markdown table (markdown). The JSON formats carry the paging metadata such as nextRange as fields. Every format shows the types of the values (number, string, boolean, date, error). showStyle is supported only by html, and showComments and showHyperlinks only by html and json. [default: html]"),
+		),
+		mcp.WithNumber("headerRow",
+			mcp.Description("Row number of the header row for the records format, which is skipped in the records [default: first row of the first paging range when paging, otherwise first row of the range]"),
+		),
 	), handleReadSheet)
 }
 
@@ -56,10 +79,26 @@ func handleReadSheet(ctx context.Context, request mcp.CallToolRequest) (*mcp.Cal
 	if issues := excelReadSheetArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
 		return imcp.NewToolResultZogIssueMap(issues), nil
 	}
-	return readSheet(args.FileAbsolutePath, args.SheetName, args.Range, args.ShowFormula, args.ShowStyle)
+	if args.ShowStyle && args.OutputFormat != "html" {
+		return imcp.NewToolResultInvalidArgumentError("showStyle is supported only by the html output format"), nil
+	}
+	if (args.ShowComments || args.ShowHyperlinks) && args.OutputFormat != "html" && args.OutputFormat != "json" {
+		return imcp.NewToolResultInvalidArgumentError("showComments and showHyperlinks are supported only by the html and json output formats"), nil
+	}
+	if args.HeaderRow != nil && args.OutputFormat != "records" {
+		return imcp.NewToolResultInvalidArgumentError("headerRow is supported only by the records output format"), nil
+	}
+	return readSheet(args)
 }
 
-func readSheet(fileAbsolutePath string, sheetName string, valueRange string, showFormula bool, showStyle bool) (*mcp.CallToolResult, error) {
+func readSheet(args ExcelReadSheetArguments) (*mcp.CallToolResult, error) {
+	fileAbsolutePath, sheetName, valueRange := args.FileAbsolutePath, args.SheetName, args.Range
+	showFormula, showStyle := args.ShowFormula, args.ShowStyle
+	options := HTMLTableOptions{
+		ShowComments:   args.ShowComments,
+		ShowHyperlinks: args.ShowHyperlinks,
+	}
+
 	config, issues := LoadConfig()
 	if issues != nil {
 		return imcp.NewToolResultZogIssueMap(issues), nil
@@ -77,6 +116,35 @@ func readSheet(fileAbsolutePath string, sheetName string, valueRange string, sho
 	}
 	defer worksheet.Release()
 
+	// Resolve a defined name into the range it refers to
+	definedName := ""
+	if valueRange != "" && !isCellOrRange(valueRange) {
+		name, err := findDefinedName(workbook, sheetName, valueRange)
+		if err != nil {
+			return nil, err
+		}
+		if name == nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid range: %s is neither a range nor a defined name", valueRange)), nil
+		}
+		refSheetName, refRange := excel.SplitSheetReference(name.RefersTo)
+		startCol, startRow, endCol, endRow, err := excel.ParseCellOrRange(refRange)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("defined name %s does not refer to a range: %s", name.Name, name.RefersTo)), nil
+		}
+		if refSheetName != "" && refSheetName != sheetName {
+			refWorksheet, err := workbook.FindSheet(refSheetName)
+			if err != nil {
+				return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+			}
+			defer refWorksheet.Release()
+			worksheet, sheetName = refWorksheet, refSheetName
+		}
+		startCell, _ := excelize.CoordinatesToCellName(startCol, startRow)
+		endCell, _ := excelize.CoordinatesToCellName(endCol, endRow)
+		definedName = name.Name
+		valueRange = fmt.Sprintf("%s:%s", startCell, endCell)
+	}
+
 	// ページング戦略の初期化
 	strategy, err := worksheet.GetPagingStrategy(config.EXCEL_MCP_PAGING_CELLS_LIMIT)
 	if err != nil {
@@ -110,19 +178,52 @@ func readSheet(fileAbsolutePath string, sheetName string, valueRange string, sho
 		return nil, err
 	}
 
+	page := sheetPage{
+		Backend:     workbook.GetBackendName(),
+		SheetName:   sheetName,
+		Range:       currentRange,
+		DefinedName: definedName,
+	}
+	if nextRange != "" {
+		page.NextRange = &nextRange
+	}
+	switch args.OutputFormat {
+	case "json":
+		document, err := createJSONOfCells(worksheet, page, startCol, startRow, endCol, endRow, showFormula, options)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(document), nil
+	case "records":
+		// The pages after the first one share the header row of the first page
+		headerRow := startRow
+		if args.HeaderRow != nil {
+			headerRow = *args.HeaderRow
+		} else if slices.Contains(allRanges, currentRange) {
+			_, headerRow, _, _, _ = excel.ParseRange(allRanges[0])
+		}
+		document, err := createJSONOfRecords(worksheet, page, startCol, startRow, endCol, endRow, headerRow, showFormula)
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(document), nil
+	case "markdown":
+		return mcp.NewToolResultText(createMarkdownResult(worksheet, page, startCol, startRow, endCol, endRow, showFormula)), nil
+	}
+
 	// HTMLテーブルの生成
 	var table *string
 	if showStyle {
 		if showFormula {
-			table, err = CreateHTMLTableOfFormulaWithStyle(worksheet, startCol, startRow, endCol, endRow)
+			table, err = CreateHTMLTableOfFormulaWithStyle(worksheet, startCol, startRow, endCol, endRow, options)
 		} else {
-			table, err = CreateHTMLTableOfValuesWithStyle(worksheet, startCol, startRow, endCol, endRow)
+			table, err = CreateHTMLTableOfValuesWithStyle(worksheet, startCol, startRow, endCol, endRow, options)
 		}
 	} else {
 		if showFormula {
-			table, err = CreateHTMLTableOfFormula(worksheet, startCol, startRow, endCol, endRow)
+			table, err = CreateHTMLTableOfFormula(worksheet, startCol, startRow, endCol, endRow, options)
 		} else {
-			table, err = CreateHTMLTableOfValues(worksheet, startCol, startRow, endCol, endRow)
+			table, err = CreateHTMLTableOfValues(worksheet, startCol, startRow, endCol, endRow, options)
 		}
 	}
 	if err != nil {
@@ -136,6 +237,12 @@ func readSheet(fileAbsolutePath string, sheetName string, valueRange string, sho
 	result += fmt.Sprintf("<li>backend: %s</li>\n", workbook.GetBackendName())
 	result += fmt.Sprintf("<li>sheet name: %s</li>\n", html.EscapeString(sheetName))
 	result += fmt.Sprintf("<li>read range: %s</li>\n", currentRange)
+	if definedName != "" {
+		result += fmt.Sprintf("<li>defined name: %s</li>\n", html.EscapeString(definedName))
+	}
+	if nextRange != "" {
+		result += fmt.Sprintf("<li>next range: %s</li>\n", nextRange)
+	}
 	result += "</ul>\n"
 	result += "<h2>Notice</h2>\n"
 	if nextRange != "" {
@@ -147,3 +254,24 @@ func readSheet(fileAbsolutePath string, sheetName string, valueRange string, sho
 	}
 	return mcp.NewToolResultText(result), nil
 }
+
+func createMarkdownResult(worksheet excel.Worksheet, page sheetPage, startCol int, startRow int, endCol int, endRow int, showFormula bool) string {
+	values := readTypedValues(worksheet, startCol, startRow, endCol, endRow)
+	result := "## Read Sheet\n\n"
+	result += createMarkdownTable(worksheet, values, startCol, startRow, showFormula)
+	result += "\n## Cell Types\n\n"
+	result += createMarkdownCellTypes(values, startCol, startRow)
+	result += "\n## Metadata\n\n"
+	result += fmt.Sprintf("- backend: %s\n", page.Backend)
+	result += fmt.Sprintf("- sheet name: %s\n", page.SheetName)
+	result += fmt.Sprintf("- read range: %s\n", page.Range)
+	if page.DefinedName != "" {
+		result += fmt.Sprintf("- defined name: %s\n", page.DefinedName)
+	}
+	if page.NextRange != nil {
+		result += fmt.Sprintf("- next range: %s\n", *page.NextRange)
+	} else {
+		result += "- next range: none (this is the last range)\n"
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelRecalculateArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+}
+
+var excelRecalculateArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+})
+
+func AddExcelRecalculateTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_recalculate",
+		mcp.WithDescription("Recalculate all formulas in the Excel file and save their calculated values, so that other applications can read the up-to-date results. Reports the cells which failed to calculate, used unsupported functions or resulted in error values."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+	), handleRecalculate)
+}
+
+func handleRecalculate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelRecalculateArguments{}
+	if issues := excelRecalculateArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return recalculate(args)
+}
+
+func recalculate(args ExcelRecalculateArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	recalculation, err := workbook.Recalculate()
+	if err != nil {
+		return nil, err
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Recalculated %d formulas and saved their calculated values.\n", recalculation.Calculated)
+	if len(recalculation.Failures) > 0 {
+		result += "# Failed cells\n"
+		result += "These cells could not be calculated, and their saved values are unchanged.\n"
+		result += formatRecalculationIssues(recalculation.Failures)
+	}
+	if len(recalculation.UnsupportedFunctions) > 0 {
+		result += "# Unsupported functions\n"
+		result += "These cells use functions the calculation engine does not support. Their values may be incorrect even if they are calculated.\n"
+		result += formatRecalculationIssues(recalculation.UnsupportedFunctions)
+	}
+	if len(recalculation.Errors) > 0 {
+		result += "# Error values\n"
+		result += formatRecalculationIssues(recalculation.Errors)
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+func formatRecalculationIssues(issues []excel.RecalculationIssue) string {
+	result := ""
+	for _, issue := range issues {
+		result += fmt.Sprintf("- %s: %s\n", issue.Cell, issue.Detail)
+	}
+	return result
+}
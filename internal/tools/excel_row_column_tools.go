@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelRowsArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Row              int    `zog:"row"`
+	Count            int    `zog:"count"`
+}
+
+var excelRowsArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"row":              z.Int().GT(0).Required(),
+	"count":            z.Int().GT(0).Default(1),
+})
+
+type ExcelColumnsArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Column           string `zog:"column"`
+	Count            int    `zog:"count"`
+}
+
+var excelColumnsArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"column":           z.String().Required(),
+	"count":            z.Int().GT(0).Default(1),
+})
+
+func AddExcelInsertRowsTool(server *server.MCPServer) {
+	server.AddTool(newRowsTool("excel_insert_rows",
+		"Insert rows into the Excel sheet. Formulas, tables and defined names referring to the shifted cells are adjusted.",
+		"Row number before which the rows are inserted",
+		"Number of rows to insert [default: 1]",
+	), handleInsertRows)
+}
+
+func AddExcelDeleteRowsTool(server *server.MCPServer) {
+	server.AddTool(newRowsTool("excel_delete_rows",
+		"Delete rows from the Excel sheet. Formulas, tables and defined names referring to the shifted cells are adjusted.",
+		"First row number to delete",
+		"Number of rows to delete [default: 1]",
+	), handleDeleteRows)
+}
+
+func AddExcelInsertColumnsTool(server *server.MCPServer) {
+	server.AddTool(newColumnsTool("excel_insert_columns",
+		"Insert columns into the Excel sheet. Formulas, tables and defined names referring to the shifted cells are adjusted.",
+		"Column name before which the columns are inserted (e.g., \"C\")",
+		"Number of columns to insert [default: 1]",
+	), handleInsertColumns)
+}
+
+func AddExcelDeleteColumnsTool(server *server.MCPServer) {
+	server.AddTool(newColumnsTool("excel_delete_columns",
+		"Delete columns from the Excel sheet. Formulas, tables and defined names referring to the shifted cells are adjusted.",
+		"First column name to delete (e.g., \"C\")",
+		"Number of columns to delete [default: 1]",
+	), handleDeleteColumns)
+}
+
+func newRowsTool(name string, description string, rowDescription string, countDescription string) mcp.Tool {
+	return mcp.NewTool(name,
+		mcp.WithDescription(description),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithNumber("row",
+			mcp.Required(),
+			mcp.Description(rowDescription),
+		),
+		mcp.WithNumber("count",
+			mcp.Description(countDescription),
+		),
+	)
+}
+
+func newColumnsTool(name string, description string, columnDescription string, countDescription string) mcp.Tool {
+	return mcp.NewTool(name,
+		mcp.WithDescription(description),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("column",
+			mcp.Required(),
+			mcp.Description(columnDescription),
+		),
+		mcp.WithNumber("count",
+			mcp.Description(countDescription),
+		),
+	)
+}
+
+func handleInsertRows(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelRowsArguments{}
+	if issues := excelRowsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return shiftCells(args.FileAbsolutePath, args.SheetName, fmt.Sprintf("%d row(s) inserted before row %d", args.Count, args.Row), func(worksheet excel.Worksheet) (*excel.ShiftResult, error) {
+		return worksheet.InsertRows(args.Row, args.Count)
+	})
+}
+
+func handleDeleteRows(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelRowsArguments{}
+	if issues := excelRowsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	return shiftCells(args.FileAbsolutePath, args.SheetName, fmt.Sprintf("%d row(s) deleted from row %d", args.Count, args.Row), func(worksheet excel.Worksheet) (*excel.ShiftResult, error) {
+		return worksheet.DeleteRows(args.Row, args.Count)
+	})
+}
+
+func handleInsertColumns(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelColumnsArguments{}
+	if issues := excelColumnsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	col, err := excelize.ColumnNameToNumber(args.Column)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	return shiftCells(args.FileAbsolutePath, args.SheetName, fmt.Sprintf("%d column(s) inserted before column %s", args.Count, args.Column), func(worksheet excel.Worksheet) (*excel.ShiftResult, error) {
+		return worksheet.InsertColumns(col, args.Count)
+	})
+}
+
+func handleDeleteColumns(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelColumnsArguments{}
+	if issues := excelColumnsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	col, err := excelize.ColumnNameToNumber(args.Column)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	return shiftCells(args.FileAbsolutePath, args.SheetName, fmt.Sprintf("%d column(s) deleted from column %s", args.Count, args.Column), func(worksheet excel.Worksheet) (*excel.ShiftResult, error) {
+		return worksheet.DeleteColumns(col, args.Count)
+	})
+}
+
+func shiftCells(fileAbsolutePath string, sheetName string, message string, shiftFn func(worksheet excel.Worksheet) (*excel.ShiftResult, error)) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(fileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(sheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	shiftResult, err := shiftFn(worksheet)
+	if err != nil {
+		return nil, err
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Sheet [%s]: %s.\n", sheetName, message)
+	result += writeShiftedReferences("Shifted tables", shiftResult.Tables)
+	result += writeShiftedReferences("Shifted defined names", shiftResult.DefinedNames)
+	result += writeShiftedReferences("Shifted formulas", shiftResult.Formulas)
+	return mcp.NewToolResultText(result), nil
+}
+
+func writeShiftedReferences(title string, references []excel.ShiftedReference) string {
+	result := fmt.Sprintf("# %s\n", title)
+	if len(references) == 0 {
+		return result + "(none)\n"
+	}
+	for _, reference := range references {
+		if reference.After == "" {
+			result += fmt.Sprintf("- %s: %s -> (removed)\n", reference.Name, reference.Before)
+		} else {
+			result += fmt.Sprintf("- %s: %s -> %s\n", reference.Name, reference.Before, reference.After)
+		}
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelSaveAsArguments struct {
+	FileAbsolutePath   string `zog:"fileAbsolutePath"`
+	TargetAbsolutePath string `zog:"targetAbsolutePath"`
+	Format             string `zog:"format"`
+	Overwrite          bool   `zog:"overwrite"`
+}
+
+var excelSaveAsArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath":   z.String().Test(AbsolutePathTest()).Required(),
+	"targetAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"format":             z.String().OneOf([]string{"xlsx", "xlsm", "xltx", "xltm"}),
+	"overwrite":          z.Bool().Default(false),
+})
+
+func AddExcelSaveAsTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_save_as",
+		mcp.WithDescription("Save a copy of the Excel file to another path, converting between xlsx, xlsm, xltx and xltm. The original file is left untouched."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("targetAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to save the copy to. The extension must match the format."),
+		),
+		mcp.WithString("format",
+			mcp.Enum("xlsx", "xlsm", "xltx", "xltm"),
+			mcp.Description("Format of the copy. Saving as xlsx or xltx drops the VBA project. Defaults to the extension of targetAbsolutePath."),
+		),
+		mcp.WithBoolean("overwrite",
+			mcp.Description("Replace the target file if it already exists [default: false]"),
+		),
+	), handleSaveAs)
+}
+
+func handleSaveAs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelSaveAsArguments{}
+	if issues := excelSaveAsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	format, err := excel.FileFormatOf(args.TargetAbsolutePath)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("extension of targetAbsolutePath must be .xlsx, .xlsm, .xltx or .xltm: %s", args.TargetAbsolutePath)), nil
+	}
+	if args.Format != "" && excel.FileFormat(args.Format) != format {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("extension of targetAbsolutePath does not match format %s: %s", args.Format, args.TargetAbsolutePath)), nil
+	}
+	if filepath.Clean(args.TargetAbsolutePath) == filepath.Clean(args.FileAbsolutePath) {
+		return imcp.NewToolResultInvalidArgumentError("targetAbsolutePath must differ from fileAbsolutePath"), nil
+	}
+	if _, err := os.Stat(args.TargetAbsolutePath); err == nil && !args.Overwrite {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("file already exists: %s", args.TargetAbsolutePath)), nil
+	}
+	return saveAs(args, format)
+}
+
+func saveAs(args ExcelSaveAsArguments, format excel.FileFormat) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	warnings, err := workbook.SaveAs(args.TargetAbsolutePath, format)
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("File saved as [%s] in %s format. The original file is unchanged.\n", args.TargetAbsolutePath, format)
+	if len(warnings) > 0 {
+		result += "# Warnings\n"
+		for _, warning := range warnings {
+			result += fmt.Sprintf("- %s\n", warning)
+		}
+	}
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelSearchArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	Query            string `zog:"query"`
+	Mode             string `zog:"mode"`
+	Target           string `zog:"target"`
+	SheetName        string `zog:"sheetName"`
+	Range            string `zog:"range"`
+	MatchCase        bool   `zog:"matchCase"`
+	WholeCell        bool   `zog:"wholeCell"`
+	MaxResults       int    `zog:"maxResults"`
+	Offset           int    `zog:"offset"`
+}
+
+var excelSearchArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"query":            z.String().Required(),
+	"mode":             z.String().OneOf([]string{"literal", "regex", "numeric"}).Default("literal"),
+	"target":           z.String().OneOf([]string{"values", "formulas", "both"}).Default("values"),
+	"sheetName":        z.String(),
+	"range":            z.String(),
+	"matchCase":        z.Bool().Default(false),
+	"wholeCell":        z.Bool().Default(false),
+	"maxResults":       z.Int().GTE(1).Default(100),
+	"offset":           z.Int().GTE(0).Default(0),
+})
+
+func AddExcelSearchTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_search",
+		mcp.WithDescription("Search cells in the Excel file by text, regular expression or numeric comparison. Matches are grouped by sheet."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Text, regular expression (RE2 syntax) or numeric comparison (e.g., \">= 100\", \"> 0 and < 1\", \"42\") to search for"),
+		),
+		mcp.WithString("mode",
+			mcp.Enum("literal", "regex", "numeric"),
+			mcp.Description("How to interpret the query [default: literal]"),
+		),
+		mcp.WithString("target",
+			mcp.Enum("values", "formulas", "both"),
+			mcp.Description("Where to search. Formulas are matched with the leading \"=\". Numeric searches look in values only. [default: values]"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Description("Sheet name to search in. Defaults to all sheets."),
+		),
+		mcp.WithString("range",
+			mcp.Description("Range to search in (e.g., \"A1:D100\"). Requires sheetName."),
+		),
+		mcp.WithBoolean("matchCase",
+			mcp.Description("Match letter case in literal and regex searches [default: false]"),
+		),
+		mcp.WithBoolean("wholeCell",
+			mcp.Description("Match the whole cell content instead of a part in literal and regex searches [default: false]"),
+		),
+		mcp.WithNumber("maxResults",
+			mcp.Description("Maximum number of matches to return [default: 100]"),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of matches to skip for paging [default: 0]"),
+		),
+	), handleSearch)
+}
+
+func handleSearch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelSearchArguments{}
+	if issues := excelSearchArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if args.Query == "" {
+		return imcp.NewToolResultInvalidArgumentError("query must not be empty"), nil
+	}
+	if args.Mode == "numeric" && args.Target == "formulas" {
+		return imcp.NewToolResultInvalidArgumentError("numeric search cannot look in formulas"), nil
+	}
+	if args.Range != "" {
+		if args.SheetName == "" {
+			return imcp.NewToolResultInvalidArgumentError("sheetName is required with range"), nil
+		}
+		if _, _, _, _, err := excel.ParseCellOrRange(args.Range); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid range: %s", args.Range)), nil
+		}
+	}
+	return search(args)
+}
+
+// sheetSearchMatches is the matches found in a sheet
+type sheetSearchMatches struct {
+	sheetName string
+	matches   []excel.SearchMatch
+}
+
+func search(args ExcelSearchArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var worksheets []excel.Worksheet
+	if args.SheetName != "" {
+		worksheet, err := workbook.FindSheet(args.SheetName)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		worksheets = []excel.Worksheet{worksheet}
+	} else if worksheets, err = workbook.GetSheets(); err != nil {
+		return nil, err
+	}
+
+	options := &excel.SearchOptions{
+		Query:     args.Query,
+		Mode:      excel.SearchMode(args.Mode),
+		Target:    excel.SearchTarget(args.Target),
+		Range:     args.Range,
+		MatchCase: args.MatchCase,
+		WholeCell: args.WholeCell,
+	}
+	total := 0
+	results := []sheetSearchMatches{}
+	for _, worksheet := range worksheets {
+		defer worksheet.Release()
+		sheetName, err := worksheet.Name()
+		if err != nil {
+			return nil, err
+		}
+		matches, err := worksheet.Search(options)
+		if err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+		// Keep only the matches in the requested page
+		start := min(max(args.Offset-total, 0), len(matches))
+		end := min(max(args.Offset+args.MaxResults-total, 0), len(matches))
+		if start < end {
+			results = append(results, sheetSearchMatches{sheetName: sheetName, matches: matches[start:end]})
+		}
+		total += len(matches)
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	shown := min(max(total-args.Offset, 0), args.MaxResults)
+	if shown == 0 {
+		result += fmt.Sprintf("Found %d matches.\n", total)
+	} else {
+		result += fmt.Sprintf("Found %d matches (showing %d-%d).\n", total, args.Offset+1, args.Offset+shown)
+	}
+	if next := args.Offset + shown; next < total {
+		result += fmt.Sprintf("To get the next matches, call again with { \"offset\": %d }.\n", next)
+	}
+	for _, sheetResult := range results {
+		result += fmt.Sprintf("# %s\n", sheetResult.sheetName)
+		for _, match := range sheetResult.matches {
+			result += fmt.Sprintf("- %s: %s", match.Cell, match.Value)
+			if match.Formula != "" {
+				result += fmt.Sprintf(" (formula: %s)", match.Formula)
+			}
+			result += "\n"
+		}
+	}
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelSetDimensionsArguments struct {
+	FileAbsolutePath string   `zog:"fileAbsolutePath"`
+	SheetName        string   `zog:"sheetName"`
+	Columns          string   `zog:"columns"`
+	Width            *float64 `zog:"width"`
+	Rows             string   `zog:"rows"`
+	Height           *float64 `zog:"height"`
+	AutoFit          bool     `zog:"autoFit"`
+}
+
+var excelSetDimensionsArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"columns":          z.String(),
+	"width":            z.Ptr(z.Float().GTE(0).LTE(255)),
+	"rows":             z.String(),
+	"height":           z.Ptr(z.Float().GTE(0).LTE(409)),
+	"autoFit":          z.Bool().Default(false),
+})
+
+func AddExcelSetDimensionsTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_set_dimensions",
+		mcp.WithDescription("Set or auto-fit the column widths and row heights of the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("columns",
+			mcp.Description("Column or column range to resize (e.g., \"B\", \"A:D\")"),
+		),
+		mcp.WithNumber("width",
+			mcp.Description("Column width in characters (0-255)"),
+		),
+		mcp.WithString("rows",
+			mcp.Description("Row or row range to resize (e.g., \"1\", \"2:10\")"),
+		),
+		mcp.WithNumber("height",
+			mcp.Description("Row height in points (0-409)"),
+		),
+		mcp.WithBoolean("autoFit",
+			mcp.Description("Fit the columns and rows to the contents of their cells instead of setting width and height. Fits all columns of the used range if neither columns nor rows is given. "+
+				"The excelize backend estimates the sizes from the text lengths and font sizes. [default: false]"),
+		),
+	), handleSetDimensions)
+}
+
+func handleSetDimensions(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelSetDimensionsArguments{}
+	if issues := excelSetDimensionsArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if args.AutoFit && (args.Width != nil || args.Height != nil) {
+		return imcp.NewToolResultInvalidArgumentError("autoFit cannot be combined with width or height"), nil
+	}
+	if !args.AutoFit {
+		if args.Columns == "" && args.Rows == "" {
+			return imcp.NewToolResultInvalidArgumentError("columns or rows is required"), nil
+		}
+		if (args.Columns == "") != (args.Width == nil) {
+			return imcp.NewToolResultInvalidArgumentError("columns and width must be given together"), nil
+		}
+		if (args.Rows == "") != (args.Height == nil) {
+			return imcp.NewToolResultInvalidArgumentError("rows and height must be given together"), nil
+		}
+	}
+	if args.Columns != "" {
+		if _, _, err := parseColumnRange(args.Columns); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid columns: %s", args.Columns)), nil
+		}
+	}
+	if args.Rows != "" {
+		if _, _, err := parseRowRange(args.Rows); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid rows: %s", args.Rows)), nil
+		}
+	}
+	return setDimensions(args)
+}
+
+// parseColumnRange parses a column or a column range such as "B" or "A:D" into column numbers
+func parseColumnRange(columns string) (int, int, error) {
+	start, end, _ := strings.Cut(strings.ToUpper(columns), ":")
+	if end == "" {
+		end = start
+	}
+	startCol, err := excelize.ColumnNameToNumber(start)
+	if err != nil {
+		return 0, 0, err
+	}
+	endCol, err := excelize.ColumnNameToNumber(end)
+	if err != nil {
+		return 0, 0, err
+	}
+	return min(startCol, endCol), max(startCol, endCol), nil
+}
+
+// parseRowRange parses a row or a row range such as "1" or "2:10" into row numbers
+func parseRowRange(rows string) (int, int, error) {
+	start, end, _ := strings.Cut(rows, ":")
+	if end == "" {
+		end = start
+	}
+	startRow, err := strconv.Atoi(start)
+	if err != nil || startRow < 1 || startRow > excelize.TotalRows {
+		return 0, 0, fmt.Errorf("invalid row: %s", start)
+	}
+	endRow, err := strconv.Atoi(end)
+	if err != nil || endRow < 1 || endRow > excelize.TotalRows {
+		return 0, 0, fmt.Errorf("invalid row: %s", end)
+	}
+	return min(startRow, endRow), max(startRow, endRow), nil
+}
+
+func setDimensions(args ExcelSetDimensionsArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	dimension, err := worksheet.GetDimention()
+	if err != nil {
+		return nil, err
+	}
+	usedStartCol, usedStartRow, usedEndCol, usedEndRow, err := excel.ParseCellOrRange(dimension)
+	if err != nil {
+		return nil, err
+	}
+	columns := args.Columns
+	if args.AutoFit && args.Columns == "" && args.Rows == "" {
+		startColumn, _ := excelize.ColumnNumberToName(usedStartCol)
+		endColumn, _ := excelize.ColumnNumberToName(usedEndCol)
+		columns = startColumn + ":" + endColumn
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	if columns != "" {
+		startCol, endCol, _ := parseColumnRange(columns)
+		if args.AutoFit {
+			// Fit to the cells of the columns in the used range
+			fitRange, _ := excelize.CoordinatesToCellName(startCol, usedStartRow)
+			fitEnd, _ := excelize.CoordinatesToCellName(endCol, usedEndRow)
+			if err := worksheet.AutoFitColumns(fitRange + ":" + fitEnd); err != nil {
+				return nil, err
+			}
+		}
+		result += fmt.Sprintf("# Column widths of sheet [%s]\n", args.SheetName)
+		for col := startCol; col <= endCol; col++ {
+			column, _ := excelize.ColumnNumberToName(col)
+			if args.Width != nil {
+				if err := worksheet.SetColumnWidth(column, *args.Width); err != nil {
+					return nil, err
+				}
+			}
+			width, err := worksheet.GetColumnWidth(column)
+			if err != nil {
+				return nil, err
+			}
+			result += fmt.Sprintf("- %s: %s\n", column, strconv.FormatFloat(width, 'f', -1, 64))
+		}
+	}
+	if args.Rows != "" {
+		startRow, endRow, _ := parseRowRange(args.Rows)
+		if args.AutoFit {
+			// Fit to the cells of the rows in the used range
+			fitRange, _ := excelize.CoordinatesToCellName(usedStartCol, startRow)
+			fitEnd, _ := excelize.CoordinatesToCellName(usedEndCol, endRow)
+			if err := worksheet.AutoFitRows(fitRange + ":" + fitEnd); err != nil {
+				return nil, err
+			}
+		}
+		result += fmt.Sprintf("# Row heights of sheet [%s]\n", args.SheetName)
+		for row := startRow; row <= endRow; row++ {
+			if args.Height != nil {
+				if err := worksheet.SetRowHeight(row, *args.Height); err != nil {
+					return nil, err
+				}
+			}
+			height, err := worksheet.GetRowHeight(row)
+			if err != nil {
+				return nil, err
+			}
+			result += fmt.Sprintf("- %d: %s\n", row, strconv.FormatFloat(height, 'f', -1, 64))
+		}
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(result), nil
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelSetHyperlinkArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Cell             string `zog:"cell"`
+	Target           string `zog:"target"`
+	Text             string `zog:"text"`
+	Tooltip          string `zog:"tooltip"`
+}
+
+var excelSetHyperlinkArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"cell":             z.String().Required(),
+	"target":           z.String(),
+	"text":             z.String(),
+	"tooltip":          z.String(),
+})
+
+func AddExcelSetHyperlinkTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_set_hyperlink",
+		mcp.WithDescription("Set or remove a hyperlink of a cell in the Excel sheet"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("cell",
+			mcp.Required(),
+			mcp.Description("Cell of the hyperlink (e.g., \"B3\")"),
+		),
+		mcp.WithString("target",
+			mcp.Description("URL (e.g., \"https://example.com\", \"mailto:user@example.com\") or location in the workbook (e.g., \"Sheet2!A1\"). Omit to remove the hyperlink."),
+		),
+		mcp.WithString("text",
+			mcp.Description("Text displayed in the cell [default: current value of the cell, or target if the cell is empty]"),
+		),
+		mcp.WithString("tooltip",
+			mcp.Description("Tooltip shown when hovering over the hyperlink"),
+		),
+	), handleSetHyperlink)
+}
+
+func handleSetHyperlink(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelSetHyperlinkArguments{}
+	if issues := excelSetHyperlinkArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if startCol, startRow, endCol, endRow, err := excel.ParseCellOrRange(args.Cell); err != nil || startCol != endCol || startRow != endRow {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid cell: %s", args.Cell)), nil
+	}
+	return setHyperlink(args)
+}
+
+func setHyperlink(args ExcelSetHyperlinkArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	var hyperlink *excel.Hyperlink
+	target := strings.TrimPrefix(args.Target, "#")
+	if target != "" {
+		hyperlink = &excel.Hyperlink{
+			Target:  target,
+			Tooltip: args.Tooltip,
+		}
+		text := args.Text
+		if text == "" {
+			value, err := worksheet.GetValue(args.Cell)
+			if err != nil {
+				return nil, err
+			}
+			if value == "" {
+				text = target
+			}
+		}
+		if text != "" {
+			if err := worksheet.SetValue(args.Cell, text); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := worksheet.SetHyperlink(args.Cell, hyperlink); err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	if hyperlink == nil {
+		result += fmt.Sprintf("Hyperlink removed from %s in sheet [%s].\n", args.Cell, args.SheetName)
+		return mcp.NewToolResultText(result), nil
+	}
+	linkType := "location in the workbook"
+	if hyperlink.IsExternal() {
+		linkType = "external link"
+	}
+	result += fmt.Sprintf("Hyperlink set to %s in sheet [%s].\n", args.Cell, args.SheetName)
+	result += fmt.Sprintf("- target: %s (%s)\n", hyperlink.Target, linkType)
+	return mcp.NewToolResultText(result), nil
+}
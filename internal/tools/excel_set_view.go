@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelSetViewArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Panes            string `zog:"panes"`
+	PaneCell         string `zog:"paneCell"`
+	ShowGridLines    *bool  `zog:"showGridLines"`
+	Zoom             *int   `zog:"zoom"`
+	RightToLeft      *bool  `zog:"rightToLeft"`
+	SelectedCell     string `zog:"selectedCell"`
+}
+
+var excelSetViewArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"panes":            z.String().OneOf([]string{string(excel.PanesFreeze), string(excel.PanesSplit), string(excel.PanesNone)}),
+	"paneCell":         z.String(),
+	"showGridLines":    z.Ptr(z.Bool()),
+	"zoom":             z.Ptr(z.Int().GTE(10).LTE(400)),
+	"rightToLeft":      z.Ptr(z.Bool()),
+	"selectedCell":     z.String(),
+})
+
+func AddExcelSetViewTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_set_view",
+		mcp.WithDescription("Freeze or split panes and change view settings (gridlines, zoom, right-to-left, selected cell) of the Excel sheet. Omitted settings are left unchanged."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("panes",
+			mcp.Enum(string(excel.PanesFreeze), string(excel.PanesSplit), string(excel.PanesNone)),
+			mcp.Description("Freeze or split the panes at paneCell, or remove the panes with \"none\""),
+		),
+		mcp.WithString("paneCell",
+			mcp.Description("Top-left cell of the scrollable pane (required for freeze and split). The rows above and the columns left of the cell are frozen (e.g., \"A2\" freezes the first row, \"B2\" freezes the first row and column)."),
+		),
+		mcp.WithBoolean("showGridLines",
+			mcp.Description("Show gridlines"),
+		),
+		mcp.WithNumber("zoom",
+			mcp.Description("Zoom in percent (10-400)"),
+		),
+		mcp.WithBoolean("rightToLeft",
+			mcp.Description("Display the sheet from right to left"),
+		),
+		mcp.WithString("selectedCell",
+			mcp.Description("Cell to select (e.g., \"A1\")"),
+		),
+	), handleSetView)
+}
+
+func handleSetView(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelSetViewArguments{}
+	if issues := excelSetViewArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	var panes *excel.Panes
+	switch excel.PanesMode(args.Panes) {
+	case excel.PanesFreeze, excel.PanesSplit:
+		col, row, endCol, endRow, err := excel.ParseCellOrRange(args.PaneCell)
+		if err != nil || col != endCol || row != endRow {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("paneCell is required for %s and must be a cell: %s", args.Panes, args.PaneCell)), nil
+		}
+		if col == 1 && row == 1 {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("cannot %s panes at A1", args.Panes)), nil
+		}
+		panes = &excel.Panes{Mode: excel.PanesMode(args.Panes), Rows: row - 1, Columns: col - 1}
+	case excel.PanesNone:
+		panes = &excel.Panes{Mode: excel.PanesNone}
+	}
+	if args.SelectedCell != "" {
+		if col, row, endCol, endRow, err := excel.ParseCellOrRange(args.SelectedCell); err != nil || col != endCol || row != endRow {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid selectedCell: %s", args.SelectedCell)), nil
+		}
+	}
+	return setView(args, panes)
+}
+
+func setView(args ExcelSetViewArguments, panes *excel.Panes) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	err = worksheet.SetViewOptions(&excel.ViewOptions{
+		ShowGridLines: args.ShowGridLines,
+		Zoom:          args.Zoom,
+		RightToLeft:   args.RightToLeft,
+		SelectedCell:  args.SelectedCell,
+	})
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if panes != nil {
+		if err := worksheet.SetPanes(panes); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+		}
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	currentPanes, err := worksheet.GetPanes()
+	if err != nil {
+		return nil, err
+	}
+	options, err := worksheet.GetViewOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("View of sheet [%s] updated.\n", args.SheetName)
+	result += "# View\n"
+	switch currentPanes.Mode {
+	case excel.PanesNone:
+		result += "- panes: none\n"
+	default:
+		result += fmt.Sprintf("- panes: %s (%d rows, %d columns)\n", currentPanes.Mode, currentPanes.Rows, currentPanes.Columns)
+	}
+	result += fmt.Sprintf("- show gridlines: %t\n", *options.ShowGridLines)
+	result += fmt.Sprintf("- zoom: %d%%\n", *options.Zoom)
+	result += fmt.Sprintf("- right-to-left: %t\n", *options.RightToLeft)
+	result += fmt.Sprintf("- selected cell: %s\n", options.SelectedCell)
+	return mcp.NewToolResultText(result), nil
+}
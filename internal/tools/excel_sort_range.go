@@ -0,0 +1,204 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/goccy/go-yaml"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExcelSortRangeArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Range            string `zog:"range"`
+	Table            string `zog:"table"`
+	HasHeader        bool   `zog:"hasHeader"`
+	CaseSensitive    bool   `zog:"caseSensitive"`
+	Natural          bool   `zog:"natural"`
+}
+
+var excelSortRangeArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"range":            z.String(),
+	"table":            z.String(),
+	"hasHeader":        z.Bool().Default(false),
+	"caseSensitive":    z.Bool().Default(false),
+	"natural":          z.Bool().Default(false),
+})
+
+// sortKeyArgument specifies a column to sort by
+type sortKeyArgument struct {
+	Column string `yaml:"column"`
+	Order  string `yaml:"order"`
+}
+
+func AddExcelSortRangeTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_sort_range",
+		mcp.WithDescription("Sort the rows of a range or a table in the Excel sheet by one or more keys. Values, styles and formulas of the cells move together."),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("range",
+			mcp.Description("Range to sort (e.g., \"A1:D100\"). Either range or table is required."),
+		),
+		mcp.WithString("table",
+			mcp.Description("Table name to sort. The header row of the table is not sorted."),
+		),
+		mcp.WithArray("keys",
+			mcp.Required(),
+			mcp.Description("Keys to sort by in order of priority. Each key is an object with column (column name such as \"B\", or header text if the range has a header row) and order (\"asc\" or \"desc\", default \"asc\")."),
+		),
+		mcp.WithBoolean("hasHeader",
+			mcp.Description("The first row of the range is a header row, which is not sorted [default: false]"),
+		),
+		mcp.WithBoolean("caseSensitive",
+			mcp.Description("Sort lowercase letters before uppercase letters [default: false]"),
+		),
+		mcp.WithBoolean("natural",
+			mcp.Description("Compare numbers in text by their values (e.g., \"item2\" before \"item10\"). Not supported by the OLE backend. [default: false]"),
+		),
+	), handleSortRange)
+}
+
+func handleSortRange(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelSortRangeArguments{}
+	if issues := excelSortRangeArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	if (args.Range == "") == (args.Table == "") {
+		return imcp.NewToolResultInvalidArgumentError("either range or table is required"), nil
+	}
+	if args.Range != "" {
+		if _, _, _, _, err := excel.ParseRange(args.Range); err != nil {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid range: %s", args.Range)), nil
+		}
+	}
+	keysArg, ok := request.Params.Arguments["keys"].([]any)
+	if !ok || len(keysArg) == 0 {
+		return imcp.NewToolResultInvalidArgumentError("keys must be a non-empty array"), nil
+	}
+	keys, err := parseSortKeyArguments(keysArg)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid keys: %v", err)), nil
+	}
+	return sortRange(args, keys)
+}
+
+func parseSortKeyArguments(keysArg []any) ([]sortKeyArgument, error) {
+	jsonBytes, err := json.Marshal(keysArg)
+	if err != nil {
+		return nil, err
+	}
+	keys := []sortKeyArgument{}
+	if err := yaml.UnmarshalWithOptions(jsonBytes, &keys, yaml.DisallowUnknownField()); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if key.Column == "" {
+			return nil, fmt.Errorf("column is required")
+		}
+		if key.Order != "" && key.Order != "asc" && key.Order != "desc" {
+			return nil, fmt.Errorf("order of column %s must be asc or desc: %s", key.Column, key.Order)
+		}
+	}
+	return keys, nil
+}
+
+func sortRange(args ExcelSortRangeArguments, keyArgs []sortKeyArgument) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	targetRange, hasHeader := args.Range, args.HasHeader
+	if args.Table != "" {
+		tables, err := worksheet.GetTables()
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range tables {
+			if strings.EqualFold(table.Name, args.Table) {
+				targetRange, hasHeader = table.Range, true
+			}
+		}
+		if targetRange == "" {
+			return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("table not found in sheet %s: %s", args.SheetName, args.Table)), nil
+		}
+	}
+	startCol, startRow, endCol, _, err := excel.ParseRange(targetRange)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header texts take precedence over column names in the keys
+	headers := map[string]string{}
+	if hasHeader {
+		for col := startCol; col <= endCol; col++ {
+			cell, _ := excelize.CoordinatesToCellName(col, startRow)
+			header, err := worksheet.GetValue(cell)
+			if err != nil {
+				return nil, err
+			}
+			columnName, _ := excelize.ColumnNumberToName(col)
+			headers[strings.ToLower(header)] = columnName
+		}
+	}
+	keys := make([]excel.SortKey, len(keyArgs))
+	descriptions := make([]string, len(keyArgs))
+	for i, keyArg := range keyArgs {
+		column, ok := headers[strings.ToLower(keyArg.Column)]
+		if !ok {
+			col, err := excelize.ColumnNameToNumber(keyArg.Column)
+			if err != nil || col < startCol || col > endCol {
+				return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("column not found in %s: %s", targetRange, keyArg.Column)), nil
+			}
+			column, _ = excelize.ColumnNumberToName(col)
+		}
+		keys[i] = excel.SortKey{Column: column, Descending: keyArg.Order == "desc"}
+		order := "ascending"
+		if keys[i].Descending {
+			order = "descending"
+		}
+		descriptions[i] = fmt.Sprintf("%s %s", keyArg.Column, order)
+	}
+
+	err = worksheet.SortRange(&excel.SortOptions{
+		Range:         targetRange,
+		Keys:          keys,
+		HasHeader:     hasHeader,
+		CaseSensitive: args.CaseSensitive,
+		Natural:       args.Natural,
+	})
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	if err := workbook.Save(); err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Range [%s] of sheet [%s] sorted by %s.\n", targetRange, args.SheetName, strings.Join(descriptions, ", "))
+	return mcp.NewToolResultText(result), nil
+}
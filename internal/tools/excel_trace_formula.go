@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	z "github.com/Oudwins/zog"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+	imcp "github.com/vKenjo/ms-excel-mcp-server/internal/mcp"
+)
+
+type ExcelTraceFormulaArguments struct {
+	FileAbsolutePath string `zog:"fileAbsolutePath"`
+	SheetName        string `zog:"sheetName"`
+	Cell             string `zog:"cell"`
+	Direction        string `zog:"direction"`
+	Depth            int    `zog:"depth"`
+}
+
+var excelTraceFormulaArgumentsSchema = z.Struct(z.Schema{
+	"fileAbsolutePath": z.String().Test(AbsolutePathTest()).Required(),
+	"sheetName":        z.String().Required(),
+	"cell":             z.String().Required(),
+	"direction":        z.String().OneOf([]string{"precedents", "dependents", "both"}).Default("both"),
+	"depth":            z.Int().GTE(1).LTE(10).Default(3),
+})
+
+func AddExcelTraceFormulaTool(server *server.MCPServer) {
+	server.AddTool(mcp.NewTool("excel_trace_formula",
+		mcp.WithDescription("Trace where the value of a cell comes from (precedents) and which formulas use it (dependents) as trees of cells, ranges, defined names and tables across the sheets of the Excel file"),
+		mcp.WithString("fileAbsolutePath",
+			mcp.Required(),
+			mcp.Description("Absolute path to the Excel file"),
+		),
+		mcp.WithString("sheetName",
+			mcp.Required(),
+			mcp.Description("Sheet name in the Excel file"),
+		),
+		mcp.WithString("cell",
+			mcp.Required(),
+			mcp.Description("Cell to trace (e.g., \"B3\")"),
+		),
+		mcp.WithString("direction",
+			mcp.Enum("precedents", "dependents", "both"),
+			mcp.Description("Trace the references in the formula of the cell (precedents), the formulas referring to the cell (dependents), or both [default: both]"),
+		),
+		mcp.WithNumber("depth",
+			mcp.Description("Number of levels to trace, from 1 to 10 [default: 3]"),
+		),
+	), handleTraceFormula)
+}
+
+func handleTraceFormula(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := ExcelTraceFormulaArguments{}
+	if issues := excelTraceFormulaArgumentsSchema.Parse(request.Params.Arguments, &args); len(issues) != 0 {
+		return imcp.NewToolResultZogIssueMap(issues), nil
+	}
+	args.Cell = strings.ToUpper(strings.ReplaceAll(args.Cell, "$", ""))
+	if startCol, startRow, endCol, endRow, err := excel.ParseCellOrRange(args.Cell); err != nil || startCol != endCol || startRow != endRow {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid cell: %s", args.Cell)), nil
+	}
+	return traceFormula(args)
+}
+
+func traceFormula(args ExcelTraceFormulaArguments) (*mcp.CallToolResult, error) {
+	workbook, release, err := excel.OpenFile(args.FileAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	worksheet, err := workbook.FindSheet(args.SheetName)
+	if err != nil {
+		return imcp.NewToolResultInvalidArgumentError(err.Error()), nil
+	}
+	defer worksheet.Release()
+
+	trace, err := worksheet.TraceFormula(args.Cell, args.Depth)
+	if err != nil {
+		return nil, err
+	}
+
+	result := "# Notice\n"
+	result += fmt.Sprintf("backend: %s\n", workbook.GetBackendName())
+	result += fmt.Sprintf("Traced cell [%s] up to depth %d.\n", trace.Cell, args.Depth)
+	result += "# Cell\n"
+	result += formatTraceNode(&excel.FormulaTraceNode{
+		Kind:      excel.FormulaReferenceCell,
+		Reference: trace.Cell,
+		Formula:   trace.Formula,
+		Value:     trace.Value,
+	}, 0)
+	if args.Direction != "dependents" {
+		result += "# Precedents\n"
+		if trace.Formula == "" {
+			result += "The cell has no formula.\n"
+		} else if len(trace.Precedents) == 0 {
+			result += "The formula has no references.\n"
+		}
+		result += formatTraceTree(trace.Precedents, 0)
+	}
+	if args.Direction != "precedents" {
+		result += "# Dependents\n"
+		if len(trace.Dependents) == 0 {
+			result += "No formula refers to the cell.\n"
+		}
+		result += formatTraceTree(trace.Dependents, 0)
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// formatTraceTree formats the nodes as a nested list indented by the level
+func formatTraceTree(nodes []*excel.FormulaTraceNode, level int) string {
+	result := ""
+	for _, node := range nodes {
+		result += formatTraceNode(node, level)
+		result += formatTraceTree(node.Children, level+1)
+	}
+	return result
+}
+
+// formatTraceNode formats the node as a list item (e.g., "- Sheet1!B3: 120 (formula: =SUM(B1:B2))")
+func formatTraceNode(node *excel.FormulaTraceNode, level int) string {
+	line := strings.Repeat("  ", level) + "- "
+	if node.Kind == excel.FormulaReferenceCell {
+		line += fmt.Sprintf("%s: %s", node.Reference, node.Value)
+		if node.Formula != "" {
+			line += fmt.Sprintf(" (formula: %s)", node.Formula)
+		}
+	} else {
+		line += fmt.Sprintf("%s %s", node.Kind, node.Reference)
+		if node.Formula != "" {
+			line += fmt.Sprintf(" (refers to: %s)", node.Formula)
+		}
+	}
+	if node.Circular {
+		line += " [circular reference]"
+	}
+	if node.Truncated {
+		line += " [more levels beyond depth]"
+	}
+	return line + "\n"
+}
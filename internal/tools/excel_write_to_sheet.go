@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	z "github.com/Oudwins/zog"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,6 +19,8 @@ type ExcelWriteToSheetArguments struct {
 	NewSheet         bool       `zog:"newSheet"`
 	Range            string     `zog:"range"`
 	Values           [][]string `zog:"values"`
+	TypeHint         string     `zog:"typeHint"`
+	ColumnTypeHints  []string   `zog:"columnTypeHints"`
 }
 
 var excelWriteToSheetArgumentsSchema = z.Struct(z.Schema{
@@ -26,8 +29,14 @@ var excelWriteToSheetArgumentsSchema = z.Struct(z.Schema{
 	"newSheet":         z.Bool().Required().Default(false),
 	"range":            z.String().Required(),
 	"values":           z.Slice(z.Slice(z.String())).Required(),
+	"typeHint":         z.String().OneOf(typeHints).Default("text"),
+	"columnTypeHints":  z.Slice(z.String().OneOf(append([]string{""}, typeHints...))),
 })
 
+// typeHints are the hints how to write the strings: as they are (text), as the ISO 8601 dates, times or durations
+// detected (auto), or as the kind of values
+var typeHints = []string{"text", "auto", "date", "datetime", "time", "duration"}
+
 func AddExcelWriteToSheetTool(server *server.MCPServer) {
 	server.AddTool(mcp.NewTool("excel_write_to_sheet",
 		mcp.WithDescription("Write values to the Excel sheet"),
@@ -70,6 +79,14 @@ func AddExcelWriteToSheetTool(server *server.MCPServer) {
 				},
 			}),
 		),
+		mcp.WithString("typeHint",
+			mcp.Enum(typeHints...),
+			mcp.Description("How to write the strings: as they are (text), as the ISO 8601 dates, datetimes, times or durations detected (auto), or as the kind of values (date, datetime, time, duration), which fails for the strings of the other forms. Dates and times are written as serial numbers in the date system of the workbook with the number formats yyyy-mm-dd, yyyy-mm-dd hh:mm:ss, hh:mm:ss or [h]:mm:ss. Durations are in days, hours, minutes and seconds (e.g., P1DT2H30M). Time zone offsets are ignored. [default: text]"),
+		),
+		mcp.WithArray("columnTypeHints",
+			mcp.Items(map[string]any{"type": "string", "enum": append([]string{""}, typeHints...)}),
+			mcp.Description("Type hints of the columns of the range, which override typeHint. An empty string uses typeHint."),
+		),
 	), handleWriteToSheet)
 }
 
@@ -94,10 +111,10 @@ func handleWriteToSheet(ctx context.Context, request mcp.CallToolRequest) (*mcp.
 		values[i] = value
 	}
 
-	return writeSheet(args.FileAbsolutePath, args.SheetName, args.NewSheet, args.Range, values)
+	return writeSheet(args.FileAbsolutePath, args.SheetName, args.NewSheet, args.Range, values, args.TypeHint, args.ColumnTypeHints)
 }
 
-func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeStr string, values [][]any) (*mcp.CallToolResult, error) {
+func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeStr string, values [][]any, typeHint string, columnTypeHints []string) (*mcp.CallToolResult, error) {
 	workbook, closeFn, err := excel.OpenFile(fileAbsolutePath)
 	if err != nil {
 		return nil, err
@@ -114,6 +131,22 @@ func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeS
 	if len(values) != rangeRowSize {
 		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("number of rows in data (%d) does not match range size (%d)", len(values), rangeRowSize)), nil
 	}
+	if len(columnTypeHints) > 0 && len(columnTypeHints) != endCol-startCol+1 {
+		return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("number of columnTypeHints (%d) does not match range size (%d)", len(columnTypeHints), endCol-startCol+1)), nil
+	}
+	columnHints := make([]string, endCol-startCol+1)
+	for i := range columnHints {
+		columnHints[i] = typeHint
+		if i < len(columnTypeHints) && columnTypeHints[i] != "" {
+			columnHints[i] = columnTypeHints[i]
+		}
+	}
+	date1904 := false
+	if slices.ContainsFunc(columnHints, func(hint string) bool { return hint != "text" }) {
+		if date1904, err = workbook.Date1904(); err != nil {
+			return nil, err
+		}
+	}
 
 	if newSheet {
 		if err := workbook.CreateNewSheet(sheetName); err != nil {
@@ -130,6 +163,7 @@ func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeS
 
 	// データの書き込み
 	wroteFormula := false
+	wroteDates := 0
 	for i, row := range values {
 		rangeColumnSize := endCol - startCol + 1
 		if len(row) != rangeColumnSize {
@@ -140,10 +174,21 @@ func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeS
 			if err != nil {
 				return nil, err
 			}
+			dateValue, err := parseDateValueWithHint(cellValue, columnHints[j])
+			var serial float64
+			if err == nil && dateValue != nil {
+				serial, err = dateValue.Serial(date1904)
+			}
+			if err != nil {
+				return imcp.NewToolResultInvalidArgumentError(fmt.Sprintf("invalid value in cell %s: %v", cell, err)), nil
+			}
 			if cellStr, ok := cellValue.(string); ok && isFormula(cellStr) {
 				// if cellValue is formula, set it as formula
 				err = worksheet.SetFormula(cell, cellStr)
 				wroteFormula = true
+			} else if dateValue != nil {
+				err = writeDateValue(worksheet, cell, serial, dateValue.NumFmt())
+				wroteDates++
 			} else {
 				// if cellValue is not formula, set it as value
 				err = worksheet.SetValue(cell, cellValue)
@@ -161,9 +206,9 @@ func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeS
 	// HTMLテーブルの生成
 	var table *string
 	if wroteFormula {
-		table, err = CreateHTMLTableOfFormula(worksheet, startCol, startRow, endCol, endRow)
+		table, err = CreateHTMLTableOfFormula(worksheet, startCol, startRow, endCol, endRow, HTMLTableOptions{})
 	} else {
-		table, err = CreateHTMLTableOfValues(worksheet, startCol, startRow, endCol, endRow)
+		table, err = CreateHTMLTableOfValues(worksheet, startCol, startRow, endCol, endRow, HTMLTableOptions{})
 	}
 	if err != nil {
 		return nil, err
@@ -178,10 +223,42 @@ func writeSheet(fileAbsolutePath string, sheetName string, newSheet bool, rangeS
 	html += "</ul>\n"
 	html += "<h2>Notice</h2>\n"
 	html += "<p>Values wrote successfully.</p>\n"
+	if wroteDates > 0 {
+		dateSystem := "1900"
+		if date1904 {
+			dateSystem = "1904"
+		}
+		html += fmt.Sprintf("<p>%d values were written as dates, times or durations in the %s date system.</p>\n", wroteDates, dateSystem)
+	}
 
 	return mcp.NewToolResultText(html), nil
 }
 
+// parseDateValueWithHint parses the string value as a date, time or duration by the type hint.
+// It returns nil for the values to write as they are.
+func parseDateValueWithHint(value any, hint string) (*excel.DateValue, error) {
+	text, ok := value.(string)
+	if !ok || text == "" || isFormula(text) || hint == "text" {
+		return nil, nil
+	}
+	if hint == "auto" {
+		dateValue, err := excel.ParseDateValue(text, "")
+		if err != nil {
+			return nil, nil
+		}
+		return dateValue, nil
+	}
+	return excel.ParseDateValue(text, excel.DateValueKind(hint))
+}
+
+// writeDateValue writes the serial number of a date, time or duration with the number format for its kind
+func writeDateValue(worksheet excel.Worksheet, cell string, serial float64, numFmt string) error {
+	if err := worksheet.SetValue(cell, serial); err != nil {
+		return err
+	}
+	return worksheet.SetCellStyle(cell, &excel.CellStyle{NumFmt: numFmt})
+}
+
 func isFormula(value string) bool {
 	return len(value) > 0 && value[0] == '='
 }
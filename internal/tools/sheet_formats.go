@@ -0,0 +1,278 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/vKenjo/ms-excel-mcp-server/internal/excel"
+)
+
+// sheetPage is the paging metadata of the structured output formats of excel_read_sheet
+type sheetPage struct {
+	Backend     string  `json:"backend"`
+	SheetName   string  `json:"sheetName"`
+	Range       string  `json:"range"`
+	DefinedName string  `json:"definedName,omitempty"`
+	NextRange   *string `json:"nextRange"` // null if this is the last range
+}
+
+// sheetCell is a cell in the json output format
+type sheetCell struct {
+	Cell  string              `json:"cell"`
+	Type  excel.CellValueType `json:"type"`
+	Value string              `json:"value"`
+	// Raw is the underlying value such as the serial number of a date, which is omitted if it is the same as the value
+	Raw       string `json:"raw,omitempty"`
+	Formula   string `json:"formula,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	Hyperlink string `json:"hyperlink,omitempty"`
+}
+
+// sheetCellsDocument is the document of the json output format, which has the cells of the range in rows
+type sheetCellsDocument struct {
+	sheetPage
+	Rows [][]sheetCell `json:"rows"`
+}
+
+// sheetRecord is a row keyed by the header, which keeps the order of the columns in JSON
+type sheetRecord struct {
+	keys   []string
+	values []any
+}
+
+func (r sheetRecord) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range r.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		if err := encodeJSON(&buffer, key); err != nil {
+			return nil, err
+		}
+		buffer.WriteByte(':')
+		if err := encodeJSON(&buffer, r.values[i]); err != nil {
+			return nil, err
+		}
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// sheetRecordsDocument is the document of the records output format
+type sheetRecordsDocument struct {
+	sheetPage
+	HeaderRow int      `json:"headerRow"`
+	Header    []string `json:"header"`
+	// ColumnTypes are the types of the values in the columns, which are "mixed" for the columns having values of several types
+	ColumnTypes []excel.CellValueType `json:"columnTypes"`
+	Records     []sheetRecord         `json:"records"`
+}
+
+// cellValueTypeMixed is the type of the columns having values of several types in the records output format
+const cellValueTypeMixed excel.CellValueType = "mixed"
+
+// recordValue converts the typed value into a JSON value. Numbers and booleans are JSON numbers and booleans,
+// empty cells are null, and the others are the formatted text.
+func recordValue(typedValue *excel.TypedValue) any {
+	switch typedValue.Type {
+	case excel.CellValueEmpty:
+		return nil
+	case excel.CellValueNumber:
+		if number, err := strconv.ParseFloat(typedValue.Raw, 64); err == nil {
+			return number
+		}
+	case excel.CellValueBoolean:
+		return typedValue.Raw == "TRUE"
+	}
+	return typedValue.Formatted
+}
+
+// encodeJSON writes the value in compact JSON without escaping HTML characters
+func encodeJSON(buffer *bytes.Buffer, value any) error {
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	// Encode terminates the value with a newline
+	buffer.Truncate(buffer.Len() - 1)
+	return nil
+}
+
+func marshalSheetDocument(document any) (string, error) {
+	var buffer bytes.Buffer
+	if err := encodeJSON(&buffer, document); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// createJSONOfCells creates a JSON document having the cells of the range in rows.
+// Formulas, comments and hyperlinks of the cells are included on request.
+func createJSONOfCells(worksheet excel.Worksheet, page sheetPage, startCol int, startRow int, endCol int, endRow int, showFormula bool, options HTMLTableOptions) (string, error) {
+	annotations, err := getCellAnnotations(worksheet, options)
+	if err != nil {
+		return "", err
+	}
+	document := sheetCellsDocument{sheetPage: page, Rows: [][]sheetCell{}}
+	for row := startRow; row <= endRow; row++ {
+		cells := []sheetCell{}
+		for col := startCol; col <= endCol; col++ {
+			axis, _ := excelize.CoordinatesToCellName(col, row)
+			cell := sheetCell{Cell: axis, Type: excel.CellValueEmpty}
+			if typedValue, err := worksheet.GetTypedValue(axis); err == nil {
+				cell.Type, cell.Value = typedValue.Type, typedValue.Formatted
+				if typedValue.Raw != typedValue.Formatted {
+					cell.Raw = typedValue.Raw
+				}
+			}
+			if showFormula {
+				// GetFormula falls back to the value for the cells without formulas
+				if formula, _ := worksheet.GetFormula(axis); strings.HasPrefix(formula, "=") {
+					cell.Formula = formula
+				}
+			}
+			if comment, ok := annotations.comments[axis]; ok {
+				cell.Comment = comment.Text
+			}
+			if annotations.hyperlinks != nil {
+				if hyperlink, err := annotations.hyperlinks(axis); err == nil && hyperlink != nil {
+					cell.Hyperlink = hyperlinkHref(hyperlink)
+				}
+			}
+			cells = append(cells, cell)
+		}
+		document.Rows = append(document.Rows, cells)
+	}
+	return marshalSheetDocument(document)
+}
+
+// createJSONOfRecords creates a JSON document having the rows of the range as objects keyed by the header row.
+// The header row is skipped if it is in the range. Empty headers are replaced with the column names,
+// and duplicate headers are suffixed with the column names.
+func createJSONOfRecords(worksheet excel.Worksheet, page sheetPage, startCol int, startRow int, endCol int, endRow int, headerRow int, showFormula bool) (string, error) {
+	header := []string{}
+	used := map[string]bool{}
+	for col := startCol; col <= endCol; col++ {
+		axis, _ := excelize.CoordinatesToCellName(col, headerRow)
+		name, _ := worksheet.GetValue(axis)
+		columnName, _ := excelize.ColumnNumberToName(col)
+		if name == "" {
+			name = columnName
+		} else if used[name] {
+			name = fmt.Sprintf("%s_%s", name, columnName)
+		}
+		used[name] = true
+		header = append(header, name)
+	}
+	document := sheetRecordsDocument{sheetPage: page, HeaderRow: headerRow, Header: header, Records: []sheetRecord{}}
+	document.ColumnTypes = make([]excel.CellValueType, len(header))
+	for i := range document.ColumnTypes {
+		document.ColumnTypes[i] = excel.CellValueEmpty
+	}
+	for row := startRow; row <= endRow; row++ {
+		if row == headerRow {
+			continue
+		}
+		record := sheetRecord{keys: header}
+		for col := startCol; col <= endCol; col++ {
+			axis, _ := excelize.CoordinatesToCellName(col, row)
+			typedValue, err := worksheet.GetTypedValue(axis)
+			if err != nil {
+				typedValue = &excel.TypedValue{Type: excel.CellValueEmpty}
+			}
+			var value any = recordValue(typedValue)
+			if showFormula {
+				value, _ = worksheet.GetFormula(axis)
+			}
+			record.values = append(record.values, value)
+
+			columnType := &document.ColumnTypes[col-startCol]
+			if *columnType == excel.CellValueEmpty {
+				*columnType = typedValue.Type
+			} else if typedValue.Type != excel.CellValueEmpty && typedValue.Type != *columnType {
+				*columnType = cellValueTypeMixed
+			}
+		}
+		document.Records = append(document.Records, record)
+	}
+	return marshalSheetDocument(document)
+}
+
+// readTypedValues reads the typed values of the cells in the range by rows. The cells failing to be read are empty.
+func readTypedValues(worksheet excel.Worksheet, startCol int, startRow int, endCol int, endRow int) [][]*excel.TypedValue {
+	values := [][]*excel.TypedValue{}
+	for row := startRow; row <= endRow; row++ {
+		rowValues := []*excel.TypedValue{}
+		for col := startCol; col <= endCol; col++ {
+			axis, _ := excelize.CoordinatesToCellName(col, row)
+			typedValue, err := worksheet.GetTypedValue(axis)
+			if err != nil {
+				typedValue = &excel.TypedValue{Type: excel.CellValueEmpty}
+			}
+			rowValues = append(rowValues, typedValue)
+		}
+		values = append(values, rowValues)
+	}
+	return values
+}
+
+// createMarkdownTable creates a table in Markdown with the column names and row numbers like the HTML table
+func createMarkdownTable(worksheet excel.Worksheet, values [][]*excel.TypedValue, startCol int, startRow int, showFormula bool) string {
+	var result strings.Builder
+	result.WriteString("| |")
+	for col := range values[0] {
+		name, _ := excelize.ColumnNumberToName(startCol + col)
+		result.WriteString(fmt.Sprintf(" %s |", name))
+	}
+	result.WriteString("\n|---|")
+	result.WriteString(strings.Repeat("---|", len(values[0])))
+	result.WriteString("\n")
+	for row, rowValues := range values {
+		result.WriteString(fmt.Sprintf("| %d |", startRow+row))
+		for col, typedValue := range rowValues {
+			value := typedValue.Formatted
+			if showFormula {
+				axis, _ := excelize.CoordinatesToCellName(startCol+col, startRow+row)
+				value, _ = worksheet.GetFormula(axis)
+			}
+			result.WriteString(fmt.Sprintf(" %s |", escapeMarkdownTableCell(value)))
+		}
+		result.WriteString("\n")
+	}
+	return result.String()
+}
+
+// createMarkdownCellTypes lists the cells of the range by the types other than string and empty
+func createMarkdownCellTypes(values [][]*excel.TypedValue, startCol int, startRow int) string {
+	types := []excel.CellValueType{excel.CellValueNumber, excel.CellValueDate, excel.CellValueBoolean, excel.CellValueError}
+	cells := map[excel.CellValueType][]string{}
+	for row, rowValues := range values {
+		for col, typedValue := range rowValues {
+			axis, _ := excelize.CoordinatesToCellName(startCol+col, startRow+row)
+			cells[typedValue.Type] = append(cells[typedValue.Type], axis)
+		}
+	}
+	var result strings.Builder
+	for _, valueType := range types {
+		if len(cells[valueType]) > 0 {
+			result.WriteString(fmt.Sprintf("- %s: %s\n", valueType, strings.Join(cells[valueType], ", ")))
+		}
+	}
+	result.WriteString("- Other cells having values are strings.\n")
+	return result.String()
+}
+
+// escapeMarkdownTableCell escapes the characters breaking the table and keeps line breaks as <br>
+func escapeMarkdownTableCell(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(value, "\r\n", "<br>")
+	return strings.ReplaceAll(value, "\n", "<br>")
+}